@@ -0,0 +1,108 @@
+package session
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// Typed readers for identity metadata. They are shared by session.Claims and
+// authjwt.Claims so both coerce values the same way:
+//
+//   - strings are read as-is; json.Number keeps its literal text;
+//   - string slices accept []string, or []any whose items are all strings;
+//     a single string is read as a one-item slice;
+//   - ints accept Go integers and integral float64/json.Number values
+//     (encoding/json decodes numbers as float64); strings are not parsed.
+//
+// A missing key, a nil map or a value of another type reports false.
+
+// MetadataString reads key from metadata as a string.
+func MetadataString(metadata map[string]any, key string) (string, bool) {
+	switch v := metadata[key].(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	default:
+		return "", false
+	}
+}
+
+// MetadataStringSlice reads key from metadata as a list of strings.
+func MetadataStringSlice(metadata map[string]any, key string) ([]string, bool) {
+	switch v := metadata[key].(type) {
+	case []string:
+		return v, true
+	case []any:
+		out := make([]string, 0, len(v))
+
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+
+			out = append(out, s)
+		}
+
+		return out, true
+	case string:
+		return []string{v}, true
+	default:
+		return nil, false
+	}
+}
+
+// MetadataInt reads key from metadata as an int.
+func MetadataInt(metadata map[string]any, key string) (int, bool) {
+	switch v := metadata[key].(type) {
+	case int:
+		return v, true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt || v >= math.MaxInt {
+			return 0, false
+		}
+
+		return int(v), true
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return 0, false
+		}
+
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
+
+// MetadataString reads a string from the claims metadata; see the package-level MetadataString.
+func (c *Claims) MetadataString(key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	return MetadataString(c.Metadata, key)
+}
+
+// MetadataStringSlice reads a list of strings from the claims metadata; see the package-level MetadataStringSlice.
+func (c *Claims) MetadataStringSlice(key string) ([]string, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	return MetadataStringSlice(c.Metadata, key)
+}
+
+// MetadataInt reads an int from the claims metadata; see the package-level MetadataInt.
+func (c *Claims) MetadataInt(key string) (int, bool) {
+	if c == nil {
+		return 0, false
+	}
+
+	return MetadataInt(c.Metadata, key)
+}
@@ -2,6 +2,8 @@ package session
 
 import (
 	"context"
+
+	"github.com/shortlink-org/go-sdk/auth/userid"
 )
 
 // Session is a typed string used as a context key for session-related values.
@@ -11,7 +13,9 @@ const (
 	// ContextClaimsKey is the key used to store JWT claims in the context.
 	contextClaimsKey = Session("jwt-claims")
 
-	// ContextUserIDKey is the key used to store the user id in the context.
+	// ContextUserIDKey was the key used to store the user id in the context.
+	//
+	// Deprecated: the user id is stored by package userid; use WithUserID and GetUserID.
 	ContextUserIDKey = Session("user-id")
 )
 
@@ -62,19 +66,19 @@ func GetClaims(ctx context.Context) (*Claims, error) {
 	return nil, ErrSessionNotFound
 }
 
-// WithUserID stores the user ID in the context.
+// WithUserID stores the user ID in the context; see userid.NewContext.
 func WithUserID(ctx context.Context, userID string) context.Context {
-	return context.WithValue(ctx, ContextUserIDKey, userID)
+	return userid.NewContext(ctx, userID)
 }
 
-// GetUserID retrieves the user ID from the context.
+// GetUserID retrieves the user ID from the context; see userid.FromContext.
+// During the deprecation period it falls back to a value stored under ContextUserIDKey.
 func GetUserID(ctx context.Context) (string, error) {
-	userID := ctx.Value(ContextUserIDKey)
-	if userID == nil {
-		return "", ErrUserIDNotFound
+	if uid, ok := userid.FromContext(ctx); ok {
+		return uid, nil
 	}
 
-	if uid, ok := userID.(string); ok {
+	if uid, ok := ctx.Value(ContextUserIDKey).(string); ok {
 		return uid, nil
 	}
 
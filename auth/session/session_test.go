@@ -0,0 +1,37 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGetUserID(t *testing.T) {
+	ctx := WithUserID(context.Background(), "user-1")
+
+	uid, err := GetUserID(ctx)
+	if err != nil || uid != "user-1" {
+		t.Fatalf("expected user-1, got %q (%v)", uid, err)
+	}
+}
+
+func TestGetUserIDFallsBackToDeprecatedKey(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ContextUserIDKey, "legacy-user")
+
+	uid, err := GetUserID(ctx)
+	if err != nil || uid != "legacy-user" {
+		t.Fatalf("expected legacy-user, got %q (%v)", uid, err)
+	}
+
+	// The userid key wins over the deprecated one.
+	uid, err = GetUserID(WithUserID(ctx, "user-1"))
+	if err != nil || uid != "user-1" {
+		t.Fatalf("expected user-1, got %q (%v)", uid, err)
+	}
+}
+
+func TestGetUserIDNotFound(t *testing.T) {
+	if _, err := GetUserID(context.Background()); !errors.Is(err, ErrUserIDNotFound) {
+		t.Fatalf("expected ErrUserIDNotFound, got %v", err)
+	}
+}
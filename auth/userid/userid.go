@@ -0,0 +1,63 @@
+// Package userid stores the authenticated user id in a context.
+//
+// It only depends on the standard library, so packages that carry the user id
+// (e.g. the cqrs message metadata) can read it without pulling in the gRPC and
+// authzed dependencies of the rest of the auth module. session.WithUserID and
+// session.GetUserID use the same context key.
+package userid
+
+import (
+	"context"
+	"sync"
+)
+
+type (
+	contextKey  struct{}
+	recorderKey struct{}
+)
+
+// NewContext returns a copy of ctx carrying userID. It also fills the Recorder
+// of ctx, if any.
+func NewContext(ctx context.Context, userID string) context.Context {
+	if rec, ok := ctx.Value(recorderKey{}).(*Recorder); ok {
+		rec.set(userID)
+	}
+
+	return context.WithValue(ctx, contextKey{}, userID)
+}
+
+// FromContext returns the user id stored in ctx, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(contextKey{}).(string)
+
+	return userID, ok
+}
+
+// Recorder captures the user id that code further down a call chain stores
+// with NewContext. It lets an outer middleware, such as a request logger that
+// runs before authentication, see the user once the inner handler returns.
+type Recorder struct {
+	mu     sync.Mutex
+	userID string
+}
+
+// WithRecorder returns a copy of ctx with a new Recorder attached.
+func WithRecorder(ctx context.Context) (context.Context, *Recorder) {
+	rec := &Recorder{}
+
+	return context.WithValue(ctx, recorderKey{}, rec), rec
+}
+
+// UserID returns the last user id recorded, or "".
+func (r *Recorder) UserID() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.userID
+}
+
+func (r *Recorder) set(userID string) {
+	r.mu.Lock()
+	r.userID = userID
+	r.mu.Unlock()
+}
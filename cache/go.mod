@@ -151,5 +151,6 @@ require (
 
 replace (
 	github.com/shortlink-org/go-sdk/db => ../db //lint:ignore gomoddirectives local development dependency
+	github.com/shortlink-org/go-sdk/http => ../http //lint:ignore gomoddirectives local development dependency
 	github.com/shortlink-org/go-sdk/observability => ../observability //lint:ignore gomoddirectives local development dependency
 )
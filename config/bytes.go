@@ -0,0 +1,83 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/viper"
+)
+
+// ErrInvalidByteSize is returned for byte sizes that ParseBytes cannot read.
+var ErrInvalidByteSize = errors.New("config: invalid byte size")
+
+// byteUnits maps upper-cased unit suffixes to their size: KB, MB, ... are base-10,
+// KiB, MiB, ... are base-2. A bare number or "B" is a number of bytes.
+var byteUnits = map[string]float64{
+	"":    1,
+	"B":   1,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"TB":  1e12,
+	"KIB": 1 << 10,
+	"MIB": 1 << 20,
+	"GIB": 1 << 30,
+	"TIB": 1 << 40,
+}
+
+// GetBytes returns the value associated with the key as a number of bytes,
+// accepting human sizes such as "512", "10MB" or "1GiB" (see ParseBytes).
+// An unset or empty value yields 0.
+func (c *Config) GetBytes(key string) (int64, error) {
+	c.mu.RLock()
+	value := viper.GetString(key)
+	c.mu.RUnlock()
+
+	if strings.TrimSpace(value) == "" {
+		return 0, nil
+	}
+
+	size, err := ParseBytes(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+
+	return size, nil
+}
+
+// ParseBytes parses a human byte size: a non-negative number, optionally fractional,
+// followed by B, KB, MB, GB, TB (powers of 1000) or KiB, MiB, GiB, TiB (powers of 1024).
+// Units are case-insensitive and may be separated from the number by spaces.
+func ParseBytes(value string) (int64, error) {
+	trimmed := strings.TrimSpace(value)
+
+	split := strings.IndexFunc(trimmed, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.'
+	})
+	if split < 0 {
+		split = len(trimmed)
+	}
+
+	number, unit := trimmed[:split], strings.ToUpper(strings.TrimSpace(trimmed[split:]))
+
+	multiplier, ok := byteUnits[unit]
+	if !ok || number == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidByteSize, value)
+	}
+
+	amount, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidByteSize, value)
+	}
+
+	size := amount * multiplier
+	if size >= math.MaxInt64 {
+		return 0, fmt.Errorf("%w: %q overflows int64", ErrInvalidByteSize, value)
+	}
+
+	return int64(size), nil
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int64
+	}{
+		{value: "512", expected: 512},
+		{value: "512B", expected: 512},
+		{value: "10MB", expected: 10_000_000},
+		{value: "10 mb", expected: 10_000_000},
+		{value: "1GiB", expected: 1 << 30},
+		{value: "1.5KiB", expected: 1536},
+		{value: " 2TB ", expected: 2_000_000_000_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseBytes(tt.value)
+			if err != nil {
+				t.Fatalf("ParseBytes(%q) error = %v", tt.value, err)
+			}
+
+			if got != tt.expected {
+				t.Errorf("ParseBytes(%q) = %d, want %d", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseBytesInvalid(t *testing.T) {
+	for _, value := range []string{"", "MB", "ten MB", "10XB", "1.2.3KB", "-5MB", "99999999999TiB"} {
+		t.Run(value, func(t *testing.T) {
+			if _, err := ParseBytes(value); !errors.Is(err, ErrInvalidByteSize) {
+				t.Errorf("ParseBytes(%q) error = %v, want ErrInvalidByteSize", value, err)
+			}
+		})
+	}
+}
+
+func TestGetBytes(t *testing.T) {
+	cfg := &Config{}
+	t.Cleanup(cfg.Reset)
+
+	t.Setenv("MAX_MESSAGE_SIZE", "10MB")
+	t.Setenv("MAX_BODY_SIZE", "lots")
+	viper.AutomaticEnv()
+
+	size, err := cfg.GetBytes("MAX_MESSAGE_SIZE")
+	if err != nil || size != 10_000_000 {
+		t.Errorf("GetBytes(MAX_MESSAGE_SIZE) = %d, %v; want 10000000", size, err)
+	}
+
+	if _, err := cfg.GetBytes("MAX_BODY_SIZE"); !errors.Is(err, ErrInvalidByteSize) {
+		t.Errorf("GetBytes(MAX_BODY_SIZE) error = %v, want ErrInvalidByteSize", err)
+	}
+
+	if size, err := cfg.GetBytes("UNSET_SIZE"); err != nil || size != 0 {
+		t.Errorf("GetBytes(UNSET_SIZE) = %d, %v; want 0, nil", size, err)
+	}
+}
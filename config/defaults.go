@@ -0,0 +1,50 @@
+package config
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// defaults records every default registered through RegisterDefaults or
+// Config.SetDefault so they can be audited in one place.
+var defaults = struct {
+	mu     sync.RWMutex
+	values map[string]any
+}{values: make(map[string]any)}
+
+// RegisterDefaults sets defaults for several keys at once. Packages call it
+// once during setup; the values are used when no other source provides one.
+func RegisterDefaults(values map[string]any) {
+	defaults.mu.Lock()
+	defer defaults.mu.Unlock()
+
+	for key, value := range values {
+		viper.SetDefault(key, value)
+		defaults.values[key] = value
+	}
+}
+
+// Defaults returns a copy of all registered defaults keyed by name,
+// for documentation and validation.
+func Defaults() map[string]any {
+	defaults.mu.RLock()
+	defer defaults.mu.RUnlock()
+
+	return maps.Clone(defaults.values)
+}
+
+func recordDefault(key string, value any) {
+	defaults.mu.Lock()
+	defer defaults.mu.Unlock()
+
+	defaults.values[key] = value
+}
+
+func resetDefaults() {
+	defaults.mu.Lock()
+	defer defaults.mu.Unlock()
+
+	clear(defaults.values)
+}
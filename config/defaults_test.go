@@ -0,0 +1,46 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestRegisterDefaults(t *testing.T) {
+	cfg := &Config{}
+	t.Cleanup(cfg.Reset)
+
+	RegisterDefaults(map[string]any{
+		"TEST_DEFAULTS_PORT": "50051",
+		"TEST_DEFAULTS_TTL":  "5m",
+	})
+
+	if got := cfg.GetString("TEST_DEFAULTS_PORT"); got != "50051" {
+		t.Errorf("expected registered default 50051, got %q", got)
+	}
+
+	t.Setenv("TEST_DEFAULTS_PORT", "6000")
+	viper.AutomaticEnv()
+
+	if got := cfg.GetString("TEST_DEFAULTS_PORT"); got != "6000" {
+		t.Errorf("expected env to override default, got %q", got)
+	}
+
+	cfg.SetDefault("TEST_DEFAULTS_ENABLED", true)
+
+	got := Defaults()
+	for key, want := range map[string]any{
+		"TEST_DEFAULTS_PORT":    "50051",
+		"TEST_DEFAULTS_TTL":     "5m",
+		"TEST_DEFAULTS_ENABLED": true,
+	} {
+		if got[key] != want {
+			t.Errorf("Defaults()[%q] = %v, want %v", key, got[key], want)
+		}
+	}
+
+	got["TEST_DEFAULTS_PORT"] = "changed"
+	if Defaults()["TEST_DEFAULTS_PORT"] != "50051" {
+		t.Error("Defaults must return a copy")
+	}
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ErrMissingKeys is returned by Require when required keys are unset or empty.
+var ErrMissingKeys = errors.New("config: missing required keys")
+
+// Require reports every key that is unset or holds only whitespace, so
+// misconfiguration fails at boot instead of on the first request. Defaults
+// registered with SetDefault count as set. The error wraps ErrMissingKeys and
+// lists all missing keys in the given order.
+func (c *Config) Require(keys ...string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var missing []string
+
+	for _, key := range keys {
+		switch value := viper.Get(key).(type) {
+		case nil:
+			missing = append(missing, key)
+		case string:
+			if strings.TrimSpace(value) == "" {
+				missing = append(missing, key)
+			}
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrMissingKeys, strings.Join(missing, ", "))
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestRequire(t *testing.T) {
+	cfg := &Config{}
+	t.Cleanup(cfg.Reset)
+
+	t.Setenv("TEST_REQUIRE_URL", "https://auth.example.com/jwks")
+	t.Setenv("TEST_REQUIRE_BLANK", "   ")
+	viper.AutomaticEnv()
+	cfg.SetDefault("TEST_REQUIRE_DEFAULTED", 10)
+
+	if err := cfg.Require("TEST_REQUIRE_URL", "TEST_REQUIRE_DEFAULTED"); err != nil {
+		t.Fatalf("Require() = %v, want nil", err)
+	}
+
+	err := cfg.Require("TEST_REQUIRE_MISSING", "TEST_REQUIRE_URL", "TEST_REQUIRE_BLANK")
+	if !errors.Is(err, ErrMissingKeys) {
+		t.Fatalf("Require() = %v, want ErrMissingKeys", err)
+	}
+
+	if want := "config: missing required keys: TEST_REQUIRE_MISSING, TEST_REQUIRE_BLANK"; err.Error() != want {
+		t.Errorf("Require() = %q, want %q", err.Error(), want)
+	}
+}
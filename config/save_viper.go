@@ -1,6 +1,9 @@
 package config
 
 import (
+	"fmt"
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -10,11 +13,13 @@ import (
 
 // SetDefault sets a default value for a key.
 // This value will be used if no other source provides a value.
+// The default is also recorded for Defaults.
 func (c *Config) SetDefault(key string, value any) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	viper.SetDefault(key, value)
+	recordDefault(key, value)
 }
 
 // Set explicitly sets a value for a key at runtime.
@@ -33,12 +38,13 @@ func (c *Config) AutomaticEnv() {
 	viper.AutomaticEnv()
 }
 
-// Reset clears all configuration values.
+// Reset clears all configuration values, including registered defaults.
 func (c *Config) Reset() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	viper.Reset()
+	resetDefaults()
 }
 
 // ----------------- Getters (read-locked) ------------------
@@ -51,6 +57,36 @@ func (c *Config) GetString(key string) string {
 	return viper.GetString(key)
 }
 
+// GetSecret returns the value associated with the key as a string, dereferencing
+// file:/path and env:NAME references (see ResolveSecret). A reference that cannot be
+// resolved is logged and yields an empty string; use GetSecretE to handle the error instead.
+//
+// Resolution is opt-in because plain settings may legitimately start with file:
+// (e.g. SQLite URIs), so read only credentials through GetSecret.
+func (c *Config) GetSecret(key string) string {
+	value, err := c.GetSecretE(key)
+	if err != nil {
+		slog.Warn("config: cannot resolve secret reference", slog.String("key", key), slog.String("error", err.Error()))
+
+		return ""
+	}
+
+	return value
+}
+
+// GetSecretE is GetSecret that reports secret references it cannot resolve.
+func (c *Config) GetSecretE(key string) (string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	value, err := ResolveSecret(viper.GetString(key))
+	if err != nil {
+		return "", fmt.Errorf("config: key %s: %w", key, err)
+	}
+
+	return value, nil
+}
+
 // GetBool returns the value associated with the key as a boolean.
 func (c *Config) GetBool(key string) bool {
 	c.mu.RLock()
@@ -107,6 +143,20 @@ func (c *Config) GetStringSlice(key string) []string {
 	return viper.GetStringSlice(key)
 }
 
+// GetStringSliceCSV returns the value associated with the key as a list of comma-separated
+// entries. Entries are trimmed and empty ones are skipped, so " a , b ," yields [a b].
+// Unlike GetStringSlice, string values are split on commas instead of whitespace.
+func (c *Config) GetStringSliceCSV(key string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if value, ok := viper.Get(key).(string); ok {
+		return SplitCSV(value)
+	}
+
+	return SplitCSV(strings.Join(viper.GetStringSlice(key), ","))
+}
+
 // GetTime returns the value associated with the key as a time.Time.
 func (c *Config) GetTime(key string) time.Time {
 	c.mu.RLock()
@@ -132,3 +182,16 @@ func (c *Config) AllKeys() []string {
 
 	return viper.AllKeys()
 }
+
+// SplitCSV splits a comma-separated list, trimming entries and skipping empty ones.
+func SplitCSV(value string) []string {
+	var entries []string
+
+	for entry := range strings.SplitSeq(value, ",") {
+		if trimmed := strings.TrimSpace(entry); trimmed != "" {
+			entries = append(entries, trimmed)
+		}
+	}
+
+	return entries
+}
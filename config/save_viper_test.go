@@ -0,0 +1,68 @@
+package config
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestGetStringSliceCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []string
+	}{
+		{
+			name:     "single_origin",
+			value:    "https://shortlink.best",
+			expected: []string{"https://shortlink.best"},
+		},
+		{
+			name:     "multiple_origins",
+			value:    "https://shortlink.best,https://www.shortlink.best,http://localhost:3000",
+			expected: []string{"https://shortlink.best", "https://www.shortlink.best", "http://localhost:3000"},
+		},
+		{
+			name:     "origins_with_spaces",
+			value:    " https://shortlink.best , https://www.shortlink.best , http://localhost:3000 ",
+			expected: []string{"https://shortlink.best", "https://www.shortlink.best", "http://localhost:3000"},
+		},
+		{
+			name:     "empty_entries",
+			value:    ",https://shortlink.best,, ,",
+			expected: []string{"https://shortlink.best"},
+		},
+		{
+			name:     "empty_value",
+			value:    "",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{}
+			t.Cleanup(cfg.Reset)
+
+			t.Setenv("TEST_CSV_ORIGINS", tt.value)
+			viper.AutomaticEnv()
+
+			if got := cfg.GetStringSliceCSV("TEST_CSV_ORIGINS"); !slices.Equal(got, tt.expected) {
+				t.Errorf("GetStringSliceCSV() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetStringSliceCSV_SliceValue(t *testing.T) {
+	cfg := &Config{}
+	t.Cleanup(cfg.Reset)
+
+	cfg.SetDefault("TEST_CSV_METHODS", []string{" /a.Service/ ", "", "/b.Service/,/c.Service/"})
+
+	want := []string{"/a.Service/", "/b.Service/", "/c.Service/"}
+	if got := cfg.GetStringSliceCSV("TEST_CSV_METHODS"); !slices.Equal(got, want) {
+		t.Errorf("GetStringSliceCSV() = %q, want %q", got, want)
+	}
+}
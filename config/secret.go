@@ -0,0 +1,63 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Secret reference prefixes understood by GetSecret.
+//
+//	file:/run/secrets/kafka_password    -> contents of the file
+//	file:///run/secrets/kafka_password  -> same, URL form
+//	env:KAFKA_PASSWORD                  -> value of the KAFKA_PASSWORD variable
+//	env://KAFKA_PASSWORD                -> same, URL form
+const (
+	secretFilePrefix = "file:"
+	secretEnvPrefix  = "env:"
+)
+
+var (
+	// ErrInvalidSecretRef is returned for a secret reference without a path or variable name.
+	ErrInvalidSecretRef = errors.New("config: invalid secret reference")
+	// ErrSecretEnvNotSet is returned when an env: reference points to an unset variable.
+	ErrSecretEnvNotSet = errors.New("config: secret environment variable is not set")
+)
+
+// ResolveSecret dereferences file: and env: references, trimming trailing newlines.
+// Values without a known prefix are returned unchanged.
+func ResolveSecret(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, secretFilePrefix):
+		path := strings.TrimPrefix(value, secretFilePrefix)
+		path = strings.TrimPrefix(path, "//")
+
+		if path == "" {
+			return "", fmt.Errorf("%w: %q", ErrInvalidSecretRef, value)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+
+		return strings.TrimRight(string(data), "\r\n"), nil
+	case strings.HasPrefix(value, secretEnvPrefix):
+		name := strings.TrimPrefix(value, secretEnvPrefix)
+		name = strings.TrimPrefix(name, "//")
+
+		if name == "" {
+			return "", fmt.Errorf("%w: %q", ErrInvalidSecretRef, value)
+		}
+
+		secret, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrSecretEnvNotSet, name)
+		}
+
+		return strings.TrimRight(secret, "\r\n"), nil
+	default:
+		return value, nil
+	}
+}
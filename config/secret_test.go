@@ -0,0 +1,107 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestGetSecretResolvesFileSecret(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	path := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(path, []byte("s3cr3t\n"), 0o600); err != nil {
+		t.Fatalf("write secret: %v", err)
+	}
+
+	cfg := &Config{}
+	cfg.Set("KAFKA_SASL_PASSWORD", "file://"+path)
+
+	if got := cfg.GetSecret("KAFKA_SASL_PASSWORD"); got != "s3cr3t" {
+		t.Errorf("expected s3cr3t, got %q", got)
+	}
+
+	cfg.Set("KAFKA_SASL_PASSWORD", "file:"+path)
+
+	if got := cfg.GetSecret("KAFKA_SASL_PASSWORD"); got != "s3cr3t" {
+		t.Errorf("expected s3cr3t, got %q", got)
+	}
+}
+
+func TestGetSecretResolvesEnvSecret(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	t.Setenv("TEST_JWKS_SECRET", "token\r\n")
+
+	cfg := &Config{}
+	cfg.Set("JWKS_SECRET", "env://TEST_JWKS_SECRET")
+
+	if got := cfg.GetSecret("JWKS_SECRET"); got != "token" {
+		t.Errorf("expected token, got %q", got)
+	}
+
+	cfg.Set("JWKS_SECRET", "env:TEST_JWKS_SECRET")
+
+	if got := cfg.GetSecret("JWKS_SECRET"); got != "token" {
+		t.Errorf("expected token, got %q", got)
+	}
+}
+
+func TestGetSecretPassthrough(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	cfg := &Config{}
+	cfg.Set("SERVICE_NAME", "billing")
+
+	if got := cfg.GetSecret("SERVICE_NAME"); got != "billing" {
+		t.Errorf("expected billing, got %q", got)
+	}
+}
+
+func TestGetStringKeepsReferences(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	t.Setenv("TEST_JWKS_SECRET", "token")
+
+	cfg := &Config{}
+	cfg.Set("STORE_SQLITE_PATH", "file:/tmp/links.sqlite?cache=shared")
+	cfg.Set("JWKS_SECRET", "env:TEST_JWKS_SECRET")
+
+	if got := cfg.GetString("STORE_SQLITE_PATH"); got != "file:/tmp/links.sqlite?cache=shared" {
+		t.Errorf("expected the SQLite URI unchanged, got %q", got)
+	}
+
+	if got := cfg.GetString("JWKS_SECRET"); got != "env:TEST_JWKS_SECRET" {
+		t.Errorf("expected the reference unchanged, got %q", got)
+	}
+}
+
+func TestResolveSecretErrors(t *testing.T) {
+	if _, err := ResolveSecret("env:TEST_SECRET_THAT_IS_NOT_SET"); !errors.Is(err, ErrSecretEnvNotSet) {
+		t.Errorf("expected ErrSecretEnvNotSet, got %v", err)
+	}
+
+	if _, err := ResolveSecret("file://"); !errors.Is(err, ErrInvalidSecretRef) {
+		t.Errorf("expected ErrInvalidSecretRef, got %v", err)
+	}
+
+	if _, err := ResolveSecret("file:" + filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestGetSecretEReportsUnresolvedSecret(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	cfg := &Config{}
+	cfg.Set("TEST_UNRESOLVED_SECRET", "env:TEST_SECRET_THAT_IS_NOT_SET")
+
+	if _, err := cfg.GetSecretE("TEST_UNRESOLVED_SECRET"); !errors.Is(err, ErrSecretEnvNotSet) {
+		t.Errorf("expected ErrSecretEnvNotSet, got %v", err)
+	}
+
+	if got := cfg.GetSecret("TEST_UNRESOLVED_SECRET"); got != "" {
+		t.Errorf("expected empty string, got %q", got)
+	}
+}
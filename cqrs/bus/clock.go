@@ -0,0 +1,20 @@
+package bus
+
+import "time"
+
+// Clock abstracts time for the outbox, so tests can control message timestamps
+// and forwarder restart backoffs.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
@@ -3,7 +3,6 @@ package bus
 import (
 	"context"
 	"errors"
-	"fmt"
 
 	wmmessage "github.com/ThreeDotsLabs/watermill/message"
 
@@ -63,6 +62,17 @@ func NewCommandBusWithOptions(
 
 // Send encodes and publishes command with Shortlink metadata and tracing context.
 func (b *CommandBus) Send(ctx context.Context, cmd any) error {
+	return b.send(ctx, cmd, nil)
+}
+
+// SendWithMetadata is Send with extra metadata (e.g. a scheduling hint or
+// idempotency key) merged onto the outgoing message. Keys the marshaler set and
+// the canonical name, content-type, kind and trace keys are never overwritten.
+func (b *CommandBus) SendWithMetadata(ctx context.Context, cmd any, metadata map[string]string) error {
+	return b.send(ctx, cmd, metadata)
+}
+
+func (b *CommandBus) send(ctx context.Context, cmd any, metadata map[string]string) error {
 	if ctx == nil {
 		return errNilContext
 	}
@@ -90,18 +100,24 @@ func (b *CommandBus) Send(ctx context.Context, cmd any) error {
 
 	msg, err := b.marshaler.Marshal(ctx, cmd)
 	if err != nil {
-		return fmt.Errorf("marshal command %T: %w", cmd, err)
+		return &MarshalError{Kind: cqrsmessage.KindCommand, Name: name, Err: err}
 	}
 
 	if msg.Metadata.Get(cqrsmessage.MetadataServiceName) == "" && service != "" {
 		msg.Metadata.Set(cqrsmessage.MetadataServiceName, service)
 	}
 
+	mergeMetadata(msg, metadata)
 	msg.Metadata.Set(cqrsmessage.MetadataMessageKind, string(cqrsmessage.KindCommand))
 
+	b.forwarder.stampOccurredAt(msg)
 	cqrsmessage.SetTrace(ctx, msg)
 
-	return b.publisher.Publish(topic, msg)
+	if err := b.publisher.Publish(topic, msg); err != nil {
+		return &PublishError{Kind: cqrsmessage.KindCommand, Name: name, Topic: topic, Err: err}
+	}
+
+	return nil
 }
 
 // RunForwarder starts the optional outbox forwarder when configured.
@@ -113,6 +129,19 @@ func (b *CommandBus) RunForwarder(ctx context.Context) error {
 	return b.forwarder.Run(ctx)
 }
 
+// ValidateForwarder checks the outbox wiring before rollout without forwarding
+// messages: the forwarder builds, its subscriber can initialize or subscribe to the
+// forwarder topic, and the real publisher accepts a probe on OutboxConfig.ProbeTopic.
+// The returned error wraps ErrForwarderValidation once per failing stage.
+// A bus without WithOutbox always validates.
+func (b *CommandBus) ValidateForwarder(ctx context.Context) error {
+	if b == nil || b.forwarder == nil {
+		return nil
+	}
+
+	return b.forwarder.Validate(ctx)
+}
+
 // CloseForwarder attempts to stop previously started forwarder gracefully.
 func (b *CommandBus) CloseForwarder(ctx context.Context) error {
 	if b == nil || b.forwarder == nil {
@@ -122,6 +151,27 @@ func (b *CommandBus) CloseForwarder(ctx context.Context) error {
 	return b.forwarder.Close(ctx)
 }
 
+// ForwarderHealthy reports whether the outbox forwarder initialized and is running.
+// A bus without WithOutbox is always healthy. Calling it before RunForwarder
+// surfaces forwarder initialization errors early.
+func (b *CommandBus) ForwarderHealthy() (bool, error) {
+	if b == nil || b.forwarder == nil {
+		return true, nil
+	}
+
+	return b.forwarder.Healthy()
+}
+
+// ForwarderCheck adapts ForwarderHealthy to a readiness check; the returned
+// function is assignable to healthcheck.Check.
+func (b *CommandBus) ForwarderCheck() func() error {
+	return func() error {
+		_, err := b.ForwarderHealthy()
+
+		return err
+	}
+}
+
 // validate checks that the command bus and its dependencies are properly initialized.
 func (b *CommandBus) validate(cmd any) error {
 	if b == nil {
@@ -1,6 +1,11 @@
 package bus
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+)
 
 var (
 	errNilContext                 = errors.New("cqrs/bus: context must not be nil")
@@ -10,6 +15,61 @@ var (
 	errOutboxMissingRealPublisher = errors.New("cqrs/bus: real publisher is required")
 	errOutboxMissingLogger        = errors.New("cqrs/bus: logger is required")
 	errOutboxMissingMeterProvider = errors.New("cqrs/bus: meter provider is required")
+	errOutboxAdapterPair          = errors.New("cqrs/bus: outbox schema and offsets adapters must be set together")
+	errOutboxDialectMismatch      = errors.New("cqrs/bus: outbox adapters use mismatched SQL dialects")
 	errForwarderNotConfigured     = errors.New("cqrs/bus: outbox forwarder is not configured")
 	errNilTxOutboxConfig          = errors.New("cqrs/bus: transactional outbox config is nil")
 )
+
+// ErrForwarderNotRunning is reported by ForwarderHealthy when the outbox forwarder
+// has not been started yet or has stopped.
+var ErrForwarderNotRunning = errors.New("cqrs/bus: outbox forwarder is not running")
+
+// ErrForwarderValidation wraps each failing stage reported by ValidateForwarder.
+var ErrForwarderValidation = errors.New("cqrs/bus: outbox forwarder validation failed")
+
+// MarshalError reports that a command or event could not be encoded or decoded.
+type MarshalError struct {
+	Kind cqrsmessage.MessageKind
+	Name string
+	Err  error
+}
+
+func (e *MarshalError) Error() string {
+	return fmt.Sprintf("cqrs/bus: marshal %s %s: %v", e.Kind, e.Name, e.Err)
+}
+
+func (e *MarshalError) Unwrap() error {
+	return e.Err
+}
+
+// PublishError reports that the transport rejected an encoded message.
+type PublishError struct {
+	Kind  cqrsmessage.MessageKind
+	Name  string
+	Topic string
+	Err   error
+}
+
+func (e *PublishError) Error() string {
+	return fmt.Sprintf("cqrs/bus: publish %s %s to %s: %v", e.Kind, e.Name, e.Topic, e.Err)
+}
+
+func (e *PublishError) Unwrap() error {
+	return e.Err
+}
+
+// HandlerError reports that a received message could not be dispatched or its handler failed.
+type HandlerError struct {
+	Kind cqrsmessage.MessageKind
+	Name string
+	Err  error
+}
+
+func (e *HandlerError) Error() string {
+	return fmt.Sprintf("cqrs/bus: handle %s %s: %v", e.Kind, e.Name, e.Err)
+}
+
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
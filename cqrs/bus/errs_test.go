@@ -0,0 +1,77 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/require"
+
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+)
+
+var (
+	errTestMarshal = errors.New("marshal boom")
+	errTestPublish = errors.New("publish boom")
+)
+
+type createOrder struct {
+	ID string `json:"id"`
+}
+
+type failingMarshaler struct {
+	cqrsmessage.Marshaler
+}
+
+func (failingMarshaler) Marshal(context.Context, any) (*wmmessage.Message, error) {
+	return nil, errTestMarshal
+}
+
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(string, ...*wmmessage.Message) error { return errTestPublish }
+func (failingPublisher) Close() error                                { return nil }
+
+func TestCommandBusSendReturnsMarshalError(t *testing.T) {
+	namer := cqrsmessage.NewShortlinkNamer("orders")
+	cmdBus := NewCommandBus(failingPublisher{}, failingMarshaler{}, namer)
+
+	err := cmdBus.Send(context.Background(), &createOrder{ID: "1"})
+
+	var marshalErr *MarshalError
+	require.ErrorAs(t, err, &marshalErr)
+	require.Equal(t, cqrsmessage.KindCommand, marshalErr.Kind)
+	require.Equal(t, namer.CommandName(&createOrder{}), marshalErr.Name)
+	require.ErrorIs(t, err, errTestMarshal)
+}
+
+func TestCommandBusSendReturnsPublishError(t *testing.T) {
+	namer := cqrsmessage.NewShortlinkNamer("orders")
+	cmdBus := NewCommandBus(failingPublisher{}, cqrsmessage.NewJSONMarshaler(namer), namer)
+
+	err := cmdBus.Send(context.Background(), &createOrder{ID: "1"})
+
+	var publishErr *PublishError
+	require.ErrorAs(t, err, &publishErr)
+	require.Equal(t, cqrsmessage.KindCommand, publishErr.Kind)
+	require.Equal(t, namer.TopicForCommand(publishErr.Name), publishErr.Topic)
+	require.ErrorIs(t, err, errTestPublish)
+}
+
+func TestEventBusPublishReturnsTypedErrors(t *testing.T) {
+	namer := cqrsmessage.NewShortlinkNamer("orders")
+
+	evtBus := NewEventBus(failingPublisher{}, failingMarshaler{}, namer)
+
+	var marshalErr *MarshalError
+	require.ErrorAs(t, evtBus.Publish(context.Background(), &createOrder{ID: "1"}), &marshalErr)
+	require.Equal(t, cqrsmessage.KindEvent, marshalErr.Kind)
+
+	evtBus = NewEventBus(failingPublisher{}, cqrsmessage.NewJSONMarshaler(namer), namer)
+
+	var publishErr *PublishError
+	require.ErrorAs(t, evtBus.Publish(context.Background(), &createOrder{ID: "1"}), &publishErr)
+	require.Equal(t, cqrsmessage.KindEvent, publishErr.Kind)
+	require.ErrorIs(t, publishErr, errTestPublish)
+}
@@ -69,6 +69,18 @@ func NewEventBusWithOptions(
 // Publish sends event using canonical topic name.
 // Optional PublishOption(s) apply to this call only; e.g. WithPublisher(txPublisher) for transactional outbox.
 func (b *EventBus) Publish(ctx context.Context, evt any, opts ...PublishOption) error {
+	return b.publish(ctx, evt, nil, opts)
+}
+
+// PublishWithMetadata is Publish with extra metadata (e.g. a partition or
+// idempotency key) merged onto the outgoing message. Keys the marshaler set and
+// the canonical name, content-type, kind and trace keys are never overwritten;
+// WithAggregateID takes precedence over an aggregate ID passed here.
+func (b *EventBus) PublishWithMetadata(ctx context.Context, evt any, metadata map[string]string, opts ...PublishOption) error {
+	return b.publish(ctx, evt, metadata, opts)
+}
+
+func (b *EventBus) publish(ctx context.Context, evt any, metadata map[string]string, opts []PublishOption) error {
 	if ctx == nil {
 		return errNilContext
 	}
@@ -138,18 +150,28 @@ func (b *EventBus) Publish(ctx context.Context, evt any, opts ...PublishOption)
 
 	msg, err := b.marshaler.Marshal(ctx, evt)
 	if err != nil {
-		return fmt.Errorf("marshal event %T: %w", evt, err)
+		return &MarshalError{Kind: cqrsmessage.KindEvent, Name: name, Err: err}
 	}
 
 	if msg.Metadata.Get(cqrsmessage.MetadataServiceName) == "" && service != "" {
 		msg.Metadata.Set(cqrsmessage.MetadataServiceName, service)
 	}
 
+	mergeMetadata(msg, metadata)
 	msg.Metadata.Set(cqrsmessage.MetadataMessageKind, string(cqrsmessage.KindEvent))
 
+	if pubOpts.aggregateID != "" {
+		msg.Metadata.Set(cqrsmessage.MetadataAggregateID, pubOpts.aggregateID)
+	}
+
+	b.forwarder.stampOccurredAt(msg)
 	cqrsmessage.SetTrace(ctx, msg)
 
-	return publisher.Publish(topic, msg)
+	if err := publisher.Publish(topic, msg); err != nil {
+		return &PublishError{Kind: cqrsmessage.KindEvent, Name: name, Topic: topic, Err: err}
+	}
+
+	return nil
 }
 
 // RunForwarder starts the optional outbox forwarder when configured.
@@ -161,6 +183,19 @@ func (b *EventBus) RunForwarder(ctx context.Context) error {
 	return b.forwarder.Run(ctx)
 }
 
+// ValidateForwarder checks the outbox wiring before rollout without forwarding
+// messages: the forwarder builds, its subscriber can initialize or subscribe to the
+// forwarder topic, and the real publisher accepts a probe on OutboxConfig.ProbeTopic.
+// The returned error wraps ErrForwarderValidation once per failing stage.
+// A bus without WithOutbox always validates.
+func (b *EventBus) ValidateForwarder(ctx context.Context) error {
+	if b == nil || b.forwarder == nil {
+		return nil
+	}
+
+	return b.forwarder.Validate(ctx)
+}
+
 // CloseForwarder stops the optional forwarder if it was started.
 func (b *EventBus) CloseForwarder(ctx context.Context) error {
 	if b == nil || b.forwarder == nil {
@@ -170,6 +205,27 @@ func (b *EventBus) CloseForwarder(ctx context.Context) error {
 	return b.forwarder.Close(ctx)
 }
 
+// ForwarderHealthy reports whether the outbox forwarder initialized and is running.
+// A bus without WithOutbox is always healthy. Calling it before RunForwarder
+// surfaces forwarder initialization errors early.
+func (b *EventBus) ForwarderHealthy() (bool, error) {
+	if b == nil || b.forwarder == nil {
+		return true, nil
+	}
+
+	return b.forwarder.Healthy()
+}
+
+// ForwarderCheck adapts ForwarderHealthy to a readiness check; the returned
+// function is assignable to healthcheck.Check.
+func (b *EventBus) ForwarderCheck() func() error {
+	return func() error {
+		_, err := b.ForwarderHealthy()
+
+		return err
+	}
+}
+
 // validate checks that the event bus and its dependencies are properly initialized.
 // When only WithTxAwareOutbox is used (no forwarder), publisher may be nil.
 func (b *EventBus) validate(evt any) error {
@@ -0,0 +1,144 @@
+//go:build integration
+
+package bus_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	wmsql "github.com/ThreeDotsLabs/watermill-sql/v4/pkg/sql"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	"github.com/shortlink-org/go-sdk/cqrs/bus"
+	"github.com/shortlink-org/go-sdk/cqrs/message"
+	"github.com/shortlink-org/go-sdk/logger"
+)
+
+func setupMySQL(t *testing.T) *sql.DB {
+	t.Helper()
+	ctx := context.Background()
+
+	container, err := testcontainers.Run(ctx,
+		"mysql:8.4",
+		testcontainers.WithExposedPorts("3306/tcp"),
+		testcontainers.WithEnv(map[string]string{
+			"MYSQL_DATABASE":      "testdb",
+			"MYSQL_USER":          "testuser",
+			"MYSQL_PASSWORD":      "testpass",
+			"MYSQL_ROOT_PASSWORD": "testpass",
+		}),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("port: 3306  MySQL Community Server").WithStartupTimeout(2*time.Minute),
+		),
+	)
+	testcontainers.CleanupContainer(t, container)
+	require.NoError(t, err, "mysql container: ensure Docker is running")
+
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+
+	port, err := container.MappedPort(ctx, "3306/tcp")
+	require.NoError(t, err)
+
+	db, err := sql.Open("mysql", fmt.Sprintf("testuser:testpass@tcp(%s:%s)/testdb?parseTime=true", host, port.Port()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	require.NoError(t, db.PingContext(ctx))
+
+	return db
+}
+
+func TestIntegration_CommandBus_MySQLOutbox(t *testing.T) {
+	db := setupMySQL(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
+	defer cancel()
+
+	wmLogger := watermill.NewStdLogger(false, false)
+
+	sqlPub, err := wmsql.NewPublisher(wmsql.BeginnerFromStdSQL(db), wmsql.PublisherConfig{
+		SchemaAdapter:        wmsql.DefaultMySQLSchema{},
+		AutoInitializeSchema: true,
+	}, wmLogger)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlPub.Close() })
+
+	// The SDK does not manage the outbox schema; provision it like a migration would.
+	schemaSub, err := wmsql.NewSubscriber(wmsql.BeginnerFromStdSQL(db), wmsql.SubscriberConfig{
+		SchemaAdapter:    wmsql.DefaultMySQLSchema{},
+		OffsetsAdapter:   wmsql.DefaultMySQLOffsetsAdapter{},
+		InitializeSchema: true,
+	}, wmLogger)
+	require.NoError(t, err)
+	require.NoError(t, schemaSub.SubscribeInitialize(forwarderTopic))
+	require.NoError(t, schemaSub.Close())
+
+	realPub := gochannel.NewGoChannel(gochannel.Config{}, wmLogger)
+	namer := message.NewShortlinkNamer(serviceName)
+	marshaler := message.NewJSONMarshaler(namer)
+
+	cfg := logger.Default()
+	cfg.Writer = io.Discard
+	cfg.Level = logger.WARN_LEVEL
+	log, err := logger.New(cfg)
+	require.NoError(t, err)
+
+	// No Subscriber: the outbox builds it from the MySQL adapters.
+	cmdBus, err := bus.NewCommandBusWithOptions(sqlPub, marshaler, namer,
+		bus.WithOutbox(&bus.OutboxConfig{
+			DB:             db,
+			SchemaAdapter:  wmsql.DefaultMySQLSchema{},
+			OffsetsAdapter: wmsql.DefaultMySQLOffsetsAdapter{},
+			RealPublisher:  realPub,
+			ForwarderName:  forwarderTopic,
+			Logger:         log,
+			MeterProvider:  noop.NewMeterProvider(),
+		}),
+	)
+	require.NoError(t, err)
+
+	cmdTopic := namer.TopicForCommand(namer.CommandName(&testCommand{}))
+	sub, err := realPub.Subscribe(ctx, cmdTopic)
+	require.NoError(t, err)
+
+	forwarderCtx, stopForwarder := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = cmdBus.RunForwarder(forwarderCtx)
+	}()
+
+	err = cmdBus.Send(ctx, &testCommand{ID: "cmd-mysql-1"})
+	require.NoError(t, err)
+
+	select {
+	case msg := <-sub:
+		require.NotNil(t, msg)
+		msg.Ack()
+	case <-time.After(20 * time.Second):
+		t.Fatal("timeout waiting for forwarded message")
+	}
+
+	stopForwarder()
+	<-done
+	closeCtx, closeCancel := context.WithTimeout(context.Background(), 3*time.Second)
+	err = cmdBus.CloseForwarder(closeCtx)
+	closeCancel()
+	if err != nil && err != context.DeadlineExceeded {
+		require.NoError(t, err)
+	}
+}
@@ -110,7 +110,7 @@ func TestIntegration_CommandBus_Outbox_NoLeaks(t *testing.T) {
 	require.NoError(t, err)
 
 	cmdBus, err := bus.NewCommandBusWithOptions(sqlPub, marshaler, namer,
-		bus.WithOutbox(bus.OutboxConfig{
+		bus.WithOutbox(&bus.OutboxConfig{
 			DB:            sqlDB,
 			Subscriber:    sqlSub,
 			RealPublisher: realPub,
@@ -254,7 +254,7 @@ func TestIntegration_ForwarderClose_NoGoroutineLeak(t *testing.T) {
 	require.NoError(t, err)
 
 	cmdBus, err := bus.NewCommandBusWithOptions(sqlPub, marshaler, namer,
-		bus.WithOutbox(bus.OutboxConfig{
+		bus.WithOutbox(&bus.OutboxConfig{
 			DB:            sqlDB,
 			Subscriber:    sqlSub,
 			RealPublisher: realPub,
@@ -275,6 +275,113 @@ func TestIntegration_ForwarderClose_NoGoroutineLeak(t *testing.T) {
 	closeCancel()
 }
 
+func TestIntegration_EventBus_TxAwareOutbox_PreservesAggregateOrder(t *testing.T) {
+	pool := setupPostgres(t)
+	sqlDB := stdlib.OpenDBFromPool(pool)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
+	defer cancel()
+
+	wmLogger := watermill.NewStdLogger(false, false)
+	schema := wmsql.DefaultPostgreSQLSchema{}
+	orderedTopic := forwarderTopic + "_ordered"
+
+	sqlSub, err := wmsql.NewSubscriber(
+		wmsql.BeginnerFromPgx(pool),
+		wmsql.SubscriberConfig{
+			SchemaAdapter:    schema,
+			OffsetsAdapter:   wmsql.DefaultPostgreSQLOffsetsAdapter{},
+			InitializeSchema: true,
+			ConsumerGroup:    "ordered-forwarder",
+			PollInterval:     50 * time.Millisecond,
+			AckDeadline:      ptrDuration(5 * time.Second),
+		},
+		wmLogger,
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlSub.Close() })
+	require.NoError(t, sqlSub.SubscribeInitialize(orderedTopic))
+
+	// Block until the subscriber acks so gochannel itself doesn't reorder deliveries.
+	realPub := gochannel.NewGoChannel(gochannel.Config{BlockPublishUntilSubscriberAck: true}, wmLogger)
+	t.Cleanup(func() { _ = realPub.Close() })
+
+	namer := message.NewShortlinkNamer(serviceName)
+	marshaler := message.NewJSONMarshaler(namer)
+
+	cfg := logger.Default()
+	cfg.Writer = io.Discard
+	cfg.Level = logger.WARN_LEVEL
+	log, err := logger.New(cfg)
+	require.NoError(t, err)
+
+	evtBus, err := bus.NewEventBusWithOptions(nil, marshaler, namer,
+		bus.WithTxAwareOutbox(orderedTopic, wmLogger),
+	)
+	require.NoError(t, err)
+
+	fwdBus, err := bus.NewEventBusWithOptions(realPub, marshaler, namer,
+		bus.WithOutbox(&bus.OutboxConfig{
+			DB:            sqlDB,
+			Subscriber:    sqlSub,
+			RealPublisher: realPub,
+			ForwarderName: orderedTopic,
+			Logger:        log,
+			MeterProvider: noop.NewMeterProvider(),
+		}),
+	)
+	require.NoError(t, err)
+
+	evtTopic := namer.TopicForEvent(namer.EventName(&testEvent{}))
+	sub, err := realPub.Subscribe(ctx, evtTopic)
+	require.NoError(t, err)
+
+	tx, err := pool.Begin(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback(ctx) })
+
+	txCtx := uow.WithTx(ctx, tx)
+	for _, id := range []string{"evt-1", "evt-2", "evt-3"} {
+		require.NoError(t, evtBus.Publish(txCtx, &testEvent{ID: id}, bus.WithAggregateID("order-1")))
+	}
+	require.NoError(t, tx.Commit(ctx))
+
+	forwarderCtx, stopForwarder := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = fwdBus.RunForwarder(forwarderCtx)
+	}()
+
+	var got []string
+	for range 3 {
+		select {
+		case msg := <-sub:
+			var evt testEvent
+			require.NoError(t, marshaler.Unmarshal(msg, &evt))
+			require.Equal(t, "order-1", msg.Metadata.Get(message.MetadataAggregateID))
+
+			key, keyErr := message.PartitionKeyByAggregate(evtTopic, msg)
+			require.NoError(t, keyErr)
+			require.Equal(t, "order-1", key)
+
+			got = append(got, evt.ID)
+			msg.Ack()
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timeout waiting for forwarded events, got %v", got)
+		}
+	}
+
+	require.Equal(t, []string{"evt-1", "evt-2", "evt-3"}, got)
+
+	stopForwarder()
+	<-done
+	closeCtx, closeCancel := context.WithTimeout(context.Background(), 3*time.Second)
+	_ = fwdBus.CloseForwarder(closeCtx)
+	closeCancel()
+}
+
 type testCommand struct {
 	ID string `json:"id"`
 }
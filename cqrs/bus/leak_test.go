@@ -13,7 +13,7 @@ import (
 )
 
 // goleakIgnoreOpts ignores known third-party goroutines that may still be
-// running after integration tests (testcontainers reaper, pgx pool, sql.DB).
+// running after tests (testcontainers reaper, pgx pool, sql.DB).
 // Used by TestMain and by integration tests.
 var goleakIgnoreOpts = []goleak.Option{
 	goleak.IgnoreTopFunction("github.com/testcontainers/testcontainers-go.(*Reaper).connect.func1"),
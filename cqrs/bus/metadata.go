@@ -0,0 +1,45 @@
+package bus
+
+import (
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+)
+
+// isReservedMetadata reports whether key describes the message itself and is
+// owned by the marshaler or the bus, so callers cannot override it.
+func isReservedMetadata(key string) bool {
+	switch key {
+	case cqrsmessage.MetadataTypeName,
+		cqrsmessage.MetadataTypeVersion,
+		cqrsmessage.MetadataContentType,
+		cqrsmessage.MetadataMessageKind,
+		cqrsmessage.MetadataOccurredAt,
+		cqrsmessage.MetadataTraceID,
+		cqrsmessage.MetadataSpanID,
+		cqrsmessage.MetadataEncryptionKeyID:
+		return true
+	default:
+		return false
+	}
+}
+
+// mergeMetadata copies caller metadata onto a marshaled message. Reserved keys
+// and keys the marshaler already set are left untouched.
+func mergeMetadata(msg *wmmessage.Message, metadata map[string]string) {
+	if len(metadata) == 0 {
+		return
+	}
+
+	if msg.Metadata == nil {
+		msg.Metadata = make(wmmessage.Metadata, len(metadata))
+	}
+
+	for key, value := range metadata {
+		if key == "" || isReservedMetadata(key) || msg.Metadata.Get(key) != "" {
+			continue
+		}
+
+		msg.Metadata.Set(key, value)
+	}
+}
@@ -0,0 +1,84 @@
+package bus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	"github.com/stretchr/testify/require"
+
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+)
+
+func TestCommandBusSendWithMetadata_RoundTrip(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	pubSub := gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+	t.Cleanup(func() { _ = pubSub.Close() })
+
+	namer := cqrsmessage.NewShortlinkNamer("orders")
+	marshaler := cqrsmessage.NewJSONMarshaler(namer)
+	cmdBus := NewCommandBus(pubSub, marshaler, namer)
+
+	cmd := &createOrder{ID: "42"}
+	plain, err := marshaler.Marshal(ctx, cmd)
+	require.NoError(t, err)
+
+	messages, err := pubSub.Subscribe(ctx, namer.TopicForCommand(namer.CommandName(cmd)))
+	require.NoError(t, err)
+
+	require.NoError(t, cmdBus.SendWithMetadata(ctx, cmd, map[string]string{
+		"idempotency_key":                   "req-42",
+		"schedule_hint":                     "low",
+		cqrsmessage.MetadataTypeName:        "forged.name",
+		cqrsmessage.MetadataContentType:     "text/plain",
+		cqrsmessage.MetadataMessageKind:     "event",
+		cqrsmessage.MetadataServiceName:     "billing",
+		cqrsmessage.MetadataEncryptionKeyID: "",
+	}))
+
+	var received *createOrder
+
+	select {
+	case msg := <-messages:
+		msg.Ack()
+
+		require.Equal(t, "req-42", msg.Metadata.Get("idempotency_key"))
+		require.Equal(t, "low", msg.Metadata.Get("schedule_hint"))
+		require.Equal(t, plain.Metadata.Get(cqrsmessage.MetadataTypeName), msg.Metadata.Get(cqrsmessage.MetadataTypeName))
+		require.Equal(t, "application/json", msg.Metadata.Get(cqrsmessage.MetadataContentType))
+		require.Equal(t, string(cqrsmessage.KindCommand), msg.Metadata.Get(cqrsmessage.MetadataMessageKind))
+		// The namer's service name is stamped by the marshaler and wins.
+		require.Equal(t, "orders", msg.Metadata.Get(cqrsmessage.MetadataServiceName))
+
+		received = &createOrder{}
+		require.NoError(t, marshaler.Unmarshal(msg, received))
+	case <-ctx.Done():
+		t.Fatal("command was not delivered")
+	}
+
+	require.Equal(t, "42", received.ID)
+}
+
+func TestEventBusPublishWithMetadata(t *testing.T) {
+	namer := cqrsmessage.NewShortlinkNamer("orders")
+	pub := &recordingPublisher{}
+	evtBus := NewEventBus(pub, cqrsmessage.NewJSONMarshaler(namer), namer)
+
+	metadata := map[string]string{
+		"partition_key":                 "customer-7",
+		cqrsmessage.MetadataAggregateID: "from-metadata",
+	}
+
+	require.NoError(t, evtBus.PublishWithMetadata(context.Background(), &createOrder{ID: "1"}, metadata))
+	require.NoError(t, evtBus.PublishWithMetadata(context.Background(), &createOrder{ID: "2"}, metadata, WithAggregateID("order-2")))
+
+	require.Len(t, pub.messages, 2)
+	require.Equal(t, "customer-7", pub.messages[0].Metadata.Get("partition_key"))
+	require.Equal(t, "from-metadata", pub.messages[0].Metadata.Get(cqrsmessage.MetadataAggregateID))
+	require.Equal(t, "order-2", pub.messages[1].Metadata.Get(cqrsmessage.MetadataAggregateID))
+	require.Equal(t, string(cqrsmessage.KindEvent), pub.messages[1].Metadata.Get(cqrsmessage.MetadataMessageKind))
+}
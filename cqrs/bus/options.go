@@ -4,17 +4,24 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ThreeDotsLabs/watermill"
+	wmsql "github.com/ThreeDotsLabs/watermill-sql/v4/pkg/sql"
 	wmmessage "github.com/ThreeDotsLabs/watermill/message"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"go.opentelemetry.io/otel/metric"
 
 	"github.com/shortlink-org/go-sdk/logger"
+	sdkwatermill "github.com/shortlink-org/go-sdk/watermill"
 )
 
-const defaultForwarderTopic = "shortlink_cqrs_outbox"
+const (
+	defaultForwarderTopic = "shortlink_cqrs_outbox"
+	// defaultRestartBackoffMax caps the forwarder restart backoff when RestartBackoffMax is unset.
+	defaultRestartBackoffMax = 30 * time.Second
+)
 
 // Option configures Bus behaviors without breaking the constructor API.
 type Option func(*cqrsConfig)
@@ -61,6 +68,31 @@ type OutboxConfig struct {
 	ForwarderName string
 	Logger        logger.Logger
 	MeterProvider metric.MeterProvider
+
+	// SchemaAdapter and OffsetsAdapter select the SQL dialect of the outbox tables
+	// (e.g. wmsql.DefaultMySQLSchema with wmsql.DefaultMySQLOffsetsAdapter).
+	// When Subscriber is nil they are used to build the forwarder subscriber on DB;
+	// its tables are not created and must be provisioned by migrations.
+	// Both must be set together and use the same dialect; Pool requires PostgreSQL.
+	SchemaAdapter  wmsql.SchemaAdapter
+	OffsetsAdapter wmsql.OffsetsAdapter
+
+	// RestartBackoffMin supervises RunForwarder: when the forwarder stops (e.g. the
+	// subscriber fails on a DB blip) before ctx is canceled or CloseForwarder is called,
+	// it is rebuilt and run again after a backoff that doubles from RestartBackoffMin
+	// up to RestartBackoffMax (default 30s). Zero returns on the first stop.
+	// Restarts reuse Subscriber, so it must allow subscribing again; it stays open
+	// between runs and is closed once RunForwarder returns.
+	RestartBackoffMin time.Duration
+	RestartBackoffMax time.Duration
+
+	// ProbeTopic is where ValidateForwarder publishes a probe through RealPublisher
+	// to check the broker is reachable. Use a topic nobody consumes; empty skips the probe.
+	ProbeTopic string
+
+	// Clock stamps the occurred_at metadata of messages written to the outbox and
+	// times forwarder restart backoffs. Nil uses the system clock.
+	Clock Clock
 }
 
 // WithOutbox enables Watermill's Outbox/Forwarder transport.
@@ -127,6 +159,20 @@ type OutboxConfig struct {
 //			}),
 //		)
 //	}
+//
+// Example with MySQL (the forwarder subscriber is built from the adapters):
+//
+//	cmdBus, err := bus.NewCommandBusWithOptions(sqlPublisher, marshaler, namer,
+//		bus.WithOutbox(&bus.OutboxConfig{
+//			DB:             mysqlDB,
+//			SchemaAdapter:  wmsql.DefaultMySQLSchema{},
+//			OffsetsAdapter: wmsql.DefaultMySQLOffsetsAdapter{},
+//			RealPublisher:  kafkaPub,
+//			ForwarderName:  "orders_outbox_forwarder",
+//			Logger:         log,
+//			MeterProvider:  meterProvider,
+//		}),
+//	)
 func WithOutbox(cfg *OutboxConfig) Option {
 	return func(cqrsCfg *cqrsConfig) {
 		if cqrsCfg.err != nil {
@@ -173,7 +219,11 @@ func (c *OutboxConfig) prepare() error {
 		return errOutboxMissingDB
 	}
 
-	if c.Subscriber == nil {
+	if err := c.validateAdapters(); err != nil {
+		return err
+	}
+
+	if c.Subscriber == nil && c.SchemaAdapter == nil {
 		return errOutboxMissingSubscriber
 	}
 
@@ -195,9 +245,97 @@ func (c *OutboxConfig) prepare() error {
 
 	c.ForwarderName = sanitizeForwarderTopic(c.ForwarderName, c.DB, c.Pool)
 
+	if c.Clock == nil {
+		c.Clock = realClock{}
+	}
+
+	if c.RestartBackoffMin > 0 && c.RestartBackoffMax == 0 {
+		c.RestartBackoffMax = defaultRestartBackoffMax
+	}
+
+	if c.RestartBackoffMax < c.RestartBackoffMin {
+		c.RestartBackoffMax = c.RestartBackoffMin
+	}
+
+	if c.Subscriber == nil {
+		sub, err := wmsql.NewSubscriber(
+			wmsql.BeginnerFromStdSQL(c.DB),
+			wmsql.SubscriberConfig{
+				SchemaAdapter:  c.SchemaAdapter,
+				OffsetsAdapter: c.OffsetsAdapter,
+				ConsumerGroup:  c.ForwarderName,
+			},
+			sdkwatermill.NewWatermillLogger(c.Logger),
+		)
+		if err != nil {
+			return fmt.Errorf("cqrs/bus: outbox subscriber: %w", err)
+		}
+
+		c.Subscriber = sub
+	}
+
 	return nil
 }
 
+// sqlDialect identifies the database a watermill-sql adapter targets.
+type sqlDialect string
+
+const (
+	dialectUnknown  sqlDialect = ""
+	dialectPostgres sqlDialect = "postgresql"
+	dialectMySQL    sqlDialect = "mysql"
+)
+
+// validateAdapters rejects half-configured or mixed-dialect adapters.
+// Custom adapters have an unknown dialect and are only checked for presence.
+func (c *OutboxConfig) validateAdapters() error {
+	if (c.SchemaAdapter == nil) != (c.OffsetsAdapter == nil) {
+		return errOutboxAdapterPair
+	}
+
+	if c.SchemaAdapter == nil {
+		return nil
+	}
+
+	schema, offsets := schemaDialect(c.SchemaAdapter), offsetsDialect(c.OffsetsAdapter)
+
+	if schema != dialectUnknown && offsets != dialectUnknown && schema != offsets {
+		return fmt.Errorf("%w: %s schema with %s offsets", errOutboxDialectMismatch, schema, offsets)
+	}
+
+	if c.Pool != nil && (schema == dialectMySQL || offsets == dialectMySQL) {
+		return fmt.Errorf("%w: pgxpool.Pool requires PostgreSQL adapters", errOutboxDialectMismatch)
+	}
+
+	return nil
+}
+
+func schemaDialect(adapter wmsql.SchemaAdapter) sqlDialect {
+	switch adapter.(type) {
+	case wmsql.DefaultPostgreSQLSchema, *wmsql.DefaultPostgreSQLSchema,
+		wmsql.PostgreSQLQueueSchema, *wmsql.PostgreSQLQueueSchema:
+		return dialectPostgres
+	case wmsql.DefaultMySQLSchema, *wmsql.DefaultMySQLSchema,
+		wmsql.MySQLQueueSchema, *wmsql.MySQLQueueSchema:
+		return dialectMySQL
+	default:
+		return dialectUnknown
+	}
+}
+
+func offsetsDialect(adapter wmsql.OffsetsAdapter) sqlDialect {
+	switch adapter.(type) {
+	case wmsql.DefaultPostgreSQLOffsetsAdapter, *wmsql.DefaultPostgreSQLOffsetsAdapter,
+		wmsql.PostgreSQLQueueOffsetsAdapter, *wmsql.PostgreSQLQueueOffsetsAdapter:
+		return dialectPostgres
+	case wmsql.DefaultMySQLOffsetsAdapter, *wmsql.DefaultMySQLOffsetsAdapter,
+		wmsql.MySQLQueueOffsetsAdapter, *wmsql.MySQLQueueOffsetsAdapter:
+		return dialectMySQL
+	default:
+		return dialectUnknown
+	}
+}
+
 func sanitizeForwarderTopic(name string, sqlDB *sql.DB, pool *pgxpool.Pool) string {
 	name = strings.TrimSpace(name)
 	if name != "" {
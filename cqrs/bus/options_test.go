@@ -0,0 +1,108 @@
+package bus
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"testing"
+
+	"github.com/ThreeDotsLabs/watermill"
+	wmsql "github.com/ThreeDotsLabs/watermill-sql/v4/pkg/sql"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	"github.com/shortlink-org/go-sdk/logger"
+)
+
+func TestOutboxConfigPrepare_Adapters(t *testing.T) {
+	log, err := logger.New(logger.Configuration{Writer: io.Discard})
+	require.NoError(t, err)
+
+	// Neither sql.Open nor pgxpool.New connect; prepare only inspects the config.
+	db, err := sql.Open("pgx", "postgres://localhost:1/outbox")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	pool, err := pgxpool.New(context.Background(), "postgres://localhost:1/outbox")
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	pubSub := gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+	t.Cleanup(func() { _ = pubSub.Close() })
+
+	tests := []struct {
+		name    string
+		mutate  func(*OutboxConfig)
+		wantErr error
+	}{
+		{
+			name:    "no subscriber and no adapters",
+			mutate:  func(*OutboxConfig) {},
+			wantErr: errOutboxMissingSubscriber,
+		},
+		{
+			name: "schema adapter without offsets adapter",
+			mutate: func(cfg *OutboxConfig) {
+				cfg.SchemaAdapter = wmsql.DefaultMySQLSchema{}
+			},
+			wantErr: errOutboxAdapterPair,
+		},
+		{
+			name: "mixed dialects",
+			mutate: func(cfg *OutboxConfig) {
+				cfg.SchemaAdapter = wmsql.DefaultMySQLSchema{}
+				cfg.OffsetsAdapter = wmsql.DefaultPostgreSQLOffsetsAdapter{}
+			},
+			wantErr: errOutboxDialectMismatch,
+		},
+		{
+			name: "mysql adapters with pgx pool",
+			mutate: func(cfg *OutboxConfig) {
+				cfg.DB, cfg.Pool = nil, pool
+				cfg.SchemaAdapter = wmsql.DefaultMySQLSchema{}
+				cfg.OffsetsAdapter = wmsql.DefaultMySQLOffsetsAdapter{}
+			},
+			wantErr: errOutboxDialectMismatch,
+		},
+		{
+			name: "mysql adapters build subscriber",
+			mutate: func(cfg *OutboxConfig) {
+				cfg.SchemaAdapter = wmsql.DefaultMySQLSchema{}
+				cfg.OffsetsAdapter = wmsql.DefaultMySQLOffsetsAdapter{}
+			},
+		},
+		{
+			name: "postgres adapters with pgx pool",
+			mutate: func(cfg *OutboxConfig) {
+				cfg.DB, cfg.Pool = nil, pool
+				cfg.SchemaAdapter = wmsql.DefaultPostgreSQLSchema{}
+				cfg.OffsetsAdapter = wmsql.DefaultPostgreSQLOffsetsAdapter{}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := OutboxConfig{
+				DB:            db,
+				RealPublisher: pubSub,
+				ForwarderName: "adapters_outbox",
+				Logger:        log,
+				MeterProvider: noop.NewMeterProvider(),
+			}
+			tt.mutate(&cfg)
+
+			err := cfg.prepare()
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.IsType(t, &wmsql.Subscriber{}, cfg.Subscriber)
+		})
+	}
+}
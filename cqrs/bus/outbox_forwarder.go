@@ -2,8 +2,10 @@ package bus
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/components/forwarder"
@@ -11,17 +13,30 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
 	"github.com/shortlink-org/go-sdk/logger"
 	sdkwatermill "github.com/shortlink-org/go-sdk/watermill"
 )
 
 type forwarderState struct {
 	cfg      *OutboxConfig
-	once     sync.Once
-	fwd      *forwarder.Forwarder
-	err      error
 	monitor  *forwarderMonitor
 	wmLogger watermill.LoggerAdapter
+
+	// fwdMu guards the current forwarder, rebuilt on every supervised restart.
+	fwdMu   sync.Mutex
+	built   bool
+	fwd     *forwarder.Forwarder
+	sub     *closedOnErrorSubscriber
+	err     error
+	closing bool
+	// closed is closed together with setting closing, so Run leaves a restart backoff at once.
+	closed chan struct{}
+
+	// mu guards stopped and runErr, set once Run returns.
+	mu      sync.Mutex
+	stopped bool
+	runErr  error
 }
 
 func newForwarderState(cfg *OutboxConfig) *forwarderState {
@@ -35,6 +50,7 @@ func newForwarderState(cfg *OutboxConfig) *forwarderState {
 		cfg:      &cfgSnap,
 		monitor:  newForwarderMonitor(cfgSnap.Logger, cfgSnap.MeterProvider, cfgSnap.ForwarderName),
 		wmLogger: sdkwatermill.NewWatermillLogger(cfgSnap.Logger),
+		closed:   make(chan struct{}),
 	}
 
 	return state
@@ -53,26 +69,130 @@ func (s *forwarderState) Run(ctx context.Context) error {
 		slog.String("forwarder", s.cfg.ForwarderName),
 	)
 
-	fwd, err := s.ensureForwarder()
-	if err != nil {
-		s.cfg.Logger.Error("Failed to initialize outbox forwarder",
-			slog.String("forwarder", s.cfg.ForwarderName),
-			slog.String("error", err.Error()),
-		)
+	backoff := s.cfg.RestartBackoffMin
 
-		return err
+	for {
+		fwd, err := s.ensureForwarder()
+		if err != nil {
+			s.cfg.Logger.Error("Failed to initialize outbox forwarder",
+				slog.String("forwarder", s.cfg.ForwarderName),
+				slog.String("error", err.Error()),
+			)
+
+			return err
+		}
+
+		if fwd == nil {
+			s.cfg.Logger.Error("Failed to initialize outbox forwarder",
+				slog.String("forwarder", s.cfg.ForwarderName),
+				slog.String("error", errForwarderNotConfigured.Error()),
+			)
+
+			return errForwarderNotConfigured
+		}
+
+		runErr := fwd.Run(ctx)
+
+		subscribeErr := s.subscribeErr()
+		if subscribeErr != nil {
+			runErr = subscribeErr
+		}
+
+		s.mu.Lock()
+		s.stopped, s.runErr = true, runErr
+		s.mu.Unlock()
+
+		if !s.shouldRestart(ctx) {
+			return s.finish(runErr)
+		}
+
+		// A forwarder that got running was healthy, so the next failure starts over.
+		if subscribeErr == nil {
+			select {
+			case <-fwd.Running():
+				backoff = s.cfg.RestartBackoffMin
+			default:
+			}
+		}
+
+		s.logRestart(ctx, runErr, backoff)
+
+		// Stopping during the backoff is a clean shutdown; runErr was already logged as the restart cause.
+		select {
+		case <-ctx.Done():
+			return s.finish(nil)
+		case <-s.closed:
+			return s.finish(nil)
+		case <-s.cfg.Clock.After(backoff):
+		}
+
+		backoff = min(backoff*2, s.cfg.RestartBackoffMax)
+
+		if !s.resetForwarder() {
+			return s.finish(nil)
+		}
 	}
+}
 
-	if fwd == nil {
-		s.cfg.Logger.Error("Failed to initialize outbox forwarder",
-			slog.String("forwarder", s.cfg.ForwarderName),
-			slog.String("error", errForwarderNotConfigured.Error()),
-		)
+// shouldRestart reports whether a stopped forwarder is supervised and was not stopped on purpose.
+func (s *forwarderState) shouldRestart(ctx context.Context) bool {
+	if s.cfg.RestartBackoffMin <= 0 || ctx.Err() != nil {
+		return false
+	}
 
-		return errForwarderNotConfigured
+	s.fwdMu.Lock()
+	defer s.fwdMu.Unlock()
+
+	return !s.closing
+}
+
+// resetForwarder drops the stopped forwarder so the next ensureForwarder builds a fresh one;
+// a watermill router cannot run twice. It returns false once Close has been called.
+func (s *forwarderState) resetForwarder() bool {
+	s.fwdMu.Lock()
+	defer s.fwdMu.Unlock()
+
+	if s.closing {
+		return false
+	}
+
+	s.built, s.fwd, s.sub, s.err = false, nil, nil, nil
+
+	s.mu.Lock()
+	s.stopped, s.runErr = false, nil
+	s.mu.Unlock()
+
+	return true
+}
+
+func (s *forwarderState) logRestart(ctx context.Context, runErr error, backoff time.Duration) {
+	fields := []slog.Attr{
+		slog.String("forwarder", s.cfg.ForwarderName),
+		slog.Duration("backoff", backoff),
+	}
+	if runErr != nil {
+		fields = append(fields, slog.String("error", runErr.Error()))
+	}
+
+	s.cfg.Logger.Warn("Outbox forwarder stopped, restarting", fields...)
+	s.monitor.observeRestart(ctx)
+}
+
+// finish closes the subscriber that supervised runs keep open and logs how Run stopped.
+func (s *forwarderState) finish(runErr error) error {
+	if s.cfg.RestartBackoffMin > 0 && s.cfg.Subscriber != nil {
+		if err := s.cfg.Subscriber.Close(); err != nil {
+			s.cfg.Logger.Error("Failed to close outbox subscriber",
+				slog.String("forwarder", s.cfg.ForwarderName),
+				slog.String("error", err.Error()),
+			)
+		}
 	}
 
-	runErr := fwd.Run(ctx)
+	return s.logStopped(runErr)
+}
+
+func (s *forwarderState) logStopped(runErr error) error {
 	if runErr != nil {
 		s.cfg.Logger.Error("Outbox forwarder stopped with error",
 			slog.String("forwarder", s.cfg.ForwarderName),
@@ -89,11 +209,61 @@ func (s *forwarderState) Run(ctx context.Context) error {
 	return nil
 }
 
+// Healthy initializes the forwarder if needed and reports whether it is running.
+// It returns the initialization error, ErrForwarderNotRunning before Run has
+// started the forwarder, or ErrForwarderNotRunning wrapping the error Run stopped with.
+func (s *forwarderState) Healthy() (bool, error) {
+	fwd, err := s.ensureForwarder()
+	if err != nil {
+		return false, err
+	}
+
+	if fwd == nil {
+		return false, errForwarderNotConfigured
+	}
+
+	s.mu.Lock()
+	stopped, runErr := s.stopped, s.runErr
+	s.mu.Unlock()
+
+	if stopped {
+		if runErr != nil {
+			return false, fmt.Errorf("%w: %w", ErrForwarderNotRunning, runErr)
+		}
+
+		return false, ErrForwarderNotRunning
+	}
+
+	// A failed subscription still lets the router start until its closed channel stops it.
+	if subscribeErr := s.subscribeErr(); subscribeErr != nil {
+		return false, fmt.Errorf("%w: %w", ErrForwarderNotRunning, subscribeErr)
+	}
+
+	select {
+	case <-fwd.Running():
+		return true, nil
+	default:
+		return false, ErrForwarderNotRunning
+	}
+}
+
 func (s *forwarderState) Close(ctx context.Context) error {
 	if s == nil || s.cfg == nil {
 		return nil
 	}
 
+	if ctx == nil {
+		return errNilContext
+	}
+
+	// Stop supervised restarts before picking the forwarder to close.
+	s.fwdMu.Lock()
+	if !s.closing {
+		s.closing = true
+		close(s.closed)
+	}
+	s.fwdMu.Unlock()
+
 	fwd, err := s.ensureForwarder()
 	if err != nil {
 		return err
@@ -103,10 +273,6 @@ func (s *forwarderState) Close(ctx context.Context) error {
 		return errForwarderNotConfigured
 	}
 
-	if ctx == nil {
-		return errNilContext
-	}
-
 	done := make(chan error, 1)
 
 	go func() {
@@ -139,6 +305,15 @@ func (s *forwarderState) Close(ctx context.Context) error {
 	}
 }
 
+// stampOccurredAt sets the occurred_at metadata from the outbox clock unless the message has one.
+func (s *forwarderState) stampOccurredAt(msg *wmmessage.Message) {
+	if s == nil || s.cfg == nil || msg.Metadata.Get(cqrsmessage.MetadataOccurredAt) != "" {
+		return
+	}
+
+	msg.Metadata.Set(cqrsmessage.MetadataOccurredAt, s.cfg.Clock.Now().UTC().Format(time.RFC3339Nano))
+}
+
 func (s *forwarderState) wrapPublisher(pub wmmessage.Publisher) wmmessage.Publisher {
 	if s == nil || s.cfg == nil || pub == nil {
 		return pub
@@ -156,7 +331,12 @@ func (s *forwarderState) ensureForwarder() (*forwarder.Forwarder, error) {
 		return nil, errForwarderNotConfigured
 	}
 
-	s.once.Do(func() {
+	s.fwdMu.Lock()
+	defer s.fwdMu.Unlock()
+
+	if !s.built {
+		s.built = true
+
 		var middlewares []wmmessage.HandlerMiddleware
 		if mw := s.monitor.middleware(); mw != nil {
 			middlewares = append(middlewares, mw)
@@ -167,22 +347,89 @@ func (s *forwarderState) ensureForwarder() (*forwarder.Forwarder, error) {
 			Middlewares:    middlewares,
 		}
 
+		s.sub = &closedOnErrorSubscriber{
+			Subscriber: s.cfg.Subscriber,
+			keepOpen:   s.cfg.RestartBackoffMin > 0,
+		}
+
 		s.fwd, s.err = forwarder.NewForwarder(
-			s.cfg.Subscriber,
+			s.sub,
 			s.cfg.RealPublisher,
 			s.wmLogger,
 			forwarderCfg,
 		)
-	})
+	}
 
 	return s.fwd, s.err
 }
 
+// subscribeErr returns the error the current forwarder failed to subscribe with, if any.
+func (s *forwarderState) subscribeErr() error {
+	s.fwdMu.Lock()
+	defer s.fwdMu.Unlock()
+
+	if s.sub == nil {
+		return nil
+	}
+
+	return s.sub.Err()
+}
+
+// closedOnErrorSubscriber turns a failed Subscribe into an already closed
+// subscription and keeps the error for Run to report. Watermill's router never
+// releases a handler whose Subscribe failed, so its goroutines would outlive
+// the forwarder; a closed channel stops the handler and the router cleanly.
+//
+// With keepOpen set, Close leaves the wrapped subscriber open: a stopping router
+// closes its subscriber, and the next supervised run subscribes with it again.
+type closedOnErrorSubscriber struct {
+	wmmessage.Subscriber
+
+	keepOpen bool
+
+	mu  sync.Mutex
+	err error
+}
+
+func (s *closedOnErrorSubscriber) Subscribe(ctx context.Context, topic string) (<-chan *wmmessage.Message, error) {
+	messages, err := s.Subscriber.Subscribe(ctx, topic)
+	if err == nil {
+		return messages, nil
+	}
+
+	s.mu.Lock()
+	s.err = fmt.Errorf("cannot subscribe topic %s: %w", topic, err)
+	s.mu.Unlock()
+
+	closed := make(chan *wmmessage.Message)
+	close(closed)
+
+	return closed, nil
+}
+
+// Close closes the wrapped subscriber unless keepOpen is set.
+func (s *closedOnErrorSubscriber) Close() error {
+	if s.keepOpen {
+		return nil
+	}
+
+	return s.Subscriber.Close()
+}
+
+// Err returns the last Subscribe error.
+func (s *closedOnErrorSubscriber) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
 type forwarderMonitor struct {
 	log           logger.Logger
 	forwarderName string
 	success       metric.Int64Counter
 	failures      metric.Int64Counter
+	restarts      metric.Int64Counter
 	attrs         []attribute.KeyValue
 }
 
@@ -194,9 +441,10 @@ func newForwarderMonitor(log logger.Logger, provider metric.MeterProvider, name
 	meter := provider.Meter("shortlink.cqrs.outbox")
 
 	var (
-		success metric.Int64Counter
-		fail    metric.Int64Counter
-		err     error
+		success  metric.Int64Counter
+		fail     metric.Int64Counter
+		restarts metric.Int64Counter
+		err      error
 	)
 
 	if success, err = meter.Int64Counter(
@@ -213,11 +461,19 @@ func newForwarderMonitor(log logger.Logger, provider metric.MeterProvider, name
 		log.Warn("Failed to create CQRS outbox failure counter", slog.String("error", err.Error()))
 	}
 
+	if restarts, err = meter.Int64Counter(
+		"shortlink_cqrs_outbox_forwarder_restarts_total",
+		metric.WithDescription("Total number of supervised outbox forwarder restarts"),
+	); err != nil {
+		log.Warn("Failed to create CQRS outbox restart counter", slog.String("error", err.Error()))
+	}
+
 	return &forwarderMonitor{
 		log:           log,
 		forwarderName: name,
 		success:       success,
 		failures:      fail,
+		restarts:      restarts,
 		attrs: []attribute.KeyValue{
 			attribute.String("forwarder_name", name),
 		},
@@ -280,3 +536,11 @@ func (m *forwarderMonitor) observeFailure(ctx context.Context, msg *wmmessage.Me
 		slog.String("error", err.Error()),
 	)
 }
+
+func (m *forwarderMonitor) observeRestart(ctx context.Context) {
+	if m == nil || m.restarts == nil {
+		return
+	}
+
+	m.restarts.Add(ctx, 1, metric.WithAttributes(m.attrs...))
+}
@@ -0,0 +1,401 @@
+package bus
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+	"github.com/shortlink-org/go-sdk/logger"
+)
+
+var errTestSubscribe = errors.New("subscribe boom")
+
+type failingSubscriber struct{}
+
+func (failingSubscriber) Subscribe(context.Context, string) (<-chan *wmmessage.Message, error) {
+	return nil, errTestSubscribe
+}
+
+func (failingSubscriber) Close() error { return nil }
+
+// flakySubscriber fails the first Subscribe, like a DB blip, then delegates.
+type flakySubscriber struct {
+	wmmessage.Subscriber
+
+	calls atomic.Int32
+}
+
+func (f *flakySubscriber) Subscribe(ctx context.Context, topic string) (<-chan *wmmessage.Message, error) {
+	if f.calls.Add(1) == 1 {
+		return nil, errTestSubscribe
+	}
+
+	return f.Subscriber.Subscribe(ctx, topic)
+}
+
+func newOutboxCommandBus(t *testing.T, sub wmmessage.Subscriber, mutate ...func(*OutboxConfig)) *CommandBus {
+	t.Helper()
+
+	log, err := logger.New(logger.Configuration{Writer: io.Discard})
+	require.NoError(t, err)
+
+	// sql.Open does not connect; the forwarder only needs the DB for naming.
+	db, err := sql.Open("pgx", "postgres://localhost:1/outbox")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	pubSub := gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+	t.Cleanup(func() { _ = pubSub.Close() })
+
+	namer := cqrsmessage.NewShortlinkNamer("health")
+
+	outboxCfg := &OutboxConfig{
+		DB:            db,
+		Subscriber:    sub,
+		RealPublisher: pubSub,
+		ForwarderName: "health_outbox",
+		Logger:        log,
+		MeterProvider: noop.NewMeterProvider(),
+	}
+
+	for _, fn := range mutate {
+		fn(outboxCfg)
+	}
+
+	cmdBus, err := NewCommandBusWithOptions(pubSub, cqrsmessage.NewJSONMarshaler(namer), namer, WithOutbox(outboxCfg))
+	require.NoError(t, err)
+
+	return cmdBus
+}
+
+func TestForwarderHealthy_WithoutOutbox(t *testing.T) {
+	namer := cqrsmessage.NewShortlinkNamer("health")
+	cmdBus := NewCommandBus(&recordingPublisher{}, cqrsmessage.NewJSONMarshaler(namer), namer)
+
+	healthy, err := cmdBus.ForwarderHealthy()
+	require.NoError(t, err)
+	require.True(t, healthy)
+}
+
+func TestForwarderHealthy_BadSubscriberReportsUnhealthy(t *testing.T) {
+	cmdBus := newOutboxCommandBus(t, failingSubscriber{})
+	check := cmdBus.ForwarderCheck()
+
+	healthy, err := cmdBus.ForwarderHealthy()
+	require.False(t, healthy)
+	require.ErrorIs(t, err, ErrForwarderNotRunning)
+
+	require.Error(t, cmdBus.RunForwarder(context.Background()))
+
+	healthy, err = cmdBus.ForwarderHealthy()
+	require.False(t, healthy)
+	require.ErrorIs(t, err, ErrForwarderNotRunning)
+	require.ErrorIs(t, err, errTestSubscribe)
+	require.ErrorIs(t, check(), errTestSubscribe)
+}
+
+func TestForwarderHealthy_RunningForwarder(t *testing.T) {
+	sub := gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+	t.Cleanup(func() { _ = sub.Close() })
+
+	cmdBus := newOutboxCommandBus(t, sub)
+
+	runErr := make(chan error, 1)
+
+	go func() { runErr <- cmdBus.RunForwarder(context.Background()) }()
+
+	require.Eventually(t, func() bool {
+		healthy, _ := cmdBus.ForwarderHealthy()
+
+		return healthy
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, cmdBus.ForwarderCheck()())
+
+	require.NoError(t, cmdBus.CloseForwarder(context.Background()))
+	require.NoError(t, <-runErr)
+
+	healthy, err := cmdBus.ForwarderHealthy()
+	require.False(t, healthy)
+	require.ErrorIs(t, err, ErrForwarderNotRunning)
+}
+
+func TestValidateForwarder_FailingSubscriber(t *testing.T) {
+	cmdBus := newOutboxCommandBus(t, failingSubscriber{})
+
+	err := cmdBus.ValidateForwarder(context.Background())
+	require.ErrorIs(t, err, ErrForwarderValidation)
+	require.ErrorIs(t, err, errTestSubscribe)
+	require.ErrorContains(t, err, "subscriber")
+
+	// Validation never starts forwarding.
+	healthy, _ := cmdBus.ForwarderHealthy()
+	require.False(t, healthy)
+}
+
+func TestValidateForwarder_PublishesProbe(t *testing.T) {
+	sub := gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+	t.Cleanup(func() { _ = sub.Close() })
+
+	broker := gochannel.NewGoChannel(gochannel.Config{OutputChannelBuffer: 1}, watermill.NopLogger{})
+	t.Cleanup(func() { _ = broker.Close() })
+
+	probes, err := broker.Subscribe(t.Context(), "outbox_probe")
+	require.NoError(t, err)
+
+	cmdBus := newOutboxCommandBus(t, sub, func(cfg *OutboxConfig) {
+		cfg.RealPublisher = broker
+		cfg.ProbeTopic = "outbox_probe"
+	})
+
+	require.NoError(t, cmdBus.ValidateForwarder(context.Background()))
+
+	select {
+	case probe := <-probes:
+		probe.Ack()
+		require.Equal(t, "health_outbox", probe.Metadata.Get(MetadataOutboxProbe))
+	case <-time.After(5 * time.Second):
+		t.Fatal("probe was not published")
+	}
+}
+
+func TestRunForwarder_RestartsAfterSubscriberError(t *testing.T) {
+	log, err := logger.New(logger.Configuration{Writer: io.Discard})
+	require.NoError(t, err)
+
+	db, err := sql.Open("pgx", "postgres://localhost:1/outbox")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	outbox := gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+	t.Cleanup(func() { _ = outbox.Close() })
+
+	broker := gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+	t.Cleanup(func() { _ = broker.Close() })
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	namer := cqrsmessage.NewShortlinkNamer("restart")
+
+	cmdBus, err := NewCommandBusWithOptions(outbox, cqrsmessage.NewJSONMarshaler(namer), namer, WithOutbox(&OutboxConfig{
+		DB:                db,
+		Subscriber:        &flakySubscriber{Subscriber: outbox},
+		RealPublisher:     broker,
+		ForwarderName:     "restart_outbox",
+		Logger:            log,
+		MeterProvider:     provider,
+		RestartBackoffMin: 10 * time.Millisecond,
+	}))
+	require.NoError(t, err)
+
+	forwarded, err := broker.Subscribe(context.Background(), "orders")
+	require.NoError(t, err)
+
+	runErr := make(chan error, 1)
+
+	go func() { runErr <- cmdBus.RunForwarder(context.Background()) }()
+
+	require.Eventually(t, func() bool {
+		healthy, _ := cmdBus.ForwarderHealthy()
+
+		return healthy
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, cmdBus.publisher.Publish("orders", wmmessage.NewMessage(watermill.NewUUID(), []byte("payload"))))
+
+	select {
+	case msg := <-forwarded:
+		require.Equal(t, "payload", string(msg.Payload))
+		msg.Ack()
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not forwarded after restart")
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Equal(t, int64(1), restartCount(rm, "restart_outbox"))
+
+	require.NoError(t, cmdBus.CloseForwarder(context.Background()))
+	require.NoError(t, <-runErr)
+}
+
+func restartCount(rm metricdata.ResourceMetrics, forwarder string) int64 {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "shortlink_cqrs_outbox_forwarder_restarts_total" {
+				continue
+			}
+
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				return 0
+			}
+
+			for _, dp := range sum.DataPoints {
+				if name, _ := dp.Attributes.Value(attribute.Key("forwarder_name")); name.AsString() == forwarder {
+					return dp.Value
+				}
+			}
+		}
+	}
+
+	return 0
+}
+
+// fakeClock returns a fixed time and fires every wait immediately, recording its duration.
+type fakeClock struct {
+	now   time.Time
+	waits chan time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits <- d
+
+	fired := make(chan time.Time, 1)
+	fired <- c.now
+
+	return fired
+}
+
+func TestOutbox_UsesConfiguredClock(t *testing.T) {
+	log, err := logger.New(logger.Configuration{Writer: io.Discard})
+	require.NoError(t, err)
+
+	db, err := sql.Open("pgx", "postgres://localhost:1/outbox")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	outbox := gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+	t.Cleanup(func() { _ = outbox.Close() })
+
+	broker := gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+	t.Cleanup(func() { _ = broker.Close() })
+
+	clock := &fakeClock{
+		now:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		waits: make(chan time.Duration, 1),
+	}
+	namer := cqrsmessage.NewShortlinkNamer("clock")
+
+	cmdBus, err := NewCommandBusWithOptions(outbox, cqrsmessage.NewJSONMarshaler(namer), namer, WithOutbox(&OutboxConfig{
+		DB:            db,
+		Subscriber:    &flakySubscriber{Subscriber: outbox},
+		RealPublisher: broker,
+		ForwarderName: "clock_outbox",
+		Logger:        log,
+		MeterProvider: noop.NewMeterProvider(),
+		// Only the fake clock lets the restart happen within the test timeout.
+		RestartBackoffMin: time.Hour,
+		Clock:             clock,
+	}))
+	require.NoError(t, err)
+
+	forwarded, err := broker.Subscribe(context.Background(), namer.TopicForCommand(namer.CommandName(&createOrder{})))
+	require.NoError(t, err)
+
+	runErr := make(chan error, 1)
+
+	go func() { runErr <- cmdBus.RunForwarder(context.Background()) }()
+
+	require.Equal(t, time.Hour, <-clock.waits)
+	require.Eventually(t, func() bool {
+		healthy, _ := cmdBus.ForwarderHealthy()
+
+		return healthy
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, cmdBus.Send(context.Background(), &createOrder{ID: "1"}))
+
+	select {
+	case msg := <-forwarded:
+		require.Equal(t, "2026-01-02T03:04:05Z", msg.Metadata.Get(cqrsmessage.MetadataOccurredAt))
+		msg.Ack()
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not forwarded")
+	}
+
+	require.NoError(t, cmdBus.CloseForwarder(context.Background()))
+	require.NoError(t, <-runErr)
+}
+
+// gatedClock blocks every wait until fire is closed.
+type gatedClock struct {
+	waits chan time.Duration
+	fire  chan time.Time
+}
+
+func (c *gatedClock) Now() time.Time { return time.Now() }
+
+func (c *gatedClock) After(d time.Duration) <-chan time.Time {
+	c.waits <- d
+
+	return c.fire
+}
+
+func TestRunForwarder_StopDuringBackoffReturnsNil(t *testing.T) {
+	tests := []struct {
+		name string
+		stop func(t *testing.T, cmdBus *CommandBus, cancel context.CancelFunc)
+	}{
+		{
+			name: "context canceled",
+			stop: func(_ *testing.T, _ *CommandBus, cancel context.CancelFunc) {
+				cancel()
+			},
+		},
+		{
+			name: "forwarder closed",
+			stop: func(t *testing.T, cmdBus *CommandBus, _ context.CancelFunc) {
+				t.Helper()
+
+				require.NoError(t, cmdBus.CloseForwarder(context.Background()))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &gatedClock{waits: make(chan time.Duration, 1), fire: make(chan time.Time)}
+
+			cmdBus := newOutboxCommandBus(t, failingSubscriber{}, func(cfg *OutboxConfig) {
+				cfg.RestartBackoffMin = time.Hour
+				cfg.Clock = clock
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			runErr := make(chan error, 1)
+
+			go func() { runErr <- cmdBus.RunForwarder(ctx) }()
+
+			// The failed subscription is retried after the backoff.
+			require.Equal(t, time.Hour, <-clock.waits)
+
+			// The backoff timer never fires, so Run has to leave the backoff on its own.
+			tt.stop(t, cmdBus, cancel)
+
+			select {
+			case err := <-runErr:
+				require.NoError(t, err)
+			case <-time.After(5 * time.Second):
+				t.Fatal("RunForwarder did not return during the restart backoff")
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/ThreeDotsLabs/watermill"
+	wmsql "github.com/ThreeDotsLabs/watermill-sql/v4/pkg/sql"
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+)
+
+// MetadataOutboxProbe marks the message ValidateForwarder publishes to OutboxConfig.ProbeTopic.
+const MetadataOutboxProbe = "shortlink.outbox_probe"
+
+// Validate checks the forwarder wiring without forwarding messages: the forwarder
+// builds, the subscriber accepts a subscription to the forwarder topic, the topic's
+// messages table exists when the SQL dialect is known, and the real publisher accepts
+// a probe on ProbeTopic when one is configured. Nothing is created.
+// Every failing stage is reported in the joined error.
+func (s *forwarderState) Validate(ctx context.Context) error {
+	if s == nil || s.cfg == nil {
+		return nil
+	}
+
+	if ctx == nil {
+		return errNilContext
+	}
+
+	var errs []error
+
+	if _, err := s.ensureForwarder(); err != nil {
+		errs = append(errs, s.stageError("build", err))
+	}
+
+	if err := s.validateSubscriber(ctx); err != nil {
+		errs = append(errs, s.stageError("subscriber", err))
+	}
+
+	if err := s.validateTopic(ctx); err != nil {
+		errs = append(errs, s.stageError("topic", err))
+	}
+
+	if err := s.validatePublisher(); err != nil {
+		errs = append(errs, s.stageError("publisher", err))
+	}
+
+	err := errors.Join(errs...)
+	if err != nil {
+		s.cfg.Logger.Warn("Outbox forwarder validation failed",
+			slog.String("forwarder", s.cfg.ForwarderName),
+			slog.String("error", err.Error()),
+		)
+
+		return err
+	}
+
+	s.cfg.Logger.Info("Outbox forwarder validated",
+		slog.String("forwarder", s.cfg.ForwarderName),
+	)
+
+	return nil
+}
+
+// validateSubscriber subscribes to the forwarder topic and cancels at once, so no
+// message is consumed.
+func (s *forwarderState) validateSubscriber(ctx context.Context) error {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	_, err := s.cfg.Subscriber.Subscribe(subCtx, s.cfg.ForwarderName)
+
+	return err
+}
+
+// validateTopic checks that the messages table of the forwarder topic exists. It is
+// skipped when the dialect is unknown: a custom Subscriber on DB without adapters.
+func (s *forwarderState) validateTopic(ctx context.Context) error {
+	var namer interface{ MessagesTable(topic string) string }
+
+	switch adapter := s.cfg.SchemaAdapter.(type) {
+	case interface{ MessagesTable(topic string) string }:
+		namer = adapter
+	case nil:
+		if s.cfg.Pool == nil {
+			return nil
+		}
+
+		namer = wmsql.DefaultPostgreSQLSchema{}
+	default:
+		return nil
+	}
+
+	rows, err := s.cfg.DB.QueryContext(ctx, "SELECT 1 FROM "+namer.MessagesTable(s.cfg.ForwarderName)+" LIMIT 0")
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(rows.Err(), rows.Close())
+}
+
+// validatePublisher publishes a probe to ProbeTopic; it is skipped when ProbeTopic is empty.
+func (s *forwarderState) validatePublisher() error {
+	if s.cfg.ProbeTopic == "" {
+		return nil
+	}
+
+	probe := wmmessage.NewMessage(watermill.NewUUID(), nil)
+	probe.Metadata.Set(MetadataOutboxProbe, s.cfg.ForwarderName)
+
+	return s.cfg.RealPublisher.Publish(s.cfg.ProbeTopic, probe)
+}
+
+func (s *forwarderState) stageError(stage string, err error) error {
+	return fmt.Errorf("%w: %s %s: %w", ErrForwarderValidation, s.cfg.ForwarderName, stage, err)
+}
@@ -8,7 +8,8 @@ import (
 type PublishOption func(*publishOptions)
 
 type publishOptions struct {
-	publisher wmmessage.Publisher
+	publisher   wmmessage.Publisher
+	aggregateID string
 }
 
 // WithPublisher uses the given publisher for this call only.
@@ -20,3 +21,12 @@ func WithPublisher(pub wmmessage.Publisher) PublishOption {
 		o.publisher = pub
 	}
 }
+
+// WithAggregateID stamps the aggregate ID into the message metadata (cqrsmessage.MetadataAggregateID).
+// Pair it with cqrsmessage.PartitionKeyByAggregate on the Kafka publisher so all events of one
+// aggregate land on the same partition and keep their publish order.
+func WithAggregateID(id string) PublishOption {
+	return func(o *publishOptions) {
+		o.aggregateID = id
+	}
+}
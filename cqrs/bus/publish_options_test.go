@@ -0,0 +1,36 @@
+package bus
+
+import (
+	"context"
+	"testing"
+
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/require"
+
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+)
+
+type recordingPublisher struct {
+	messages []*wmmessage.Message
+}
+
+func (p *recordingPublisher) Publish(_ string, msgs ...*wmmessage.Message) error {
+	p.messages = append(p.messages, msgs...)
+
+	return nil
+}
+
+func (p *recordingPublisher) Close() error { return nil }
+
+func TestEventBusPublishWithAggregateID(t *testing.T) {
+	namer := cqrsmessage.NewShortlinkNamer("orders")
+	pub := &recordingPublisher{}
+	evtBus := NewEventBus(pub, cqrsmessage.NewJSONMarshaler(namer), namer)
+
+	require.NoError(t, evtBus.Publish(context.Background(), &createOrder{ID: "1"}, WithAggregateID("order-1")))
+	require.NoError(t, evtBus.Publish(context.Background(), &createOrder{ID: "2"}))
+
+	require.Len(t, pub.messages, 2)
+	require.Equal(t, "order-1", pub.messages[0].Metadata.Get(cqrsmessage.MetadataAggregateID))
+	require.Empty(t, pub.messages[1].Metadata.Get(cqrsmessage.MetadataAggregateID))
+}
@@ -2,6 +2,7 @@ package bus
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 	"sync"
 
@@ -62,6 +63,34 @@ func (r *TypeRegistry) RegisterEvent(evt any) error {
 	return nil
 }
 
+// RegisterCommands registers every command and returns a joined error listing each failure.
+// Valid commands are registered even when others fail.
+func (r *TypeRegistry) RegisterCommands(cmds ...any) error {
+	var errs error
+
+	for i, cmd := range cmds {
+		if err := r.RegisterCommand(cmd); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("command #%d (%T): %w", i, cmd, err))
+		}
+	}
+
+	return errs
+}
+
+// RegisterEvents registers every event and returns a joined error listing each failure.
+// Valid events are registered even when others fail.
+func (r *TypeRegistry) RegisterEvents(evts ...any) error {
+	var errs error
+
+	for i, evt := range evts {
+		if err := r.RegisterEvent(evt); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("event #%d (%T): %w", i, evt, err))
+		}
+	}
+
+	return errs
+}
+
 // ResolveCommand returns command type by canonical name.
 func (r *TypeRegistry) ResolveCommand(name string) (reflect.Type, bool) {
 	r.mu.RLock()
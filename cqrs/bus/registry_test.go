@@ -57,3 +57,34 @@ func TestTypeRegistryConcurrentAccess(t *testing.T) {
 		require.True(t, ok, "event %d not found", i)
 	}
 }
+
+func TestTypeRegistryRegisterCommandsJoinsFailures(t *testing.T) {
+	reg := NewTypeRegistry()
+
+	valid := &cqrsmessage.CommandEnvelope{
+		Metadata: map[string]string{
+			cqrsmessage.MetadataTypeName:    "billing.command.create_invoice",
+			cqrsmessage.MetadataTypeVersion: "v1",
+		},
+	}
+
+	err := reg.RegisterCommands(nil, valid, nil)
+	require.Error(t, err)
+	require.ErrorIs(t, err, ErrNilCommandType)
+	require.Contains(t, err.Error(), "command #0")
+	require.Contains(t, err.Error(), "command #2")
+	require.NotContains(t, err.Error(), "command #1")
+
+	_, ok := reg.ResolveCommand(cqrsmessage.NameOf(valid))
+	require.True(t, ok, "valid command should still be registered")
+}
+
+func TestTypeRegistryRegisterEvents(t *testing.T) {
+	reg := NewTypeRegistry()
+
+	require.NoError(t, reg.RegisterEvents())
+
+	err := reg.RegisterEvents(nil)
+	require.ErrorIs(t, err, ErrNilEventType)
+	require.Contains(t, err.Error(), "event #0")
+}
@@ -52,12 +52,12 @@ func main() {
 		Handlers: []router.HandlerRegistration{
 			{
 				Name:    "create_order_command",
-				Topic:   cqrsmessage.TopicForCommand(namer.CommandName(&CreateOrderCommand{})),
+				Topic:   namer.TopicForCommand(namer.CommandName(&CreateOrderCommand{})),
 				Handler: createOrderHandler,
 			},
 			{
 				Name:    "order_created_event",
-				Topic:   cqrsmessage.TopicForEvent(namer.EventName(&OrderCreatedEvent{})),
+				Topic:   namer.TopicForEvent(namer.EventName(&OrderCreatedEvent{})),
 				Handler: orderCreatedHandler,
 			},
 		},
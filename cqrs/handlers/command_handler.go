@@ -25,5 +25,5 @@ func NewCommandHandler[T any](
 		handle = logic.Handle
 	}
 
-	return newWatermillTypedHandler(handle, registry, marshaler, (*bus.TypeRegistry).ResolveCommand, errCommandNotRegistered, errNilCommandLogic, "command")
+	return newWatermillTypedHandler(handle, registry, marshaler, (*bus.TypeRegistry).ResolveCommand, errCommandNotRegistered, errNilCommandLogic, cqrsmessage.KindCommand)
 }
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+	wmmid "github.com/ThreeDotsLabs/watermill/message/router/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/shortlink-org/go-sdk/auth/userid"
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+)
+
+// MessageContext carries request-scoped values that arrived with a message.
+type MessageContext struct {
+	CorrelationID string
+	// UserID is the shortlink.user_id metadata as claimed by the publisher. It is
+	// not authenticated: anyone able to publish on the topic can set it.
+	UserID  string
+	TraceID string
+}
+
+type messageContextKey struct{}
+
+// WithMessageContext stores mc inside ctx for handlers.
+func WithMessageContext(ctx context.Context, mc MessageContext) context.Context {
+	return context.WithValue(ctx, messageContextKey{}, mc)
+}
+
+// FromContext returns the message context populated by ContextMiddleware.
+// Missing values are left empty.
+func FromContext(ctx context.Context) MessageContext {
+	if ctx == nil {
+		return MessageContext{}
+	}
+
+	mc, _ := ctx.Value(messageContextKey{}).(MessageContext)
+
+	return mc
+}
+
+// ContextMiddleware extracts the correlation id, user id and trace context from
+// message metadata into the handler context. The user id from metadata is only
+// exposed as MessageContext.UserID; it does not become the authenticated user.
+func ContextMiddleware(h wmmessage.HandlerFunc) wmmessage.HandlerFunc {
+	return contextMiddleware(h, false)
+}
+
+// TrustedUserContextMiddleware is ContextMiddleware that also stores the user id
+// from metadata as the authenticated user (userid.FromContext, session.GetUserID).
+// Use it only when every publisher on the subscribed topics is trusted.
+func TrustedUserContextMiddleware(h wmmessage.HandlerFunc) wmmessage.HandlerFunc {
+	return contextMiddleware(h, true)
+}
+
+func contextMiddleware(h wmmessage.HandlerFunc, trustUserID bool) wmmessage.HandlerFunc {
+	return func(msg *wmmessage.Message) ([]*wmmessage.Message, error) {
+		ctx := msg.Context()
+		if ctx == nil {
+			//nolint:contextcheck // Watermill may deliver synthetic messages without context.
+			ctx = context.Background()
+		}
+
+		ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(msg.Metadata))
+
+		mc := MessageContext{
+			CorrelationID: wmmid.MessageCorrelationID(msg),
+			UserID:        msg.Metadata.Get(cqrsmessage.MetadataUserID),
+			TraceID:       msg.Metadata.Get(cqrsmessage.MetadataTraceID),
+		}
+
+		if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+			mc.TraceID = spanCtx.TraceID().String()
+		}
+
+		switch {
+		case mc.UserID == "":
+			mc.UserID, _ = userid.FromContext(ctx)
+		case trustUserID:
+			ctx = userid.NewContext(ctx, mc.UserID)
+		}
+
+		msg.SetContext(WithMessageContext(ctx, mc))
+
+		return h(msg)
+	}
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ThreeDotsLabs/watermill"
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+
+	"github.com/shortlink-org/go-sdk/cqrs/bus"
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+)
+
+var errTestHandle = errors.New("handle boom")
+
+type shipOrder struct {
+	ID string `json:"id"`
+}
+
+type shipOrderLogic struct {
+	err error
+}
+
+func (l shipOrderLogic) Handle(context.Context, *shipOrder) error {
+	return l.err
+}
+
+func TestCommandHandlerReturnsTypedErrors(t *testing.T) {
+	// Registry resolves names via cqrsmessage.NameOf, so use the default namer.
+	marshaler := cqrsmessage.NewJSONMarshaler(nil)
+
+	registry := bus.NewTypeRegistry()
+	if err := registry.RegisterCommand(&shipOrder{}); err != nil {
+		t.Fatalf("RegisterCommand failed: %v", err)
+	}
+
+	msg, err := marshaler.Marshal(context.Background(), &shipOrder{ID: "1"})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	t.Run("handler failure", func(t *testing.T) {
+		handler := NewCommandHandler[*shipOrder](shipOrderLogic{err: errTestHandle}, registry, marshaler)
+
+		_, err := handler(msg)
+
+		var handlerErr *bus.HandlerError
+		if !errors.As(err, &handlerErr) {
+			t.Fatalf("expected *bus.HandlerError, got %T: %v", err, err)
+		}
+
+		if handlerErr.Kind != cqrsmessage.KindCommand {
+			t.Errorf("expected kind %q, got %q", cqrsmessage.KindCommand, handlerErr.Kind)
+		}
+
+		if !errors.Is(err, errTestHandle) {
+			t.Errorf("expected wrapped handler error, got %v", err)
+		}
+	})
+
+	t.Run("not registered", func(t *testing.T) {
+		handler := NewCommandHandler[*shipOrder](shipOrderLogic{}, bus.NewTypeRegistry(), marshaler)
+
+		_, err := handler(msg)
+
+		var handlerErr *bus.HandlerError
+		if !errors.As(err, &handlerErr) {
+			t.Fatalf("expected *bus.HandlerError, got %T: %v", err, err)
+		}
+
+		if !errors.Is(err, errCommandNotRegistered) {
+			t.Errorf("expected errCommandNotRegistered, got %v", err)
+		}
+	})
+
+	t.Run("decode failure", func(t *testing.T) {
+		handler := NewCommandHandler[*shipOrder](shipOrderLogic{}, registry, marshaler)
+
+		broken := wmmessage.NewMessage(watermill.NewUUID(), []byte("{"))
+		broken.Metadata = msg.Copy().Metadata
+
+		_, err := handler(broken)
+
+		var marshalErr *bus.MarshalError
+		if !errors.As(err, &marshalErr) {
+			t.Fatalf("expected *bus.MarshalError, got %T: %v", err, err)
+		}
+	})
+}
@@ -25,5 +25,5 @@ func NewEventHandler[T any](
 		handle = logic.Handle
 	}
 
-	return newWatermillTypedHandler(handle, registry, marshaler, (*bus.TypeRegistry).ResolveEvent, errEventNotRegistered, errNilEventLogic, "event")
+	return newWatermillTypedHandler(handle, registry, marshaler, (*bus.TypeRegistry).ResolveEvent, errEventNotRegistered, errNilEventLogic, cqrsmessage.KindEvent)
 }
@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"fmt"
 	"reflect"
 
 	wmmessage "github.com/ThreeDotsLabs/watermill/message"
@@ -20,7 +19,7 @@ func newWatermillTypedHandler[T any](
 	resolve resolveRegistered,
 	errNotRegistered error,
 	errNilLogic error,
-	kind string,
+	kind cqrsmessage.MessageKind,
 ) wmmessage.HandlerFunc {
 	expectedType := handlerTypeOf[T]()
 
@@ -48,17 +47,17 @@ func newWatermillTypedHandler[T any](
 
 		payloadType, ok := resolve(registry, name)
 		if !ok {
-			return nil, fmt.Errorf("%w: %s", errNotRegistered, name)
+			return nil, &bus.HandlerError{Kind: kind, Name: name, Err: errNotRegistered}
 		}
 
 		instance := newValue(payloadType)
 		if err := marshaler.Unmarshal(msg, instance); err != nil {
-			return nil, fmt.Errorf("unmarshal %s %s: %w", kind, name, err)
+			return nil, &bus.MarshalError{Kind: kind, Name: name, Err: err}
 		}
 
 		typed, err := typedPayload[T](instance, expectedType, payloadType)
 		if err != nil {
-			return nil, fmt.Errorf("%s %s: %w", kind, name, err)
+			return nil, &bus.HandlerError{Kind: kind, Name: name, Err: err}
 		}
 
 		msgCtx := msg.Context()
@@ -68,7 +67,7 @@ func newWatermillTypedHandler[T any](
 		}
 
 		if err := handle(msgCtx, typed); err != nil {
-			return nil, fmt.Errorf("handle %s %s: %w", kind, name, err)
+			return nil, &bus.HandlerError{Kind: kind, Name: name, Err: err}
 		}
 
 		return nil, nil
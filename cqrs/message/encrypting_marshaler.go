@@ -0,0 +1,398 @@
+package message
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+)
+
+// encryptTag marks struct fields whose JSON value EncryptingMarshaler encrypts: `cqrs:"encrypt"`.
+const encryptTag = "encrypt"
+
+// KeyProvider supplies AES keys (16, 24 or 32 bytes) to EncryptingMarshaler.
+//
+// Keys are addressed by ID so they can be rotated: new messages use the current
+// key, while messages encrypted before a rotation carry the ID of their key in
+// MetadataEncryptionKeyID and keep decrypting as long as Key still resolves it.
+type KeyProvider interface {
+	// CurrentKey returns the ID and key used to encrypt new messages.
+	CurrentKey(ctx context.Context) (id string, key []byte, err error)
+	// Key returns the key registered under id.
+	Key(ctx context.Context, id string) ([]byte, error)
+}
+
+// StaticKeyProvider is an in-memory KeyProvider with a fixed key set.
+type StaticKeyProvider struct {
+	current string
+	keys    map[string][]byte
+}
+
+// NewStaticKeyProvider encrypts with keys[current] and decrypts with any key in keys.
+func NewStaticKeyProvider(current string, keys map[string][]byte) *StaticKeyProvider {
+	return &StaticKeyProvider{current: current, keys: keys}
+}
+
+// CurrentKey implements KeyProvider.
+func (p *StaticKeyProvider) CurrentKey(ctx context.Context) (string, []byte, error) {
+	key, err := p.Key(ctx, p.current)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return p.current, key, nil
+}
+
+// Key implements KeyProvider.
+func (p *StaticKeyProvider) Key(_ context.Context, id string) ([]byte, error) {
+	key, ok := p.keys[id]
+	if !ok || id == "" {
+		return nil, fmt.Errorf("%w: unknown key %q", ErrEncryptionKeyID, id)
+	}
+
+	return key, nil
+}
+
+// EncryptingMarshaler encrypts selected fields of JSON payloads produced by an
+// inner marshaler with AES-GCM, leaving the remaining fields and all metadata in
+// plaintext so messages can still be routed, partitioned and inspected.
+//
+// Fields are selected by `cqrs:"encrypt"` struct tags on the marshaled type and
+// by explicit dot-separated JSON paths (e.g. "customer.email"). Each encrypted
+// value is replaced by a base64 string of nonce+ciphertext bound to its path;
+// the key ID is stored in MetadataEncryptionKeyID.
+type EncryptingMarshaler struct {
+	inner Marshaler
+	keys  KeyProvider
+	paths []string
+
+	tagPaths sync.Map // reflect.Type -> []string
+}
+
+// NewEncryptingMarshaler wraps inner, which must produce JSON payloads.
+// paths lists JSON paths to encrypt in addition to tagged struct fields.
+func NewEncryptingMarshaler(inner Marshaler, keys KeyProvider, paths ...string) *EncryptingMarshaler {
+	return &EncryptingMarshaler{
+		inner: inner,
+		keys:  keys,
+		paths: paths,
+	}
+}
+
+// Marshal encodes v with the inner marshaler and encrypts the selected fields.
+func (m *EncryptingMarshaler) Marshal(ctx context.Context, v any) (*wmmessage.Message, error) {
+	msg, err := m.inner.Marshal(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := m.fields(v)
+	if len(paths) == 0 {
+		return msg, nil
+	}
+
+	if ct := msg.Metadata.Get(MetadataContentType); ct != "" && ct != ContentTypeJSON {
+		return nil, fmt.Errorf("%w: cannot encrypt fields of %s", ErrUnsupportedContentType, ct)
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	keyID, key, err := m.keys.CurrentKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("encryption key: %w", err)
+	}
+
+	if keyID == "" {
+		return nil, ErrEncryptionKeyID
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, fmt.Errorf("encryption key %s: %w", keyID, err)
+	}
+
+	doc, err := decodeDocument(msg.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		parent, field, ok := lookupPath(doc, path)
+		if !ok {
+			continue
+		}
+
+		plaintext, err := json.Marshal(parent[field])
+		if err != nil {
+			return nil, fmt.Errorf("encrypt %s: %w", path, err)
+		}
+
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, fmt.Errorf("encrypt %s: %w", path, err)
+		}
+
+		sealed := aead.Seal(nonce, nonce, plaintext, []byte(path))
+		parent[field] = base64.StdEncoding.EncodeToString(sealed)
+	}
+
+	payload, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	msg.Payload = payload
+	msg.Metadata.Set(MetadataEncryptionKeyID, keyID)
+
+	return msg, nil
+}
+
+// Unmarshal decrypts the selected fields with the key named in MetadataEncryptionKeyID,
+// then decodes with the inner marshaler. Messages without the header are passed through.
+func (m *EncryptingMarshaler) Unmarshal(msg *wmmessage.Message, v any) error {
+	if msg == nil {
+		return errMessageNil
+	}
+
+	keyID := msg.Metadata.Get(MetadataEncryptionKeyID)
+	if keyID == "" {
+		return m.inner.Unmarshal(msg, v)
+	}
+
+	ctx := msg.Context()
+
+	key, err := m.keys.Key(ctx, keyID)
+	if err != nil {
+		return fmt.Errorf("encryption key %s: %w", keyID, err)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return fmt.Errorf("encryption key %s: %w", keyID, err)
+	}
+
+	doc, err := decodeDocument(msg.Payload)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range m.fields(v) {
+		parent, field, ok := lookupPath(doc, path)
+		if !ok {
+			continue
+		}
+
+		encoded, ok := parent[field].(string)
+		if !ok {
+			return fmt.Errorf("%w: %s is not encrypted", ErrDecryptField, path)
+		}
+
+		sealed, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(sealed) < aead.NonceSize() {
+			return fmt.Errorf("%w: %s is malformed", ErrDecryptField, path)
+		}
+
+		nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+
+		plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(path))
+		if err != nil {
+			return fmt.Errorf("%w: %s with key %s", ErrDecryptField, path, keyID)
+		}
+
+		value, err := decodeValue(plaintext)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrDecryptField, path, err)
+		}
+
+		parent[field] = value
+	}
+
+	payload, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	decrypted := msg.Copy()
+	decrypted.Payload = payload
+	decrypted.SetContext(ctx)
+
+	return m.inner.Unmarshal(decrypted, v)
+}
+
+// Name delegates to the inner marshaler.
+func (m *EncryptingMarshaler) Name(v any) string {
+	return m.inner.Name(v)
+}
+
+// NameFromMessage delegates to the inner marshaler.
+func (m *EncryptingMarshaler) NameFromMessage(msg *wmmessage.Message) string {
+	return m.inner.NameFromMessage(msg)
+}
+
+// fields returns the explicit paths plus the tagged paths of v's type.
+func (m *EncryptingMarshaler) fields(v any) []string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return m.paths
+	}
+
+	cached, ok := m.tagPaths.Load(t)
+	if !ok {
+		cached, _ = m.tagPaths.LoadOrStore(t, taggedPaths(t, "", map[reflect.Type]bool{}))
+	}
+
+	tagged, _ := cached.([]string)
+	if len(tagged) == 0 {
+		return m.paths
+	}
+
+	paths := slices.Concat(m.paths, tagged)
+	slices.Sort(paths)
+
+	return slices.Compact(paths)
+}
+
+// taggedPaths walks t for `cqrs:"encrypt"` fields, following nested structs by their JSON names.
+func taggedPaths(t reflect.Type, prefix string, seen map[reflect.Type]bool) []string {
+	if seen[t] {
+		return nil
+	}
+
+	seen[t] = true
+	defer delete(seen, t)
+
+	var paths []string
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+
+		name, ok := jsonFieldName(f)
+		if !ok {
+			continue
+		}
+
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			paths = append(paths, taggedPaths(ft, prefix, seen)...)
+
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		path := prefix + name
+
+		if slices.Contains(strings.Split(f.Tag.Get("cqrs"), ","), encryptTag) {
+			paths = append(paths, path)
+
+			continue
+		}
+
+		if ft.Kind() == reflect.Struct {
+			paths = append(paths, taggedPaths(ft, path+".", seen)...)
+		}
+	}
+
+	return paths
+}
+
+// jsonFieldName returns the explicit json tag name ("" when untagged) and false for skipped fields.
+func jsonFieldName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+
+	return name, true
+}
+
+// lookupPath resolves a dot-separated path to its parent object and key.
+func lookupPath(doc map[string]any, path string) (map[string]any, string, bool) {
+	segments := strings.Split(path, ".")
+	parent := doc
+
+	for _, segment := range segments[:len(segments)-1] {
+		next, ok := parent[segment].(map[string]any)
+		if !ok {
+			return nil, "", false
+		}
+
+		parent = next
+	}
+
+	field := segments[len(segments)-1]
+
+	value, ok := parent[field]
+	if !ok || value == nil {
+		return nil, "", false
+	}
+
+	return parent, field, true
+}
+
+func decodeDocument(payload []byte) (map[string]any, error) {
+	if len(payload) == 0 {
+		return nil, errMessageEmptyBody
+	}
+
+	value, err := decodeValue(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: payload is not a JSON object", ErrUnsupportedContentType)
+	}
+
+	return doc, nil
+}
+
+// decodeValue decodes JSON keeping numbers as json.Number so they round-trip unchanged.
+func decodeValue(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,89 @@
+package message
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+)
+
+type testSensitiveCommand struct {
+	OrderID string `json:"order_id"`
+	Email   string `json:"email"   cqrs:"encrypt"`
+}
+
+func TestEncryptingMarshalerRoundTrip(t *testing.T) {
+	keys := NewStaticKeyProvider("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)})
+	m := NewEncryptingMarshaler(NewJSONMarshaler(NewShortlinkNamer("test")), keys)
+
+	original := &testSensitiveCommand{OrderID: "order-123", Email: "alice@example.com"}
+
+	msg, err := m.Marshal(context.Background(), original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	if got := msg.Metadata.Get(MetadataEncryptionKeyID); got != "k1" {
+		t.Errorf("expected key id 'k1', got %q", got)
+	}
+
+	if bytes.Contains(msg.Payload, []byte(original.Email)) {
+		t.Errorf("payload leaks encrypted field: %s", msg.Payload)
+	}
+
+	var wire map[string]any
+	if err := json.Unmarshal(msg.Payload, &wire); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+
+	if wire["order_id"] != original.OrderID {
+		t.Errorf("expected order_id to stay plaintext, got %v", wire["order_id"])
+	}
+
+	received := wmmessage.NewMessage(msg.UUID, msg.Payload)
+	received.Metadata = msg.Metadata
+
+	var decoded testSensitiveCommand
+	if err := m.Unmarshal(received, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if decoded != *original {
+		t.Errorf("expected %+v, got %+v", *original, decoded)
+	}
+
+	if !bytes.Equal(received.Payload, msg.Payload) {
+		t.Error("Unmarshal must not modify the received payload")
+	}
+}
+
+func TestEncryptingMarshalerKeyRotation(t *testing.T) {
+	oldKey, newKey := bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 32)
+	inner := NewJSONMarshaler(NewShortlinkNamer("test"))
+
+	before := NewEncryptingMarshaler(inner, NewStaticKeyProvider("k1", map[string][]byte{"k1": oldKey}))
+
+	msg, err := before.Marshal(context.Background(), &testSensitiveCommand{OrderID: "order-1", Email: "bob@example.com"})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	after := NewEncryptingMarshaler(inner, NewStaticKeyProvider("k2", map[string][]byte{"k1": oldKey, "k2": newKey}))
+
+	var decoded testSensitiveCommand
+	if err := after.Unmarshal(msg, &decoded); err != nil {
+		t.Fatalf("Unmarshal with rotated provider failed: %v", err)
+	}
+
+	if decoded.Email != "bob@example.com" {
+		t.Errorf("expected decrypted email, got %q", decoded.Email)
+	}
+
+	retired := NewEncryptingMarshaler(inner, NewStaticKeyProvider("k2", map[string][]byte{"k2": newKey}))
+	if err := retired.Unmarshal(msg, &decoded); !errors.Is(err, ErrEncryptionKeyID) {
+		t.Errorf("expected ErrEncryptionKeyID for a retired key, got %v", err)
+	}
+}
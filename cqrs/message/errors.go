@@ -2,9 +2,35 @@ package message
 
 import "errors"
 
+// ErrUnsupportedContentType is returned when no codec is registered for a message content type.
+var ErrUnsupportedContentType = errors.New("cqrs/message: unsupported content type")
+
+var (
+	// ErrSchemaRegistryHeader is returned when a payload lacks a valid schema registry wire-format header.
+	ErrSchemaRegistryHeader = errors.New("cqrs/message: invalid schema registry header")
+	// ErrSchemaMismatch is returned when the embedded schema ID is not registered for the message subject.
+	ErrSchemaMismatch = errors.New("cqrs/message: schema id does not match subject")
+	// ErrUnsupportedSchemaFormat is returned by NewSchemaRegistryMarshaler for formats it cannot encode.
+	ErrUnsupportedSchemaFormat = errors.New("cqrs/message: unsupported schema format")
+)
+
+var (
+	// ErrEncryptionKeyID is returned when an encrypted message lacks a usable encryption key ID.
+	ErrEncryptionKeyID = errors.New("cqrs/message: missing encryption key id")
+	// ErrDecryptField is returned when an encrypted field cannot be decrypted with the referenced key.
+	ErrDecryptField = errors.New("cqrs/message: cannot decrypt field")
+)
+
+// ErrInvalidNameSegment is returned for service or type names that would corrupt the canonical name or topic.
+var ErrInvalidNameSegment = errors.New("cqrs/message: invalid name segment")
+
+// ErrInvalidTopicPrefix is returned by NewShortlinkNamerStrict for a WithTopicPrefix
+// prefix that is not a valid Kafka topic name prefix.
+var ErrInvalidTopicPrefix = errors.New("cqrs/message: invalid topic prefix")
+
 var (
-	errMessageNil        = errors.New("cqrs/message: message is nil")
-	errMessageEmptyBody  = errors.New("cqrs/message: message payload is empty")
-	errValueNotProto     = errors.New("cqrs/message: value does not implement proto.Message")
-	errTargetNotProto    = errors.New("cqrs/message: target does not implement proto.Message")
+	errMessageNil       = errors.New("cqrs/message: message is nil")
+	errMessageEmptyBody = errors.New("cqrs/message: message payload is empty")
+	errValueNotProto    = errors.New("cqrs/message: value does not implement proto.Message")
+	errTargetNotProto   = errors.New("cqrs/message: target does not implement proto.Message")
 )
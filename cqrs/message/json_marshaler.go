@@ -46,7 +46,7 @@ func (m *JSONMarshaler) Marshal(ctx context.Context, v any) (*wmmessage.Message,
 	}
 
 	if wmMsg.Metadata.Get(MetadataContentType) == "" {
-		wmMsg.Metadata.Set(MetadataContentType, "application/json")
+		wmMsg.Metadata.Set(MetadataContentType, ContentTypeJSON)
 	}
 
 	if wmMsg.Metadata.Get(MetadataServiceName) == "" && m.namer != nil {
@@ -60,7 +60,7 @@ func (m *ProtoMarshaler) Marshal(ctx context.Context, v any) (*wmmessage.Message
 	}
 
 	if wmMsg.Metadata.Get(MetadataContentType) == "" {
-		wmMsg.Metadata.Set(MetadataContentType, "application/x-protobuf")
+		wmMsg.Metadata.Set(MetadataContentType, ContentTypeProtobuf)
 	}
 
 	if wmMsg.Metadata.Get(MetadataServiceName) == "" && m.namer != nil {
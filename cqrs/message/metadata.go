@@ -10,6 +10,8 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/shortlink-org/go-sdk/auth/userid"
 )
 
 var (
@@ -29,6 +31,10 @@ var (
 	MetadataContentType = metadataKey("content_type")
 	MetadataOccurredAt  = metadataKey("occurred_at")
 	MetadataMessageKind = metadataKey("message_kind")
+	MetadataAggregateID = metadataKey("aggregate_id")
+	MetadataUserID      = metadataKey("user_id")
+	// MetadataEncryptionKeyID names the key EncryptingMarshaler used for the payload's encrypted fields.
+	MetadataEncryptionKeyID = metadataKey("encryption_key_id")
 )
 
 func metadataKey(suffix string) string {
@@ -96,6 +102,11 @@ func SetTrace(ctx context.Context, msg *wmmessage.Message) {
 		msg.Metadata.Set(MetadataServiceName, service)
 	}
 
+	// Carry the authenticated user across the broker.
+	if userID, ok := userid.FromContext(ctx); ok && userID != "" && msg.Metadata.Get(MetadataUserID) == "" {
+		msg.Metadata.Set(MetadataUserID, userID)
+	}
+
 	if msg.Metadata.Get(MetadataOccurredAt) == "" {
 		msg.Metadata.Set(MetadataOccurredAt, time.Now().UTC().Format(time.RFC3339Nano))
 	}
@@ -0,0 +1,125 @@
+package message
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"strings"
+
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+)
+
+const (
+	// ContentTypeJSON is the media type produced by JSONMarshaler.
+	ContentTypeJSON = "application/json"
+	// ContentTypeProtobuf is the media type produced by ProtoMarshaler.
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// MultiMarshaler delegates to a codec selected by the content-type metadata key.
+//
+// It lets a single router consume topics carrying a mix of JSON and protobuf
+// payloads (e.g. during a migration). Messages without a content type are
+// decoded with the default codec; Marshal always encodes with the default codec.
+type MultiMarshaler struct {
+	defaultType string
+	codecs      map[string]Marshaler
+}
+
+// NewMultiMarshaler builds a marshaler that understands JSON and protobuf payloads.
+// JSON is used for encoding and for messages that carry no content type.
+func NewMultiMarshaler(namer Namer) *MultiMarshaler {
+	return &MultiMarshaler{
+		defaultType: ContentTypeJSON,
+		codecs: map[string]Marshaler{
+			ContentTypeJSON:     NewJSONMarshaler(namer),
+			ContentTypeProtobuf: NewProtoMarshaler(namer),
+		},
+	}
+}
+
+// Register adds or replaces the codec used for contentType.
+func (m *MultiMarshaler) Register(contentType string, codec Marshaler) {
+	if codec == nil {
+		return
+	}
+
+	m.codecs[normalizeContentType(contentType)] = codec
+}
+
+// SetDefault selects the codec used for encoding and for messages without content type.
+func (m *MultiMarshaler) SetDefault(contentType string) error {
+	contentType = normalizeContentType(contentType)
+	if _, ok := m.codecs[contentType]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnsupportedContentType, contentType)
+	}
+
+	m.defaultType = contentType
+
+	return nil
+}
+
+// Marshal encodes payload with the default codec.
+func (m *MultiMarshaler) Marshal(ctx context.Context, v any) (*wmmessage.Message, error) {
+	return m.codecs[m.defaultType].Marshal(ctx, v)
+}
+
+// Unmarshal decodes payload with the codec matching the message content type.
+func (m *MultiMarshaler) Unmarshal(msg *wmmessage.Message, v any) error {
+	if msg == nil {
+		return errMessageNil
+	}
+
+	codec, err := m.codecFor(msg)
+	if err != nil {
+		return err
+	}
+
+	return codec.Unmarshal(msg, v)
+}
+
+// Name returns canonical name for payload.
+func (m *MultiMarshaler) Name(v any) string {
+	return m.codecs[m.defaultType].Name(v)
+}
+
+// NameFromMessage reconstructs canonical name using message metadata.
+func (m *MultiMarshaler) NameFromMessage(msg *wmmessage.Message) string {
+	if msg == nil {
+		return ""
+	}
+
+	codec, err := m.codecFor(msg)
+	if err != nil {
+		codec = m.codecs[m.defaultType]
+	}
+
+	return codec.NameFromMessage(msg)
+}
+
+func (m *MultiMarshaler) codecFor(msg *wmmessage.Message) (Marshaler, error) {
+	contentType := normalizeContentType(msg.Metadata.Get(MetadataContentType))
+	if contentType == "" {
+		contentType = m.defaultType
+	}
+
+	codec, ok := m.codecs[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedContentType, contentType)
+	}
+
+	return codec, nil
+}
+
+func normalizeContentType(contentType string) string {
+	contentType = strings.TrimSpace(contentType)
+	if contentType == "" {
+		return ""
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+
+	return strings.ToLower(contentType)
+}
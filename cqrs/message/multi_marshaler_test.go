@@ -0,0 +1,113 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestMultiMarshalerRoundTripJSON(t *testing.T) {
+	namer := NewShortlinkNamer("test")
+	m := NewMultiMarshaler(namer)
+
+	original := &testCommand{OrderId: "order-123", Amount: 42}
+
+	msg, err := NewJSONMarshaler(namer).Marshal(context.Background(), original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded testCommand
+	if err := m.Unmarshal(msg, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if decoded != *original {
+		t.Errorf("expected %+v, got %+v", *original, decoded)
+	}
+}
+
+func TestMultiMarshalerRoundTripProto(t *testing.T) {
+	namer := NewShortlinkNamer("test")
+	m := NewMultiMarshaler(namer)
+
+	msg, err := NewProtoMarshaler(namer).Marshal(context.Background(), wrapperspb.String("hello"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	if msg.Metadata.Get(MetadataContentType) != ContentTypeProtobuf {
+		t.Fatalf("expected content type %s, got %s", ContentTypeProtobuf, msg.Metadata.Get(MetadataContentType))
+	}
+
+	var decoded wrapperspb.StringValue
+	if err := m.Unmarshal(msg, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if decoded.GetValue() != "hello" {
+		t.Errorf("expected 'hello', got %q", decoded.GetValue())
+	}
+}
+
+func TestMultiMarshalerDefaultsToJSONWithoutContentType(t *testing.T) {
+	m := NewMultiMarshaler(NewShortlinkNamer("test"))
+	msg := wmmessage.NewMessage("id", []byte(`{"order_id":"order-1","amount":1}`))
+
+	var decoded testCommand
+	if err := m.Unmarshal(msg, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if decoded.OrderId != "order-1" {
+		t.Errorf("expected OrderId order-1, got %s", decoded.OrderId)
+	}
+}
+
+func TestMultiMarshalerContentTypeParameters(t *testing.T) {
+	m := NewMultiMarshaler(NewShortlinkNamer("test"))
+	msg := wmmessage.NewMessage("id", []byte(`{"order_id":"order-1"}`))
+	msg.Metadata.Set(MetadataContentType, "Application/JSON; charset=utf-8")
+
+	var decoded testCommand
+	if err := m.Unmarshal(msg, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+}
+
+func TestMultiMarshalerUnsupportedContentType(t *testing.T) {
+	m := NewMultiMarshaler(NewShortlinkNamer("test"))
+	msg := wmmessage.NewMessage("id", []byte("payload"))
+	msg.Metadata.Set(MetadataContentType, "application/xml")
+
+	var decoded testCommand
+
+	err := m.Unmarshal(msg, &decoded)
+	if !errors.Is(err, ErrUnsupportedContentType) {
+		t.Fatalf("expected ErrUnsupportedContentType, got %v", err)
+	}
+}
+
+func TestMultiMarshalerSetDefault(t *testing.T) {
+	m := NewMultiMarshaler(NewShortlinkNamer("test"))
+
+	if err := m.SetDefault(ContentTypeProtobuf); err != nil {
+		t.Fatalf("SetDefault failed: %v", err)
+	}
+
+	msg, err := m.Marshal(context.Background(), wrapperspb.String("hello"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	if msg.Metadata.Get(MetadataContentType) != ContentTypeProtobuf {
+		t.Errorf("expected content type %s, got %s", ContentTypeProtobuf, msg.Metadata.Get(MetadataContentType))
+	}
+
+	if err := m.SetDefault("text/plain"); !errors.Is(err, ErrUnsupportedContentType) {
+		t.Errorf("expected ErrUnsupportedContentType, got %v", err)
+	}
+}
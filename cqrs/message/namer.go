@@ -1,6 +1,7 @@
 package message
 
 import (
+	"fmt"
 	"os"
 	"reflect"
 	"regexp"
@@ -36,20 +37,91 @@ type Namer interface {
 
 // ShortlinkNamer implements the Shortlink naming convention.
 type ShortlinkNamer struct {
-	serviceName string
-	version     string
+	serviceName       string
+	version           string
+	aggregateResolver func(v any) string
+	topicPrefix       string
+	topicPrefixErr    error
+}
+
+// NamerOption configures a ShortlinkNamer.
+type NamerOption func(*ShortlinkNamer)
+
+// WithAggregateResolver overrides how EventName derives the aggregate segment,
+// e.g. from a struct tag or an interface method, for protobuf packages that do not
+// follow domain.{aggregate}.v1. When resolve returns "", the protobuf package is used.
+// Aggregates set explicitly through MetadataTypeName are never overridden.
+func WithAggregateResolver(resolve func(v any) string) NamerOption {
+	return func(n *ShortlinkNamer) {
+		n.aggregateResolver = resolve
+	}
+}
+
+// WithTopicPrefix prepends an environment or tenant namespace to every topic
+// returned by TopicForCommand and TopicForEvent, e.g. "staging" turns
+// "billing.command.create_order.v1" into "staging.billing.command.create_order.v1".
+// A trailing "." is added when missing. Characters Kafka does not allow in topic
+// names are replaced with "_"; NewShortlinkNamerStrict rejects them instead.
+func WithTopicPrefix(prefix string) NamerOption {
+	return func(n *ShortlinkNamer) {
+		n.topicPrefix, n.topicPrefixErr = normalizeTopicPrefix(prefix)
+	}
 }
 
 // NewShortlinkNamer creates a namer bound to a service name.
-func NewShortlinkNamer(serviceName string) *ShortlinkNamer {
+// Separators, whitespace and control characters in the name are replaced
+// with "_"; use NewShortlinkNamerStrict to reject such names instead.
+func NewShortlinkNamer(serviceName string, opts ...NamerOption) *ShortlinkNamer {
 	if strings.TrimSpace(serviceName) == "" {
 		serviceName = defaultServiceName()
 	}
 
-	return &ShortlinkNamer{
-		serviceName: normalizeSegment(serviceName),
+	namer := &ShortlinkNamer{
+		serviceName: sanitizeSegment(normalizeSegment(serviceName)),
 		version:     defaultVersion,
 	}
+
+	for _, opt := range opts {
+		opt(namer)
+	}
+
+	return namer
+}
+
+// NewShortlinkNamerStrict is like NewShortlinkNamer but returns ErrInvalidNameSegment
+// when the service name would have to be sanitized, and ErrInvalidTopicPrefix
+// when the WithTopicPrefix prefix would.
+func NewShortlinkNamerStrict(serviceName string, opts ...NamerOption) (*ShortlinkNamer, error) {
+	if strings.TrimSpace(serviceName) != "" {
+		if err := ValidateSegment(serviceName); err != nil {
+			return nil, err
+		}
+	}
+
+	namer := NewShortlinkNamer(serviceName, opts...)
+	if namer.topicPrefixErr != nil {
+		return nil, namer.topicPrefixErr
+	}
+
+	return namer, nil
+}
+
+// ValidateSegment reports whether s can be used as one segment of
+// {service}.{kind}.{name}.{version}: it must be non-empty after trimming and
+// must not contain dots, slashes, backslashes, whitespace or control characters.
+func ValidateSegment(s string) error {
+	s = normalizeSegment(s)
+	if s == "" {
+		return fmt.Errorf("%w: empty", ErrInvalidNameSegment)
+	}
+
+	for _, r := range s {
+		if isSegmentSeparator(r) {
+			return fmt.Errorf("%w: %q contains %q", ErrInvalidNameSegment, s, r)
+		}
+	}
+
+	return nil
 }
 
 // ServiceName returns configured service identifier.
@@ -69,6 +141,14 @@ func (n *ShortlinkNamer) CommandName(v any) string {
 // {service}.{aggregate}.{event}.{version}
 func (n *ShortlinkNamer) EventName(v any) string {
 	comps := buildNameComponents(v, n.serviceName, string(KindEvent), n.version)
+
+	if aggregate := n.resolveAggregate(v); aggregate != "" {
+		comps.Kind = aggregate
+		comps.Name = strings.TrimPrefix(comps.Name, aggregate+"_")
+
+		return comps.String()
+	}
+
 	// For events, Kind field is used as aggregate (ADR-0002 format)
 	// If aggregate is not set, use service name as aggregate
 	if comps.Kind == string(KindEvent) || comps.Kind == "" {
@@ -90,14 +170,28 @@ func (n *ShortlinkNamer) EventName(v any) string {
 	return comps.String()
 }
 
-// TopicForCommand resolves Kafka topic name for a command.
+// resolveAggregate returns the aggregate from the configured resolver, or ""
+// when there is none or the metadata already names the aggregate.
+func (n *ShortlinkNamer) resolveAggregate(v any) string {
+	if n.aggregateResolver == nil {
+		return ""
+	}
+
+	if strings.Contains(metadataFromValue(v)[MetadataTypeName], ".") {
+		return ""
+	}
+
+	return normalizeSegment(n.aggregateResolver(v))
+}
+
+// TopicForCommand resolves Kafka topic name for a command, with the WithTopicPrefix prefix.
 func (n *ShortlinkNamer) TopicForCommand(name string) string {
-	return TopicForCommand(name)
+	return n.topicPrefix + TopicForCommand(name)
 }
 
-// TopicForEvent resolves Kafka topic name for an event.
+// TopicForEvent resolves Kafka topic name for an event, with the WithTopicPrefix prefix.
 func (n *ShortlinkNamer) TopicForEvent(name string) string {
-	return TopicForEvent(name)
+	return n.topicPrefix + TopicForEvent(name)
 }
 
 // NameOf extracts fully qualified name using metadata or protobuf descriptors.
@@ -127,10 +221,10 @@ type nameComponents struct {
 }
 
 func (c nameComponents) String() string {
-	service := normalizeSegment(c.Service)
-	kind := normalizeSegment(c.Kind)
-	name := normalizeSegment(c.Name)
-	version := normalizeVersion(c.Version)
+	service := sanitizeSegment(normalizeSegment(c.Service))
+	kind := sanitizeSegment(normalizeSegment(c.Kind))
+	name := sanitizeSegment(normalizeSegment(c.Name))
+	version := sanitizeSegment(normalizeVersion(c.Version))
 
 	return strings.Join([]string{service, kind, name, version}, ".")
 }
@@ -349,6 +443,25 @@ func normalizeSegment(s string) string {
 	return strings.ToLower(strings.TrimSpace(s))
 }
 
+// sanitizeSegment replaces characters that would split or corrupt a name segment.
+func sanitizeSegment(s string) string {
+	if strings.IndexFunc(s, isSegmentSeparator) < 0 {
+		return s
+	}
+
+	return strings.Map(func(r rune) rune {
+		if isSegmentSeparator(r) {
+			return '_'
+		}
+
+		return r
+	}, s)
+}
+
+func isSegmentSeparator(r rune) bool {
+	return r == '.' || r == '/' || r == '\\' || unicode.IsSpace(r) || unicode.IsControl(r)
+}
+
 func normalizeVersion(v string) string {
 	v = strings.TrimSpace(v)
 	if versionSegment.MatchString(v) {
@@ -366,6 +479,48 @@ func sanitizeTopic(name string) string {
 	return strings.ReplaceAll(strings.ToLower(name), " ", "_")
 }
 
+// normalizeTopicPrefix lowercases prefix and ensures it ends with ".". Runes
+// outside Kafka's topic alphabet [a-z0-9._-] and empty dot-separated parts are
+// reported with ErrInvalidTopicPrefix; the returned prefix has them replaced with "_".
+func normalizeTopicPrefix(prefix string) (string, error) {
+	prefix = normalizeSegment(prefix)
+	if prefix == "" {
+		return "", nil
+	}
+
+	prefix = strings.TrimSuffix(prefix, ".")
+
+	var err error
+
+	parts := strings.Split(prefix, ".")
+	for i, part := range parts {
+		if part == "" {
+			err = fmt.Errorf("%w: %q has an empty segment", ErrInvalidTopicPrefix, prefix)
+			parts[i] = "_"
+
+			continue
+		}
+
+		parts[i] = strings.Map(func(r rune) rune {
+			if isTopicRune(r) {
+				return r
+			}
+
+			if err == nil {
+				err = fmt.Errorf("%w: %q contains %q", ErrInvalidTopicPrefix, prefix, r)
+			}
+
+			return '_'
+		}, part)
+	}
+
+	return strings.Join(parts, ".") + ".", err
+}
+
+func isTopicRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '-'
+}
+
 func defaultServiceName() string {
 	if svc := strings.TrimSpace(os.Getenv("SERVICE_NAME")); svc != "" {
 		return strings.ToLower(svc)
@@ -2,9 +2,11 @@ package message
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type (
@@ -78,3 +80,140 @@ func TestInferKindDefaultsToCommand(t *testing.T) {
 		t.Fatalf("expected KindEvent, got %s", got)
 	}
 }
+
+func TestNewShortlinkNamerStrictRejectsSeparators(t *testing.T) {
+	for _, serviceName := range []string{"billing.api", "billing api", "billing/api", "billing\tapi"} {
+		if _, err := NewShortlinkNamerStrict(serviceName); !errors.Is(err, ErrInvalidNameSegment) {
+			t.Fatalf("expected ErrInvalidNameSegment for %q, got %v", serviceName, err)
+		}
+	}
+
+	namer, err := NewShortlinkNamerStrict("  Billing  ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if namer.ServiceName() != "billing" {
+		t.Fatalf("unexpected service name: %s", namer.ServiceName())
+	}
+}
+
+func TestNewShortlinkNamerSanitizesSeparators(t *testing.T) {
+	namer := NewShortlinkNamer("Billing.API v2")
+
+	if namer.ServiceName() != "billing_api_v2" {
+		t.Fatalf("unexpected service name: %s", namer.ServiceName())
+	}
+
+	if name := namer.CommandName(&createInvoiceCommand{}); name != "billing_api_v2.command.create_invoice_command.v1" {
+		t.Fatalf("unexpected command name: %s", name)
+	}
+}
+
+func TestNameOfSanitizesMetadataSegments(t *testing.T) {
+	env := CommandEnvelope{
+		Metadata: map[string]string{
+			MetadataServiceName: "my svc",
+			MetadataMessageKind: "command",
+			MetadataTypeName:    "create invoice",
+		},
+	}
+
+	if name := NameOf(env); name != "my_svc.command.create_invoice.v1" {
+		t.Fatalf("unexpected name: %s", name)
+	}
+}
+
+func TestValidateSegment(t *testing.T) {
+	if err := ValidateSegment("create_invoice"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, segment := range []string{"", "  ", "a.b", "a b", "a\x00b"} {
+		if err := ValidateSegment(segment); !errors.Is(err, ErrInvalidNameSegment) {
+			t.Fatalf("expected ErrInvalidNameSegment for %q, got %v", segment, err)
+		}
+	}
+}
+
+type linkClickedEvent struct{}
+
+func (linkClickedEvent) Aggregate() string { return "Link" }
+
+func TestEventNameAggregateResolver(t *testing.T) {
+	// google.protobuf.Timestamp does not follow domain.{aggregate}.v1.
+	event := &timestamppb.Timestamp{}
+
+	if got := NewShortlinkNamer("billing").EventName(event); got != "billing.protobuf.timestamp.v1" {
+		t.Fatalf("unexpected default event name: %s", got)
+	}
+
+	namer := NewShortlinkNamer("billing", WithAggregateResolver(func(v any) string {
+		if aggregate, ok := v.(interface{ Aggregate() string }); ok {
+			return aggregate.Aggregate()
+		}
+
+		return ""
+	}))
+
+	if got := namer.EventName(&linkClickedEvent{}); got != "billing.link.clicked_event.v1" {
+		t.Fatalf("unexpected resolved event name: %s", got)
+	}
+
+	// An empty result falls back to the protobuf package.
+	if got := namer.EventName(event); got != "billing.protobuf.timestamp.v1" {
+		t.Fatalf("unexpected fallback event name: %s", got)
+	}
+
+	env := EventEnvelope{Metadata: map[string]string{MetadataTypeName: "orders.invoice_paid"}}
+	if got := namer.EventName(env); got != "billing.orders.invoice_paid.v1" {
+		t.Fatalf("resolver must not override metadata aggregate, got %s", got)
+	}
+}
+
+func TestWithTopicPrefix(t *testing.T) {
+	for _, prefix := range []string{"staging", "Staging.", " staging "} {
+		namer := NewShortlinkNamer("billing", WithTopicPrefix(prefix))
+
+		cmdName := namer.CommandName(&createInvoiceCommand{})
+		if got, want := namer.TopicForCommand(cmdName), "staging."+TopicForCommand(cmdName); got != want {
+			t.Fatalf("prefix %q: command topic %q, want %q", prefix, got, want)
+		}
+
+		evtName := namer.EventName(&invoiceCreatedEvent{})
+		if got, want := namer.TopicForEvent(evtName), "staging."+TopicForEvent(evtName); got != want {
+			t.Fatalf("prefix %q: event topic %q, want %q", prefix, got, want)
+		}
+	}
+
+	// Names are never prefixed, only topics.
+	namer := NewShortlinkNamer("billing", WithTopicPrefix("eu.prod"))
+	if name := namer.CommandName(&createInvoiceCommand{}); name != "billing.command.create_invoice_command.v1" {
+		t.Fatalf("unexpected command name: %s", name)
+	}
+
+	if topic := namer.TopicForCommand("billing.command.create_invoice_command.v1"); topic != "eu.prod.billing.command.create_invoice_command.v1" {
+		t.Fatalf("unexpected nested prefix topic: %s", topic)
+	}
+
+	if topic := NewShortlinkNamer("billing", WithTopicPrefix("")).TopicForCommand("a.b"); topic != "a.b" {
+		t.Fatalf("empty prefix must not change topic, got %s", topic)
+	}
+}
+
+func TestWithTopicPrefixValidation(t *testing.T) {
+	for _, prefix := range []string{"stag ing", "staging/", "..staging", "a..b", "ünicode"} {
+		if _, err := NewShortlinkNamerStrict("billing", WithTopicPrefix(prefix)); !errors.Is(err, ErrInvalidTopicPrefix) {
+			t.Fatalf("expected ErrInvalidTopicPrefix for %q, got %v", prefix, err)
+		}
+	}
+
+	if _, err := NewShortlinkNamerStrict("billing", WithTopicPrefix("eu-west_1.staging")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	namer := NewShortlinkNamer("billing", WithTopicPrefix("stag ing/"))
+	if topic := namer.TopicForEvent("billing.billing.x.v1"); topic != "stag_ing_.billing.billing.x.v1" {
+		t.Fatalf("unexpected sanitized topic: %s", topic)
+	}
+}
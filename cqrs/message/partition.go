@@ -0,0 +1,24 @@
+package message
+
+import (
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+)
+
+// PartitionKeyByAggregate returns the aggregate ID stamped in MetadataAggregateID, so all
+// events of one aggregate hash to the same Kafka partition and are consumed in publish order.
+// Messages without an aggregate ID fall back to their UUID and spread across partitions.
+//
+// Its signature matches the watermill Kafka GeneratePartitionKey hook:
+//
+//	kafka.NewWithPartitioningMarshaler(cqrsmessage.PartitionKeyByAggregate)
+func PartitionKeyByAggregate(_ string, msg *wmmessage.Message) (string, error) {
+	if msg == nil {
+		return "", errMessageNil
+	}
+
+	if id := msg.Metadata.Get(MetadataAggregateID); id != "" {
+		return id, nil
+	}
+
+	return msg.UUID, nil
+}
@@ -0,0 +1,31 @@
+package message
+
+import (
+	"testing"
+
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+)
+
+func TestPartitionKeyByAggregate(t *testing.T) {
+	msg := wmmessage.NewMessage("uuid-1", nil)
+
+	key, err := PartitionKeyByAggregate("topic", msg)
+	if err != nil {
+		t.Fatalf("PartitionKeyByAggregate failed: %v", err)
+	}
+
+	if key != "uuid-1" {
+		t.Errorf("expected UUID fallback, got %q", key)
+	}
+
+	msg.Metadata.Set(MetadataAggregateID, "order-42")
+
+	key, err = PartitionKeyByAggregate("topic", msg)
+	if err != nil {
+		t.Fatalf("PartitionKeyByAggregate failed: %v", err)
+	}
+
+	if key != "order-42" {
+		t.Errorf("expected aggregate ID, got %q", key)
+	}
+}
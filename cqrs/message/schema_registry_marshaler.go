@@ -0,0 +1,266 @@
+package message
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"sync"
+
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SchemaFormat is the schema type registered in the schema registry.
+// Avro is not supported: the inner marshalers produce JSON or protobuf payloads only.
+type SchemaFormat string
+
+const (
+	SchemaFormatJSON     SchemaFormat = "JSON"
+	SchemaFormatProtobuf SchemaFormat = "PROTOBUF"
+)
+
+// schemaRegistryMagicByte starts every Confluent wire-format payload.
+const schemaRegistryMagicByte byte = 0
+
+// schemaRegistryHeaderSize is the magic byte plus the big-endian 4-byte schema ID.
+const schemaRegistryHeaderSize = 5
+
+// SchemaRegistryClient is the subset of a schema registry used by SchemaRegistryMarshaler.
+// Implementations wrap a real client (e.g. Confluent Schema Registry) or a fake in tests.
+type SchemaRegistryClient interface {
+	// SchemaID returns the ID of the schema of v under subject, registering it when missing.
+	SchemaID(ctx context.Context, subject string, format SchemaFormat, v any) (int, error)
+	// SubjectsByID lists the subjects a schema ID is registered under.
+	SubjectsByID(ctx context.Context, id int) ([]string, error)
+}
+
+// SchemaRegistryMarshaler prefixes payloads produced by an inner marshaler with the
+// Confluent wire-format header (magic byte + schema ID, plus message indexes for
+// protobuf), so Kafka consumers using Schema Registry serdes can decode them.
+//
+// Subjects follow the topic name strategy: "<topic>-value", with the topic
+// derived from the namer.
+type SchemaRegistryMarshaler struct {
+	inner  Marshaler
+	client SchemaRegistryClient
+	format SchemaFormat
+	namer  Namer
+
+	// subjects caches SubjectsByID results per schema ID.
+	mu       sync.RWMutex
+	subjects map[int][]string
+}
+
+// NewSchemaRegistryMarshaler wraps inner, which must produce payloads matching format.
+// It returns ErrUnsupportedSchemaFormat for formats other than JSON and PROTOBUF.
+func NewSchemaRegistryMarshaler(
+	inner Marshaler,
+	client SchemaRegistryClient,
+	format SchemaFormat,
+	namer Namer,
+) (*SchemaRegistryMarshaler, error) {
+	switch format {
+	case SchemaFormatJSON, SchemaFormatProtobuf:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedSchemaFormat, format)
+	}
+
+	return &SchemaRegistryMarshaler{
+		inner:    inner,
+		client:   client,
+		format:   format,
+		namer:    namer,
+		subjects: make(map[int][]string),
+	}, nil
+}
+
+// Marshal encodes v with the inner marshaler and prepends the schema registry header.
+func (m *SchemaRegistryMarshaler) Marshal(ctx context.Context, v any) (*wmmessage.Message, error) {
+	msg, err := m.inner.Marshal(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	subject := m.subject(inferKind(msg), m.inner.NameFromMessage(msg))
+
+	id, err := m.client.SchemaID(ctx, subject, m.format, v)
+	if err != nil {
+		return nil, fmt.Errorf("schema registry subject %s: %w", subject, err)
+	}
+
+	header := make([]byte, schemaRegistryHeaderSize, schemaRegistryHeaderSize+len(msg.Payload)+1)
+	header[0] = schemaRegistryMagicByte
+	binary.BigEndian.PutUint32(header[1:], uint32(id)) //nolint:gosec // schema IDs are positive int32 values
+
+	if m.format == SchemaFormatProtobuf {
+		indexes, err := protoMessageIndexes(v)
+		if err != nil {
+			return nil, err
+		}
+
+		header = appendMessageIndexes(header, indexes)
+	}
+
+	msg.Payload = append(header, msg.Payload...)
+
+	return msg, nil
+}
+
+// Unmarshal validates and strips the schema registry header, then decodes with the inner marshaler.
+func (m *SchemaRegistryMarshaler) Unmarshal(msg *wmmessage.Message, v any) error {
+	if msg == nil {
+		return errMessageNil
+	}
+
+	if len(msg.Payload) < schemaRegistryHeaderSize || msg.Payload[0] != schemaRegistryMagicByte {
+		return ErrSchemaRegistryHeader
+	}
+
+	id := int(binary.BigEndian.Uint32(msg.Payload[1:schemaRegistryHeaderSize]))
+	payload := msg.Payload[schemaRegistryHeaderSize:]
+
+	if m.format == SchemaFormatProtobuf {
+		var err error
+
+		payload, err = skipMessageIndexes(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx := msg.Context()
+
+	subject := m.subject(inferKind(msg), m.inner.NameFromMessage(msg))
+
+	if err := m.checkSubject(ctx, id, subject); err != nil {
+		return err
+	}
+
+	stripped := msg.Copy()
+	stripped.Payload = payload
+	stripped.SetContext(ctx)
+
+	return m.inner.Unmarshal(stripped, v)
+}
+
+// checkSubject verifies that schema id is registered under subject. Lookups are
+// served from the cache; the registry is only asked again when the cached
+// subjects do not contain subject, since an ID can be registered under more
+// subjects later.
+func (m *SchemaRegistryMarshaler) checkSubject(ctx context.Context, id int, subject string) error {
+	m.mu.RLock()
+	cached := m.subjects[id]
+	m.mu.RUnlock()
+
+	if slices.Contains(cached, subject) {
+		return nil
+	}
+
+	subjects, err := m.client.SubjectsByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("schema registry id %d: %w", id, err)
+	}
+
+	m.mu.Lock()
+	m.subjects[id] = subjects
+	m.mu.Unlock()
+
+	if !slices.Contains(subjects, subject) {
+		return fmt.Errorf("%w: id %d is not registered for %s", ErrSchemaMismatch, id, subject)
+	}
+
+	return nil
+}
+
+// Name delegates to the inner marshaler.
+func (m *SchemaRegistryMarshaler) Name(v any) string {
+	return m.inner.Name(v)
+}
+
+// NameFromMessage delegates to the inner marshaler.
+func (m *SchemaRegistryMarshaler) NameFromMessage(msg *wmmessage.Message) string {
+	return m.inner.NameFromMessage(msg)
+}
+
+func (m *SchemaRegistryMarshaler) subject(kind MessageKind, name string) string {
+	var topic string
+
+	switch {
+	case m.namer != nil && kind == KindEvent:
+		topic = m.namer.TopicForEvent(name)
+	case m.namer != nil:
+		topic = m.namer.TopicForCommand(name)
+	case kind == KindEvent:
+		topic = TopicForEvent(name)
+	default:
+		topic = TopicForCommand(name)
+	}
+
+	return topic + "-value"
+}
+
+// protoMessageIndexes returns the path of v's message type within its .proto file,
+// e.g. [0] for the first top-level message or [1, 0] for the first nested one of the second.
+func protoMessageIndexes(v any) ([]int, error) {
+	protoMsg, ok := toProto(v)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errValueNotProto, v)
+	}
+
+	var indexes []int
+
+	var desc protoreflect.Descriptor = protoMsg.ProtoReflect().Descriptor()
+	for {
+		msgDesc, isMessage := desc.(protoreflect.MessageDescriptor)
+		if !isMessage {
+			break
+		}
+
+		indexes = append(indexes, msgDesc.Index())
+		desc = msgDesc.Parent()
+	}
+
+	slices.Reverse(indexes)
+
+	return indexes, nil
+}
+
+// appendMessageIndexes writes the Confluent message-index array: a zigzag varint count
+// followed by zigzag varint indexes, with [0] shortened to a single zero byte.
+func appendMessageIndexes(buf []byte, indexes []int) []byte {
+	if len(indexes) == 1 && indexes[0] == 0 {
+		return append(buf, 0)
+	}
+
+	buf = binary.AppendVarint(buf, int64(len(indexes)))
+	for _, idx := range indexes {
+		buf = binary.AppendVarint(buf, int64(idx))
+	}
+
+	return buf
+}
+
+func skipMessageIndexes(payload []byte) ([]byte, error) {
+	count, n := binary.Varint(payload)
+	if n <= 0 || count < 0 {
+		return nil, ErrSchemaRegistryHeader
+	}
+
+	payload = payload[n:]
+
+	for range count {
+		_, n = binary.Varint(payload)
+		if n <= 0 {
+			return nil, ErrSchemaRegistryHeader
+		}
+
+		payload = payload[n:]
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,206 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type fakeSchemaRegistry struct {
+	mu       sync.Mutex
+	nextID   int
+	bySubj   map[string]int
+	subjects map[int][]string
+	lookups  int
+}
+
+func newFakeSchemaRegistry() *fakeSchemaRegistry {
+	return &fakeSchemaRegistry{
+		nextID:   1,
+		bySubj:   map[string]int{},
+		subjects: map[int][]string{},
+	}
+}
+
+func (r *fakeSchemaRegistry) SchemaID(_ context.Context, subject string, _ SchemaFormat, _ any) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if id, ok := r.bySubj[subject]; ok {
+		return id, nil
+	}
+
+	id := r.nextID
+	r.nextID++
+	r.bySubj[subject] = id
+	r.subjects[id] = append(r.subjects[id], subject)
+
+	return id, nil
+}
+
+func (r *fakeSchemaRegistry) SubjectsByID(_ context.Context, id int) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lookups++
+
+	return r.subjects[id], nil
+}
+
+func newTestSchemaRegistryMarshaler(t *testing.T, inner Marshaler, registry SchemaRegistryClient, format SchemaFormat, namer Namer) *SchemaRegistryMarshaler {
+	t.Helper()
+
+	m, err := NewSchemaRegistryMarshaler(inner, registry, format, namer)
+	if err != nil {
+		t.Fatalf("NewSchemaRegistryMarshaler failed: %v", err)
+	}
+
+	return m
+}
+
+func TestSchemaRegistryMarshalerRoundTripProto(t *testing.T) {
+	namer := NewShortlinkNamer("test")
+	registry := newFakeSchemaRegistry()
+	m := newTestSchemaRegistryMarshaler(t, NewProtoMarshaler(namer), registry, SchemaFormatProtobuf, namer)
+
+	msg, err := m.Marshal(context.Background(), wrapperspb.String("hello"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	// magic byte, schema ID 1, message indexes [7] (StringValue in wrappers.proto)
+	header := []byte{0, 0, 0, 0, 1, 2, 14}
+	if len(msg.Payload) < len(header) || string(msg.Payload[:len(header)]) != string(header) {
+		t.Fatalf("unexpected header: %v", msg.Payload)
+	}
+
+	subject := namer.TopicForCommand(m.NameFromMessage(msg)) + "-value"
+	if _, ok := registry.bySubj[subject]; !ok {
+		t.Fatalf("expected subject %s to be registered, got %v", subject, registry.bySubj)
+	}
+
+	var decoded wrapperspb.StringValue
+	if err := m.Unmarshal(msg, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if decoded.GetValue() != "hello" {
+		t.Errorf("expected 'hello', got %q", decoded.GetValue())
+	}
+
+	if len(msg.Payload) < len(header) || msg.Payload[0] != 0 {
+		t.Error("Unmarshal must not modify the original payload")
+	}
+}
+
+func TestSchemaRegistryMarshalerRoundTripJSON(t *testing.T) {
+	namer := NewShortlinkNamer("test")
+	m := newTestSchemaRegistryMarshaler(t, NewJSONMarshaler(namer), newFakeSchemaRegistry(), SchemaFormatJSON, namer)
+
+	original := &testCommand{OrderId: "order-1", Amount: 7}
+
+	msg, err := m.Marshal(context.Background(), original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	if msg.Payload[0] != 0 || msg.Payload[schemaRegistryHeaderSize] != '{' {
+		t.Fatalf("unexpected payload: %q", msg.Payload)
+	}
+
+	var decoded testCommand
+	if err := m.Unmarshal(msg, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if decoded != *original {
+		t.Errorf("expected %+v, got %+v", *original, decoded)
+	}
+}
+
+func TestSchemaRegistryMarshalerRejectsMissingHeader(t *testing.T) {
+	namer := NewShortlinkNamer("test")
+	m := newTestSchemaRegistryMarshaler(t, NewJSONMarshaler(namer), newFakeSchemaRegistry(), SchemaFormatJSON, namer)
+
+	msg := wmmessage.NewMessage("id", []byte(`{"order_id":"order-1"}`))
+
+	var decoded testCommand
+	if err := m.Unmarshal(msg, &decoded); !errors.Is(err, ErrSchemaRegistryHeader) {
+		t.Fatalf("expected ErrSchemaRegistryHeader, got %v", err)
+	}
+}
+
+func TestSchemaRegistryMarshalerRejectsForeignSchemaID(t *testing.T) {
+	namer := NewShortlinkNamer("test")
+	registry := newFakeSchemaRegistry()
+	m := newTestSchemaRegistryMarshaler(t, NewProtoMarshaler(namer), registry, SchemaFormatProtobuf, namer)
+
+	msg, err := m.Marshal(context.Background(), wrapperspb.String("hello"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	otherID, err := registry.SchemaID(context.Background(), "other-value", SchemaFormatProtobuf, nil)
+	if err != nil {
+		t.Fatalf("SchemaID failed: %v", err)
+	}
+
+	msg.Payload[4] = byte(otherID)
+
+	var decoded wrapperspb.StringValue
+	if err := m.Unmarshal(msg, &decoded); !errors.Is(err, ErrSchemaMismatch) {
+		t.Fatalf("expected ErrSchemaMismatch, got %v", err)
+	}
+}
+
+func TestSchemaRegistryMarshalerRejectsAvro(t *testing.T) {
+	namer := NewShortlinkNamer("test")
+
+	_, err := NewSchemaRegistryMarshaler(NewJSONMarshaler(namer), newFakeSchemaRegistry(), "AVRO", namer)
+	if !errors.Is(err, ErrUnsupportedSchemaFormat) {
+		t.Fatalf("expected ErrUnsupportedSchemaFormat, got %v", err)
+	}
+}
+
+func TestSchemaRegistryMarshalerCachesSubjectsByID(t *testing.T) {
+	namer := NewShortlinkNamer("test")
+	registry := newFakeSchemaRegistry()
+	m := newTestSchemaRegistryMarshaler(t, NewProtoMarshaler(namer), registry, SchemaFormatProtobuf, namer)
+
+	msg, err := m.Marshal(context.Background(), wrapperspb.String("hello"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	for range 3 {
+		var decoded wrapperspb.StringValue
+		if err := m.Unmarshal(msg, &decoded); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+	}
+
+	if registry.lookups != 1 {
+		t.Errorf("expected 1 SubjectsByID lookup, got %d", registry.lookups)
+	}
+}
+
+func TestAppendMessageIndexes(t *testing.T) {
+	got := appendMessageIndexes(nil, []int{1, 0})
+
+	rest, err := skipMessageIndexes(append(got, 'x'))
+	if err != nil {
+		t.Fatalf("skipMessageIndexes failed: %v", err)
+	}
+
+	if string(rest) != "x" {
+		t.Errorf("expected remaining payload 'x', got %q", rest)
+	}
+
+	if want := []byte{4, 2, 0}; string(got) != string(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
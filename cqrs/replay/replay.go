@@ -0,0 +1,143 @@
+// Package replay re-dispatches historical events to handlers, e.g. to rebuild projections.
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+
+	"github.com/shortlink-org/go-sdk/cqrs/bus"
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+)
+
+const defaultProgressEvery = 1000
+
+var (
+	errNilSource  = errors.New("cqrs/replay: source is required")
+	errNilHandler = errors.New("cqrs/replay: handler is required")
+	errNoTopic    = errors.New("cqrs/replay: topic is required")
+)
+
+// Source reads the historical messages of a topic in order.
+// Implementations must be read-only: they must not commit offsets of any live consumer group.
+type Source interface {
+	Read(ctx context.Context, topic string, fn func(*wmmessage.Message) error) error
+}
+
+// SourceFunc adapts a function to Source, e.g. a closure over kafka.ReadRange.
+type SourceFunc func(ctx context.Context, topic string, fn func(*wmmessage.Message) error) error
+
+// Read calls f.
+func (f SourceFunc) Read(ctx context.Context, topic string, fn func(*wmmessage.Message) error) error {
+	return f(ctx, topic, fn)
+}
+
+// Progress reports how far a replay has got.
+type Progress struct {
+	// Read is the number of messages read from the source.
+	Read int
+	// Dispatched is the number of messages handled successfully.
+	Dispatched int
+	// Skipped is the number of messages whose type is not in the registry.
+	Skipped int
+}
+
+// Config configures a replay.
+type Config struct {
+	Source Source
+	Topic  string
+	// Handler receives every replayed message, typically handlers.NewEventHandler(projector, registry, marshaler).
+	// Messages it returns are dropped; nothing is re-published.
+	Handler wmmessage.HandlerFunc
+	// Registry and Marshaler, when both set, skip messages whose type is not registered
+	// instead of failing, so topics shared with other event types can be replayed.
+	Registry  *bus.TypeRegistry
+	Marshaler cqrsmessage.Marshaler
+	// OnProgress is called every ProgressEvery messages read and once when the replay ends.
+	OnProgress func(Progress)
+	// ProgressEvery defaults to 1000.
+	ProgressEvery int
+}
+
+type replayContextKey struct{}
+
+// IsReplay reports whether ctx belongs to a replayed message, so handlers can skip
+// side effects (emails, outgoing commands) that must not run twice.
+func IsReplay(ctx context.Context) bool {
+	replaying, _ := ctx.Value(replayContextKey{}).(bool)
+
+	return replaying
+}
+
+// Run reads cfg.Topic from cfg.Source and dispatches every message to cfg.Handler in order.
+// Handlers should be idempotent: message UUIDs are preserved, so they can serve as dedup keys.
+// It stops at the first handler error.
+//
+//nolint:gocritic // hugeParam: Config is the public configuration DTO.
+func Run(ctx context.Context, cfg Config) (Progress, error) {
+	var progress Progress
+
+	switch {
+	case cfg.Source == nil:
+		return progress, errNilSource
+	case cfg.Handler == nil:
+		return progress, errNilHandler
+	case cfg.Topic == "":
+		return progress, errNoTopic
+	}
+
+	every := cfg.ProgressEvery
+	if every <= 0 {
+		every = defaultProgressEvery
+	}
+
+	report := func() {
+		if cfg.OnProgress != nil {
+			cfg.OnProgress(progress)
+		}
+	}
+	defer report()
+
+	err := cfg.Source.Read(ctx, cfg.Topic, func(msg *wmmessage.Message) error {
+		progress.Read++
+
+		if progress.Read%every == 0 {
+			defer report()
+		}
+
+		if !isRegistered(cfg.Registry, cfg.Marshaler, msg) {
+			progress.Skipped++
+
+			return nil
+		}
+
+		msgCtx := msg.Context()
+		if msgCtx == nil {
+			msgCtx = ctx
+		}
+
+		msg.SetContext(context.WithValue(msgCtx, replayContextKey{}, true))
+
+		if _, err := cfg.Handler(msg); err != nil {
+			return fmt.Errorf("replay message %s: %w", msg.UUID, err)
+		}
+
+		progress.Dispatched++
+
+		return nil
+	})
+
+	return progress, err
+}
+
+func isRegistered(registry *bus.TypeRegistry, marshaler cqrsmessage.Marshaler, msg *wmmessage.Message) bool {
+	if registry == nil || marshaler == nil {
+		return true
+	}
+
+	_, ok := registry.ResolveEvent(marshaler.NameFromMessage(msg))
+
+	return ok
+}
@@ -0,0 +1,114 @@
+package replay_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/cqrs/bus"
+	"github.com/shortlink-org/go-sdk/cqrs/handlers"
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+	"github.com/shortlink-org/go-sdk/cqrs/replay"
+)
+
+type linkCreated struct {
+	ID string `json:"id"`
+}
+
+type linkDeleted struct {
+	ID string `json:"id"`
+}
+
+type countingProjector struct {
+	ids      []string
+	replayed int
+}
+
+func (p *countingProjector) Handle(ctx context.Context, evt *linkCreated) error {
+	p.ids = append(p.ids, evt.ID)
+
+	if replay.IsReplay(ctx) {
+		p.replayed++
+	}
+
+	return nil
+}
+
+// sliceSource replays a fixed set of messages.
+func sliceSource(msgs []*wmmessage.Message) replay.Source {
+	return replay.SourceFunc(func(_ context.Context, _ string, fn func(*wmmessage.Message) error) error {
+		for _, msg := range msgs {
+			if err := fn(msg); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func TestRunReplaysFixedSetThroughHandler(t *testing.T) {
+	namer := cqrsmessage.NewShortlinkNamer("links")
+	marshaler := cqrsmessage.NewJSONMarshaler(nil)
+
+	registry := bus.NewTypeRegistry()
+	require.NoError(t, registry.RegisterEvent(&linkCreated{}))
+
+	var msgs []*wmmessage.Message
+
+	for _, evt := range []any{&linkCreated{ID: "1"}, &linkDeleted{ID: "1"}, &linkCreated{ID: "2"}, &linkCreated{ID: "3"}} {
+		msg, err := marshaler.Marshal(context.Background(), evt)
+		require.NoError(t, err)
+
+		msg.Metadata.Set(cqrsmessage.MetadataMessageKind, string(cqrsmessage.KindEvent))
+		msgs = append(msgs, msg)
+	}
+
+	projector := &countingProjector{}
+
+	var reports []replay.Progress
+
+	progress, err := replay.Run(context.Background(), replay.Config{
+		Source:        sliceSource(msgs),
+		Topic:         namer.TopicForEvent(namer.EventName(&linkCreated{})),
+		Handler:       handlers.NewEventHandler[*linkCreated](projector, registry, marshaler),
+		Registry:      registry,
+		Marshaler:     marshaler,
+		OnProgress:    func(p replay.Progress) { reports = append(reports, p) },
+		ProgressEvery: 2,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"1", "2", "3"}, projector.ids)
+	require.Equal(t, 3, projector.replayed)
+	require.Equal(t, replay.Progress{Read: 4, Dispatched: 3, Skipped: 1}, progress)
+	require.Equal(t, []replay.Progress{
+		{Read: 2, Dispatched: 1, Skipped: 1},
+		{Read: 4, Dispatched: 3, Skipped: 1},
+		{Read: 4, Dispatched: 3, Skipped: 1},
+	}, reports)
+}
+
+func TestRunStopsOnHandlerError(t *testing.T) {
+	errBoom := errors.New("boom")
+	calls := 0
+
+	progress, err := replay.Run(context.Background(), replay.Config{
+		Source: sliceSource([]*wmmessage.Message{
+			wmmessage.NewMessage("a", nil),
+			wmmessage.NewMessage("b", nil),
+		}),
+		Topic: "links.event.created.v1",
+		Handler: func(*wmmessage.Message) ([]*wmmessage.Message, error) {
+			calls++
+
+			return nil, errBoom
+		},
+	})
+	require.ErrorIs(t, err, errBoom)
+	require.Equal(t, 1, calls)
+	require.Equal(t, replay.Progress{Read: 1}, progress)
+}
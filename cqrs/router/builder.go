@@ -1,9 +1,11 @@
 package router
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ThreeDotsLabs/watermill"
 	wmmessage "github.com/ThreeDotsLabs/watermill/message"
@@ -12,21 +14,25 @@ import (
 	"github.com/shortlink-org/go-sdk/cqrs/handlers"
 )
 
+// ErrHandlerTimeout is returned when a handler fails after exceeding its HandlerRegistration.Timeout.
+var ErrHandlerTimeout = errors.New("cqrs/router: handler timed out")
+
 var (
 	errNilLogger       = errors.New("cqrs/router: watermill logger is required")
 	errNilSubscriber   = errors.New("cqrs/router: subscriber is required")
 	errNilPublisher    = errors.New("cqrs/router: publisher is required")
-	errNoHandlers      = errors.New("cqrs/router: at least one handler must be configured")
 	errNilHandlerLogic = errors.New("cqrs/router: handler function is nil")
+	errDuplicateName   = errors.New("cqrs/router: handler name is already registered")
 )
 
 // NewRouter builds CQRS-aware Watermill router.
+// cfg.Handlers may be empty; handlers can be added later with Router.AddHandler.
 func NewRouter(
 	logger watermill.LoggerAdapter,
 	subscriber wmmessage.Subscriber,
 	publisher wmmessage.Publisher,
 	cfg RouterConfig,
-) (*wmmessage.Router, error) {
+) (*Router, error) {
 	if logger == nil {
 		return nil, errNilLogger
 	}
@@ -39,17 +45,21 @@ func NewRouter(
 		return nil, errNilPublisher
 	}
 
-	if len(cfg.Handlers) == 0 {
-		return nil, errNoHandlers
+	router, err := wmmessage.NewRouter(wmmessage.RouterConfig{CloseTimeout: cfg.DrainTimeout}, logger)
+	if err != nil {
+		return nil, err
 	}
 
-	router, err := wmmessage.NewRouter(wmmessage.RouterConfig{}, logger)
+	tracker := newInFlightTracker()
+
+	applyBaseMiddlewares(router, cfg.TrustMessageUserID)
+	router.AddMiddleware(tracker.middleware)
+
+	guard, err := newPayloadGuard(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	applyBaseMiddlewares(router)
-
 	decoratorCfg := handlers.DecoratorConfig{
 		Timeout:                cfg.Middlewares.Timeout,
 		RetryMax:               cfg.Middlewares.RetryMax,
@@ -57,26 +67,90 @@ func NewRouter(
 		CircuitBreakerSettings: cfg.Middlewares.CircuitBreakerSettings,
 	}
 
-	service := sanitizeService(cfg.ServiceName)
-	for _, registration := range enumerateHandlers(cfg, service) {
-		if registration.Handler == nil {
-			return nil, fmt.Errorf("%w: topic %s", errNilHandlerLogic, registration.Topic)
-		}
+	rt := &Router{
+		Router:     router,
+		logger:     logger,
+		inFlight:   tracker,
+		subscriber: subscriber,
+		publisher:  publisher,
+		guard:      guard,
+		decorator:  decoratorCfg,
+		service:    sanitizeService(cfg.ServiceName),
+		names:      make(map[string]struct{}, len(cfg.Handlers)),
+		runDone:    make(chan struct{}),
+	}
 
-		if registration.Topic == "" {
-			return nil, fmt.Errorf("cqrs/router: topic is empty for handler %s", registration.Name)
+	for _, registration := range cfg.Handlers {
+		if err := rt.addHandler(registration); err != nil {
+			return nil, err
 		}
+	}
+
+	return rt, nil
+}
+
+// addHandler validates registration, decorates its handler and adds it to the
+// Watermill router. The caller must hold r.mu or own r exclusively.
+func (r *Router) addHandler(registration HandlerRegistration) error {
+	registration = registration.sanitize(r.service)
+
+	if registration.Handler == nil {
+		return fmt.Errorf("%w: topic %s", errNilHandlerLogic, registration.Topic)
+	}
+
+	if registration.Topic == "" {
+		return fmt.Errorf("cqrs/router: topic is empty for handler %s", registration.Name)
+	}
+
+	if _, ok := r.names[registration.Name]; ok {
+		return fmt.Errorf("%w: %s", errDuplicateName, registration.Name)
+	}
+
+	handlerCfg := r.decorator
+	handler := registration.Handler
 
-		decorated := handlers.DecorateHandler(registration.Handler, decoratorCfg)
-		router.AddHandler(registration.Name, registration.Topic, subscriber, "", publisher, decorated)
+	if registration.Timeout > 0 {
+		handlerCfg.Timeout = 0
+		handler = withHandlerTimeout(registration.Name, registration.Timeout, handler)
 	}
 
-	return router, nil
+	decorated := r.guard.wrap(registration.Name, registration.Payload, handlers.DecorateHandler(handler, handlerCfg))
+	r.Router.AddHandler(registration.Name, registration.Topic, r.subscriber, "", r.publisher, decorated)
+	r.names[registration.Name] = struct{}{}
+
+	return nil
 }
 
-func applyBaseMiddlewares(router *wmmessage.Router) {
+// withHandlerTimeout bounds each attempt of h by timeout. A failure after the
+// deadline is reported as ErrHandlerTimeout, which the retry middleware retries.
+func withHandlerTimeout(name string, timeout time.Duration, h wmmessage.HandlerFunc) wmmessage.HandlerFunc {
+	return func(msg *wmmessage.Message) ([]*wmmessage.Message, error) {
+		parent := msg.Context()
+
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		defer cancel()
+
+		msg.SetContext(ctx)
+		defer msg.SetContext(parent)
+
+		produced, err := h(msg)
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: %s after %s: %w", ErrHandlerTimeout, name, timeout, err)
+		}
+
+		return produced, err
+	}
+}
+
+func applyBaseMiddlewares(router *wmmessage.Router, trustMessageUserID bool) {
 	router.AddMiddleware(wmmid.Recoverer)
 	router.AddMiddleware(wmmid.CorrelationID)
+
+	if trustMessageUserID {
+		router.AddMiddleware(handlers.TrustedUserContextMiddleware)
+	} else {
+		router.AddMiddleware(handlers.ContextMiddleware)
+	}
 }
 
 func sanitizeService(name string) string {
@@ -96,12 +170,3 @@ func sanitizeTopic(name string) string {
 
 	return strings.ToLower(strings.ReplaceAll(name, "*", "wildcard"))
 }
-
-func enumerateHandlers(cfg RouterConfig, service string) []HandlerRegistration {
-	regs := make([]HandlerRegistration, 0, len(cfg.Handlers))
-	for _, h := range cfg.Handlers {
-		regs = append(regs, h.sanitize(service))
-	}
-
-	return regs
-}
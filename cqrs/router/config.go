@@ -6,6 +6,9 @@ import (
 
 	wmmessage "github.com/ThreeDotsLabs/watermill/message"
 	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/otel/metric"
+
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
 )
 
 // RouterConfig describes CQRS router runtime parameters.
@@ -13,6 +16,15 @@ type RouterConfig struct {
 	ServiceName string
 	Handlers    []HandlerRegistration
 	Middlewares RouterMiddlewareConfig
+	// DrainTimeout bounds how long Run waits for in-flight handlers after ctx is canceled.
+	// Zero falls back to Watermill's default close timeout (30s).
+	DrainTimeout time.Duration
+	// MeterProvider records cqrs_router_messages_rejected_total. Nil uses the global provider.
+	MeterProvider metric.MeterProvider
+	// TrustMessageUserID stores the shortlink.user_id metadata as the authenticated
+	// user of the handler context (session.GetUserID). The metadata is set by the
+	// publisher, so enable it only when every publisher on the subscribed topics is trusted.
+	TrustMessageUserID bool
 }
 
 // HandlerRegistration wires a Watermill handler to a topic.
@@ -20,6 +32,41 @@ type HandlerRegistration struct {
 	Name    string
 	Topic   string
 	Handler wmmessage.HandlerFunc
+	// Timeout overrides RouterMiddlewareConfig.Timeout for this handler.
+	// On expiry the handler context is canceled and ErrHandlerTimeout is returned, so the message is retried.
+	// Zero uses the router-wide timeout.
+	Timeout time.Duration
+	// Payload is a value of the message type the handler consumes. Command and Event set it;
+	// RouterMiddlewareConfig.PayloadMarshaler uses it to validate payloads before the handler runs.
+	Payload any
+}
+
+// Command registers handler on the topic namer derives for cmd.
+// A nil namer falls back to cqrsmessage.NameOf, like the buses do.
+func Command(namer cqrsmessage.Namer, name string, cmd any, handler wmmessage.HandlerFunc) HandlerRegistration {
+	var topic string
+
+	if namer != nil {
+		topic = namer.TopicForCommand(namer.CommandName(cmd))
+	} else {
+		topic = cqrsmessage.TopicForCommand(cqrsmessage.NameOf(cmd))
+	}
+
+	return HandlerRegistration{Name: name, Topic: topic, Handler: handler, Payload: cmd}
+}
+
+// Event registers handler on the topic namer derives for evt.
+// A nil namer falls back to cqrsmessage.NameOf, like the buses do.
+func Event(namer cqrsmessage.Namer, name string, evt any, handler wmmessage.HandlerFunc) HandlerRegistration {
+	var topic string
+
+	if namer != nil {
+		topic = namer.TopicForEvent(namer.EventName(evt))
+	} else {
+		topic = cqrsmessage.TopicForEvent(cqrsmessage.NameOf(evt))
+	}
+
+	return HandlerRegistration{Name: name, Topic: topic, Handler: handler, Payload: evt}
 }
 
 // RouterMiddlewareConfig configures CQRS decorator behavior.
@@ -28,6 +75,10 @@ type RouterMiddlewareConfig struct {
 	RetryMax               int
 	CircuitBreakerEnabled  bool
 	CircuitBreakerSettings *gobreaker.Settings
+	// MaxPayloadBytes rejects larger messages with ErrMessageRejected. Zero disables the limit.
+	MaxPayloadBytes int
+	// PayloadMarshaler, when set, rejects messages that do not decode into HandlerRegistration.Payload.
+	PayloadMarshaler cqrsmessage.Marshaler
 }
 
 func (h HandlerRegistration) sanitize(service string) HandlerRegistration {
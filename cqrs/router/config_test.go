@@ -0,0 +1,35 @@
+package router
+
+import (
+	"testing"
+
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+)
+
+type (
+	createInvoiceCommand struct{}
+	invoiceCreatedEvent  struct{}
+)
+
+func TestCommandAndEventDeriveTopicFromNamer(t *testing.T) {
+	namer := cqrsmessage.NewShortlinkNamer("billing")
+	handler := func(*wmmessage.Message) ([]*wmmessage.Message, error) { return nil, nil }
+
+	cmd := Command(namer, "create_invoice", &createInvoiceCommand{}, handler)
+
+	if want := cqrsmessage.TopicForCommand(namer.CommandName(&createInvoiceCommand{})); cmd.Topic != want {
+		t.Errorf("command topic = %q, want %q", cmd.Topic, want)
+	}
+
+	if cmd.Name != "create_invoice" || cmd.Handler == nil {
+		t.Errorf("unexpected command registration: %+v", cmd)
+	}
+
+	evt := Event(namer, "invoice_created", &invoiceCreatedEvent{}, handler)
+
+	if want := cqrsmessage.TopicForEvent(namer.EventName(&invoiceCreatedEvent{})); evt.Topic != want {
+		t.Errorf("event topic = %q, want %q", evt.Topic, want)
+	}
+}
@@ -0,0 +1,101 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+)
+
+// ErrMessageRejected is returned for messages that exceed RouterMiddlewareConfig.MaxPayloadBytes
+// or do not decode into the handler's Payload type. The check runs outside the retry
+// middleware, so a rejected message fails once and goes straight to the poison queue
+// (DLQ) when one is configured on the router.
+var ErrMessageRejected = errors.New("cqrs/router: message rejected")
+
+// Reasons recorded in the reason attribute of cqrs_router_messages_rejected_total.
+const (
+	rejectReasonSize   = "size"
+	rejectReasonDecode = "decode"
+)
+
+// payloadGuard rejects poison payloads before they reach a handler.
+type payloadGuard struct {
+	maxBytes  int
+	marshaler cqrsmessage.Marshaler
+	rejected  metric.Int64Counter
+}
+
+// newPayloadGuard returns nil when neither a size limit nor a marshaler is configured.
+func newPayloadGuard(cfg RouterConfig) (*payloadGuard, error) {
+	if cfg.Middlewares.MaxPayloadBytes <= 0 && cfg.Middlewares.PayloadMarshaler == nil {
+		return nil, nil //nolint:nilnil // guard disabled
+	}
+
+	provider := cfg.MeterProvider
+	if provider == nil {
+		provider = otel.GetMeterProvider()
+	}
+
+	rejected, err := provider.Meter("shortlink.cqrs.router").Int64Counter(
+		"cqrs_router_messages_rejected_total",
+		metric.WithDescription("Total number of messages rejected before reaching a handler"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &payloadGuard{
+		maxBytes:  cfg.Middlewares.MaxPayloadBytes,
+		marshaler: cfg.Middlewares.PayloadMarshaler,
+		rejected:  rejected,
+	}, nil
+}
+
+// wrap checks every message for the handler registered as name. payload is the
+// registered message type; decoding is only validated when it is set.
+func (g *payloadGuard) wrap(name string, payload any, h wmmessage.HandlerFunc) wmmessage.HandlerFunc {
+	if g == nil {
+		return h
+	}
+
+	var payloadType reflect.Type
+	if payload != nil && g.marshaler != nil {
+		payloadType = reflect.TypeOf(payload)
+		for payloadType.Kind() == reflect.Pointer {
+			payloadType = payloadType.Elem()
+		}
+	}
+
+	return func(msg *wmmessage.Message) ([]*wmmessage.Message, error) {
+		if g.maxBytes > 0 && len(msg.Payload) > g.maxBytes {
+			g.reject(msg, name, rejectReasonSize)
+
+			return nil, fmt.Errorf("%w: payload of %d bytes exceeds %d", ErrMessageRejected, len(msg.Payload), g.maxBytes)
+		}
+
+		if payloadType != nil {
+			target := reflect.New(payloadType).Interface()
+			if err := g.marshaler.Unmarshal(msg, target); err != nil {
+				g.reject(msg, name, rejectReasonDecode)
+
+				return nil, fmt.Errorf("%w: decode %s: %w", ErrMessageRejected, payloadType, err)
+			}
+		}
+
+		return h(msg)
+	}
+}
+
+func (g *payloadGuard) reject(msg *wmmessage.Message, handler, reason string) {
+	g.rejected.Add(msg.Context(), 1, metric.WithAttributes(
+		attribute.String("handler", handler),
+		attribute.String("reason", reason),
+	))
+}
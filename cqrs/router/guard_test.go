@@ -0,0 +1,147 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+	wmmid "github.com/ThreeDotsLabs/watermill/message/router/middleware"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+)
+
+type guardedCommand struct {
+	ID string `json:"id"`
+}
+
+func TestRouterRejectsOversizedMessageToPoisonQueue(t *testing.T) {
+	logger := watermill.NopLogger{}
+	pubsub := gochannel.NewGoChannel(gochannel.Config{}, logger)
+	t.Cleanup(func() { _ = pubsub.Close() })
+
+	reader := sdkmetric.NewManualReader()
+	handled := make(chan struct{}, 1)
+
+	rt, err := NewRouter(logger, pubsub, pubsub, RouterConfig{
+		ServiceName:   "orders",
+		MeterProvider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		Middlewares: RouterMiddlewareConfig{
+			RetryMax:         3,
+			MaxPayloadBytes:  16,
+			PayloadMarshaler: cqrsmessage.NewJSONMarshaler(nil),
+		},
+		Handlers: []HandlerRegistration{
+			Command(nil, "guarded_handler", guardedCommand{}, func(*wmmessage.Message) ([]*wmmessage.Message, error) {
+				handled <- struct{}{}
+
+				return nil, nil
+			}),
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewRouter failed: %v", err)
+	}
+
+	poison, err := wmmid.PoisonQueue(pubsub, "orders.poison")
+	if err != nil {
+		t.Fatalf("PoisonQueue failed: %v", err)
+	}
+
+	rt.AddMiddleware(poison)
+
+	poisoned, err := pubsub.Subscribe(context.Background(), "orders.poison")
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go func() { _ = rt.Run(ctx) }()
+
+	<-rt.Running()
+
+	topic := cqrsmessage.TopicForCommand(cqrsmessage.NameOf(guardedCommand{}))
+	payload := []byte(`{"id":"` + strings.Repeat("x", 32) + `"}`)
+
+	if err := pubsub.Publish(topic, wmmessage.NewMessage("1", payload)); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	select {
+	case msg := <-poisoned:
+		msg.Ack()
+
+		if reason := msg.Metadata.Get(wmmid.ReasonForPoisonedKey); !strings.Contains(reason, ErrMessageRejected.Error()) {
+			t.Fatalf("unexpected poison reason: %q", reason)
+		}
+	case <-handled:
+		t.Fatal("handler must not receive an oversized message")
+	case <-time.After(5 * time.Second):
+		t.Fatal("oversized message was not routed to the poison queue")
+	}
+
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+
+	if got := rejectedCount(data, rejectReasonSize); got != 1 {
+		t.Fatalf("expected 1 rejected message, got %d", got)
+	}
+}
+
+func TestPayloadGuardRejectsUndecodablePayload(t *testing.T) {
+	guard, err := newPayloadGuard(RouterConfig{
+		MeterProvider: sdkmetric.NewMeterProvider(),
+		Middlewares: RouterMiddlewareConfig{
+			PayloadMarshaler: cqrsmessage.NewJSONMarshaler(nil),
+		},
+	})
+	if err != nil {
+		t.Fatalf("newPayloadGuard failed: %v", err)
+	}
+
+	h := guard.wrap("guarded_handler", guardedCommand{}, func(*wmmessage.Message) ([]*wmmessage.Message, error) {
+		t.Fatal("handler must not receive an undecodable message")
+
+		return nil, nil
+	})
+
+	_, err = h(wmmessage.NewMessage("1", []byte("not json")))
+	if !errors.Is(err, ErrMessageRejected) {
+		t.Fatalf("expected ErrMessageRejected, got %v", err)
+	}
+}
+
+func rejectedCount(data metricdata.ResourceMetrics, reason string) int64 {
+	var total int64
+
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != "cqrs_router_messages_rejected_total" {
+				continue
+			}
+
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				continue
+			}
+
+			for _, point := range sum.DataPoints {
+				if value, _ := point.Attributes.Value("reason"); value.AsString() == reason {
+					total += point.Value
+				}
+			}
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,166 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ThreeDotsLabs/watermill"
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+
+	"github.com/shortlink-org/go-sdk/cqrs/handlers"
+)
+
+var (
+	// ErrRouterClosed is returned by AddHandler once the router has stopped.
+	ErrRouterClosed = errors.New("cqrs/router: router is closed")
+	// ErrDynamicAddUnsupported is returned by AddHandler when the embedded Watermill
+	// router was started directly instead of through Router.Run.
+	ErrDynamicAddUnsupported = errors.New("cqrs/router: handlers can only be added at runtime to a router started with Router.Run")
+)
+
+// Router wraps Watermill router with graceful drain on context cancellation.
+type Router struct {
+	*wmmessage.Router
+
+	logger   watermill.LoggerAdapter
+	inFlight *inFlightTracker
+
+	subscriber wmmessage.Subscriber
+	publisher  wmmessage.Publisher
+	guard      *payloadGuard
+	decorator  handlers.DecoratorConfig
+	service    string
+
+	// mu serializes AddHandler with Run starting the router.
+	mu      sync.Mutex
+	names   map[string]struct{}
+	runCtx  context.Context //nolint:containedctx // handlers added at runtime subscribe with the Run context
+	runDone chan struct{}
+	runOnce sync.Once
+}
+
+// AddHandler registers a handler with the same decoration as RouterConfig.Handlers.
+//
+// Before Run the handler starts together with the others. On a running router it
+// subscribes to its topic immediately, provided the router was started with
+// Router.Run; otherwise ErrDynamicAddUnsupported is returned. Once the router has
+// stopped, ErrRouterClosed is returned. Handler names must be unique, and handlers
+// cannot be removed again.
+func (r *Router) AddHandler(registration HandlerRegistration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Router.IsClosed() {
+		return ErrRouterClosed
+	}
+
+	if r.runCtx == nil {
+		if r.Router.IsRunning() {
+			return ErrDynamicAddUnsupported
+		}
+
+		return r.addHandler(registration)
+	}
+
+	// Wait until Watermill accepts RunHandlers, or Run has already failed.
+	select {
+	case <-r.Router.Running():
+	case <-r.runDone:
+		return ErrRouterClosed
+	}
+
+	if err := r.addHandler(registration); err != nil {
+		return err
+	}
+
+	return r.Router.RunHandlers(r.runCtx)
+}
+
+// Run starts the router and blocks until ctx is canceled or all handlers stop.
+//
+// On ctx.Done() the router stops consuming new messages and waits up to
+// RouterConfig.DrainTimeout for in-flight handlers to finish. Handlers still
+// running once the drain window expires get their message context canceled.
+func (r *Router) Run(ctx context.Context) error {
+	runCtx := context.WithoutCancel(ctx)
+
+	r.mu.Lock()
+	r.runCtx = runCtx
+	r.mu.Unlock()
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer r.runOnce.Do(func() { close(r.runDone) })
+
+		errCh <- r.Router.Run(runCtx)
+	}()
+
+	select {
+	case err := <-errCh:
+		r.inFlight.abort()
+
+		return err
+	case <-ctx.Done():
+	}
+
+	r.logger.Info("Draining router", watermill.LogFields{
+		"in_flight": r.inFlight.count(),
+	})
+
+	closeErr := r.Router.Close()
+	r.inFlight.abort()
+
+	runErr := <-errCh
+
+	return errors.Join(runErr, closeErr)
+}
+
+// InFlight reports how many messages are currently being handled.
+func (r *Router) InFlight() int64 {
+	return r.inFlight.count()
+}
+
+// inFlightTracker counts running handlers and detaches them from subscriber
+// cancellation so they can finish while the router drains.
+type inFlightTracker struct {
+	active atomic.Int64
+
+	stopCtx context.Context
+	stop    context.CancelFunc
+}
+
+func newInFlightTracker() *inFlightTracker {
+	stopCtx, stop := context.WithCancel(context.Background())
+
+	return &inFlightTracker{stopCtx: stopCtx, stop: stop}
+}
+
+func (t *inFlightTracker) middleware(h wmmessage.HandlerFunc) wmmessage.HandlerFunc {
+	return func(msg *wmmessage.Message) ([]*wmmessage.Message, error) {
+		t.active.Add(1)
+		defer t.active.Add(-1)
+
+		parent := msg.Context()
+
+		ctx, cancel := context.WithCancel(context.WithoutCancel(parent))
+		defer cancel()
+
+		stopAfter := context.AfterFunc(t.stopCtx, cancel)
+		defer stopAfter()
+
+		msg.SetContext(ctx)
+
+		return h(msg)
+	}
+}
+
+func (t *inFlightTracker) count() int64 {
+	return t.active.Load()
+}
+
+func (t *inFlightTracker) abort() {
+	t.stop()
+}
@@ -0,0 +1,369 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	wmmessage "github.com/ThreeDotsLabs/watermill/message"
+	wmmid "github.com/ThreeDotsLabs/watermill/message/router/middleware"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+
+	"github.com/shortlink-org/go-sdk/auth/userid"
+	"github.com/shortlink-org/go-sdk/cqrs/handlers"
+	cqrsmessage "github.com/shortlink-org/go-sdk/cqrs/message"
+)
+
+func TestRouterDrainsInFlightHandlerOnShutdown(t *testing.T) {
+	logger := watermill.NopLogger{}
+	pubsub := gochannel.NewGoChannel(gochannel.Config{}, logger)
+	t.Cleanup(func() { _ = pubsub.Close() })
+
+	started := make(chan struct{})
+	completed := make(chan error, 1)
+
+	rt, err := NewRouter(logger, pubsub, pubsub, RouterConfig{
+		ServiceName:  "orders",
+		DrainTimeout: 2 * time.Second,
+		Handlers: []HandlerRegistration{
+			{
+				Name:  "slow_handler",
+				Topic: "orders.command.slow.v1",
+				Handler: func(msg *wmmessage.Message) ([]*wmmessage.Message, error) {
+					close(started)
+					time.Sleep(200 * time.Millisecond)
+					completed <- msg.Context().Err()
+
+					return nil, nil
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewRouter failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	runErr := make(chan error, 1)
+
+	go func() { runErr <- rt.Run(ctx) }()
+
+	<-rt.Running()
+
+	if err := pubsub.Publish("orders.command.slow.v1", wmmessage.NewMessage("1", []byte("{}"))); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("handler did not start")
+	}
+
+	if got := rt.InFlight(); got != 1 {
+		t.Errorf("expected 1 in-flight message, got %d", got)
+	}
+
+	cancel()
+
+	select {
+	case err := <-completed:
+		if err != nil {
+			t.Errorf("handler context canceled during drain: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("in-flight handler did not complete within drain window")
+	}
+
+	select {
+	case err := <-runErr:
+		if err != nil {
+			t.Errorf("Run returned error: %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("router did not stop")
+	}
+
+	if got := rt.InFlight(); got != 0 {
+		t.Errorf("expected 0 in-flight messages after drain, got %d", got)
+	}
+}
+
+// runMessageContextHandler publishes a message with context metadata through a
+// router and returns what the handler observed.
+func runMessageContextHandler(t *testing.T, trustUserID bool) (handlers.MessageContext, string) {
+	t.Helper()
+
+	logger := watermill.NopLogger{}
+	pubsub := gochannel.NewGoChannel(gochannel.Config{}, logger)
+	t.Cleanup(func() { _ = pubsub.Close() })
+
+	type observed struct {
+		mc          handlers.MessageContext
+		sessionUser string
+	}
+
+	got := make(chan observed, 1)
+
+	rt, err := NewRouter(logger, pubsub, pubsub, RouterConfig{
+		ServiceName:        "orders",
+		TrustMessageUserID: trustUserID,
+		Handlers: []HandlerRegistration{
+			{
+				Name:  "context_handler",
+				Topic: "orders.command.context.v1",
+				Handler: func(msg *wmmessage.Message) ([]*wmmessage.Message, error) {
+					userID, _ := userid.FromContext(msg.Context())
+					got <- observed{mc: handlers.FromContext(msg.Context()), sessionUser: userID}
+
+					return nil, nil
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewRouter failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go func() { _ = rt.Run(ctx) }()
+
+	<-rt.Running()
+
+	msg := wmmessage.NewMessage("1", []byte("{}"))
+	wmmid.SetCorrelationID("corr-1", msg)
+	msg.Metadata.Set(cqrsmessage.MetadataUserID, "user-1")
+	msg.Metadata.Set(cqrsmessage.MetadataTraceID, "4bf92f3577b34da6a3ce929d0e0e4736")
+
+	if err := pubsub.Publish("orders.command.context.v1", msg); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	select {
+	case obs := <-got:
+		return obs.mc, obs.sessionUser
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler did not receive message")
+	}
+
+	return handlers.MessageContext{}, ""
+}
+
+func TestRouterPopulatesMessageContext(t *testing.T) {
+	mc, sessionUser := runMessageContextHandler(t, false)
+
+	want := handlers.MessageContext{
+		CorrelationID: "corr-1",
+		UserID:        "user-1",
+		TraceID:       "4bf92f3577b34da6a3ce929d0e0e4736",
+	}
+	if mc != want {
+		t.Errorf("FromContext = %+v, want %+v", mc, want)
+	}
+
+	if sessionUser != "" {
+		t.Errorf("untrusted metadata user id became the session user %q", sessionUser)
+	}
+}
+
+func TestRouterTrustMessageUserIDRestoresSessionUser(t *testing.T) {
+	_, sessionUser := runMessageContextHandler(t, true)
+
+	if sessionUser != "user-1" {
+		t.Errorf("session user id = %q, want user-1", sessionUser)
+	}
+}
+
+func TestRouterCancelsHandlerAfterPerHandlerTimeout(t *testing.T) {
+	logger := watermill.NopLogger{}
+	pubsub := gochannel.NewGoChannel(gochannel.Config{}, logger)
+	t.Cleanup(func() { _ = pubsub.Close() })
+
+	canceled := make(chan error, 1)
+
+	rt, err := NewRouter(logger, pubsub, pubsub, RouterConfig{
+		ServiceName: "reports",
+		Handlers: []HandlerRegistration{
+			{
+				Name:    "generate_report",
+				Topic:   "reports.command.generate.v1",
+				Timeout: 50 * time.Millisecond,
+				Handler: func(msg *wmmessage.Message) ([]*wmmessage.Message, error) {
+					select {
+					case <-msg.Context().Done():
+						select {
+						case canceled <- msg.Context().Err():
+						default:
+						}
+
+						return nil, msg.Context().Err()
+					case <-time.After(2 * time.Second):
+						return nil, nil
+					}
+				},
+			},
+		},
+		Middlewares: RouterMiddlewareConfig{Timeout: time.Minute},
+	})
+	if err != nil {
+		t.Fatalf("NewRouter failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go func() { _ = rt.Run(ctx) }()
+
+	<-rt.Running()
+
+	if err := pubsub.Publish("reports.command.generate.v1", wmmessage.NewMessage("1", []byte("{}"))); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	select {
+	case err := <-canceled:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline exceeded, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("handler was not canceled by its per-handler timeout")
+	}
+}
+
+func TestWithHandlerTimeoutReturnsRetryableError(t *testing.T) {
+	handler := withHandlerTimeout("slow", 10*time.Millisecond, func(msg *wmmessage.Message) ([]*wmmessage.Message, error) {
+		<-msg.Context().Done()
+
+		return nil, msg.Context().Err()
+	})
+
+	_, err := handler(wmmessage.NewMessage("1", nil))
+	if !errors.Is(err, ErrHandlerTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected ErrHandlerTimeout wrapping deadline exceeded, got %v", err)
+	}
+
+	fast := withHandlerTimeout("fast", time.Second, func(*wmmessage.Message) ([]*wmmessage.Message, error) {
+		return nil, nil
+	})
+
+	if _, err := fast(wmmessage.NewMessage("2", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRouterAddHandlerBeforeRun(t *testing.T) {
+	logger := watermill.NopLogger{}
+	pubsub := gochannel.NewGoChannel(gochannel.Config{}, logger)
+	t.Cleanup(func() { _ = pubsub.Close() })
+
+	rt, err := NewRouter(logger, pubsub, pubsub, RouterConfig{ServiceName: "tenants"})
+	if err != nil {
+		t.Fatalf("NewRouter without handlers failed: %v", err)
+	}
+
+	received := make(chan string, 1)
+
+	err = rt.AddHandler(HandlerRegistration{
+		Name:  "tenant_handler",
+		Topic: "tenants.acme.event.created.v1",
+		Handler: func(msg *wmmessage.Message) ([]*wmmessage.Message, error) {
+			received <- msg.UUID
+
+			return nil, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddHandler failed: %v", err)
+	}
+
+	err = rt.AddHandler(HandlerRegistration{
+		Name:    "tenant_handler",
+		Topic:   "tenants.other.event.created.v1",
+		Handler: func(*wmmessage.Message) ([]*wmmessage.Message, error) { return nil, nil },
+	})
+	if !errors.Is(err, errDuplicateName) {
+		t.Fatalf("expected errDuplicateName, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() { _ = rt.Run(ctx) }()
+
+	<-rt.Running()
+
+	if err := pubsub.Publish("tenants.acme.event.created.v1", wmmessage.NewMessage("1", []byte("{}"))); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	select {
+	case uuid := <-received:
+		if uuid != "1" {
+			t.Errorf("expected message 1, got %s", uuid)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("handler added before Run did not consume the message")
+	}
+}
+
+func TestRouterAddHandlerWhileRunning(t *testing.T) {
+	logger := watermill.NopLogger{}
+	pubsub := gochannel.NewGoChannel(gochannel.Config{}, logger)
+	t.Cleanup(func() { _ = pubsub.Close() })
+
+	rt, err := NewRouter(logger, pubsub, pubsub, RouterConfig{ServiceName: "tenants"})
+	if err != nil {
+		t.Fatalf("NewRouter failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	runErr := make(chan error, 1)
+
+	go func() { runErr <- rt.Run(ctx) }()
+
+	<-rt.Running()
+
+	received := make(chan string, 1)
+
+	err = rt.AddHandler(HandlerRegistration{
+		Topic: "tenants.acme.event.created.v1",
+		Handler: func(msg *wmmessage.Message) ([]*wmmessage.Message, error) {
+			received <- msg.UUID
+
+			return nil, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddHandler on running router failed: %v", err)
+	}
+
+	if err := pubsub.Publish("tenants.acme.event.created.v1", wmmessage.NewMessage("2", []byte("{}"))); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	select {
+	case <-received:
+	case <-time.After(time.Second):
+		t.Fatal("handler added at runtime did not consume the message")
+	}
+
+	cancel()
+
+	if err := <-runErr; err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	err = rt.AddHandler(HandlerRegistration{
+		Topic:   "tenants.late.event.created.v1",
+		Handler: func(*wmmessage.Message) ([]*wmmessage.Message, error) { return nil, nil },
+	})
+	if !errors.Is(err, ErrRouterClosed) {
+		t.Fatalf("expected ErrRouterClosed, got %v", err)
+	}
+}
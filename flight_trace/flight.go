@@ -1,13 +1,10 @@
 package flight_trace
 
 import (
+	"bytes"
 	"context"
 	"errors"
-	"os"
-	"path/filepath"
 	"runtime/trace"
-	"sort"
-	"time"
 
 	"github.com/shortlink-org/go-sdk/config"
 )
@@ -21,15 +18,31 @@ var (
 	ErrStartRecorder          = errors.New("failed to start flight recorder")
 )
 
-// Recorder manages Go's Flight Recorder lifecycle and dump rotation.
+// Recorder manages Go's Flight Recorder lifecycle and routes dumps to a Sink.
 type Recorder struct {
-	fr       *trace.FlightRecorder
-	dumpPath string
-	cfg      *config.Config
+	fr   *trace.FlightRecorder
+	sink Sink
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithSink routes dumps to sink instead of FLIGHT_RECORDER_DUMP_PATH.
+// A nil sink discards dumps.
+func WithSink(sink Sink) Option {
+	return func(rec *Recorder) {
+		if sink == nil {
+			sink = NopSink{}
+		}
+
+		rec.sink = sink
+	}
 }
 
 // New initializes the Flight Recorder and starts background cleanup.
-func New(ctx context.Context, cfg *config.Config) (*Recorder, error) {
+// Without WithSink, dumps are written to FLIGHT_RECORDER_DUMP_PATH with rotation;
+// an empty path discards them.
+func New(ctx context.Context, cfg *config.Config, opts ...Option) (*Recorder, error) {
 	cfg.SetDefault("FLIGHT_RECORDER_ENABLED", true)
 	cfg.SetDefault("FLIGHT_RECORDER_MIN_AGE", "1s")
 	cfg.SetDefault("FLIGHT_RECORDER_MAX_BYTES", 20*1024*1024)
@@ -41,41 +54,55 @@ func New(ctx context.Context, cfg *config.Config) (*Recorder, error) {
 		return nil, nil
 	}
 
-	dumpPath := cfg.GetString("FLIGHT_RECORDER_DUMP_PATH")
-	err := os.MkdirAll(dumpPath, 0o755)
-	if err != nil {
-		return nil, ErrCreateDumpPath
+	rec := &Recorder{}
+	for _, opt := range opts {
+		opt(rec)
+	}
+
+	if rec.sink == nil {
+		sink, err := defaultSink(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		rec.sink = sink
 	}
 
-	fr := trace.NewFlightRecorder(trace.FlightRecorderConfig{
+	rec.fr = trace.NewFlightRecorder(trace.FlightRecorderConfig{
 		MinAge:   cfg.GetDuration("FLIGHT_RECORDER_MIN_AGE"),
 		MaxBytes: cfg.GetUint64("FLIGHT_RECORDER_MAX_BYTES"),
 	})
-	err = fr.Start()
+
+	err := rec.fr.Start()
 	if err != nil {
 		return nil, ErrStartRecorder
 	}
 
-	rec := &Recorder{
-		fr:       fr,
-		dumpPath: dumpPath,
-		cfg:      cfg,
-	}
-
 	// Graceful shutdown
 	go func() {
 		<-ctx.Done()
-		fr.Stop()
+		rec.fr.Stop()
 	}()
 
 	// Periodic cleanup
-	go rec.periodicCleanup(cfg.GetDuration("FLIGHT_RECORDER_CLEANUP_INTERVAL"))
+	if dir, ok := rec.sink.(*DirSink); ok {
+		go dir.periodicCleanup(ctx, cfg.GetDuration("FLIGHT_RECORDER_CLEANUP_INTERVAL"))
+	}
 
 	return rec, nil
 }
 
-// DumpToFile writes the flight recorder buffer into a file under dumpPath.
-func (wr *Recorder) DumpToFile(fileName string) error {
+func defaultSink(cfg *config.Config) (Sink, error) {
+	dumpPath := cfg.GetString("FLIGHT_RECORDER_DUMP_PATH")
+	if dumpPath == "" {
+		return NopSink{}, nil
+	}
+
+	return NewDirSink(dumpPath, cfg.GetInt("FLIGHT_RECORDER_MAX_DUMPS"))
+}
+
+// Dump snapshots the flight recorder buffer and hands it to the configured sink.
+func (wr *Recorder) Dump(ctx context.Context, name string) error {
 	if wr == nil {
 		return ErrRecorderNotInitialized
 	}
@@ -84,19 +111,17 @@ func (wr *Recorder) DumpToFile(fileName string) error {
 		return ErrRecorderNotStarted
 	}
 
-	f, err := os.Create(filepath.Join(wr.dumpPath, fileName))
-	if err != nil {
-		return ErrCreateDumpFile
-	}
-	defer f.Close()
-
-	if _, err = wr.fr.WriteTo(f); err != nil {
+	var buf bytes.Buffer
+	if _, err := wr.fr.WriteTo(&buf); err != nil {
 		return ErrWriteDump
 	}
 
-	go wr.cleanupOldDumps()
+	return wr.sink.Write(ctx, name, buf.Bytes())
+}
 
-	return nil
+// DumpToFile writes the flight recorder buffer to the configured sink under fileName.
+func (wr *Recorder) DumpToFile(fileName string) error {
+	return wr.Dump(context.Background(), fileName)
 }
 
 // DumpToFileAsync runs DumpToFile asynchronously.
@@ -105,37 +130,3 @@ func (wr *Recorder) DumpToFileAsync(fileName string) {
 		_ = wr.DumpToFile(fileName)
 	}()
 }
-
-// cleanupOldDumps keeps only the N newest dump files, deleting older ones.
-func (wr *Recorder) cleanupOldDumps() {
-	maxDumps := wr.cfg.GetInt("FLIGHT_RECORDER_MAX_DUMPS")
-	if maxDumps <= 0 {
-		maxDumps = 100
-	}
-
-	files, err := filepath.Glob(filepath.Join(wr.dumpPath, "flight-*.out"))
-	if err != nil || len(files) <= maxDumps {
-		return
-	}
-
-	sort.Slice(files, func(i, j int) bool {
-		fi, _ := os.Stat(files[i])
-		fj, _ := os.Stat(files[j])
-
-		return fi.ModTime().After(fj.ModTime()) // newest first
-	})
-
-	for _, old := range files[maxDumps:] {
-		_ = os.Remove(old)
-	}
-}
-
-// periodicCleanup periodically enforces dump rotation even if no new dumps are made.
-func (wr *Recorder) periodicCleanup(interval time.Duration) {
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		wr.cleanupOldDumps()
-	}
-}
@@ -0,0 +1,47 @@
+package flight_trace
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/config"
+)
+
+type memorySink struct {
+	mu        sync.Mutex
+	snapshots map[string][]byte
+}
+
+func (s *memorySink) Write(_ context.Context, name string, snapshot []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshots[name] = append([]byte(nil), snapshot...)
+
+	return nil
+}
+
+func TestRecorderDumpToSink(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	sink := &memorySink{snapshots: make(map[string][]byte)}
+
+	rec, err := New(ctx, cfg, WithSink(sink))
+	require.NoError(t, err)
+	require.NotNil(t, rec)
+
+	require.NoError(t, rec.Dump(ctx, "snapshot.out"))
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+
+	require.Contains(t, sink.snapshots, "snapshot.out")
+	require.NotEmpty(t, sink.snapshots["snapshot.out"])
+}
@@ -0,0 +1,169 @@
+package flight_trace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Sink receives flight recorder snapshots.
+type Sink interface {
+	Write(ctx context.Context, name string, snapshot []byte) error
+}
+
+// NopSink discards snapshots.
+type NopSink struct{}
+
+// Write implements Sink.
+func (NopSink) Write(context.Context, string, []byte) error {
+	return nil
+}
+
+// WriterSink writes every snapshot to a single io.Writer (e.g. a socket or a buffer).
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterSink creates a sink writing snapshots to w.
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+// Write implements Sink.
+func (s *WriterSink) Write(_ context.Context, _ string, snapshot []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.w.Write(snapshot); err != nil {
+		return fmt.Errorf("%w: %w", ErrWriteDump, err)
+	}
+
+	return nil
+}
+
+// DirSink writes snapshots as files into a directory and keeps only the newest maxDumps.
+// Files are named after the dump and only the ones this sink wrote are rotated, so other
+// files in the directory (including dumps from a previous process) are left alone.
+type DirSink struct {
+	dir      string
+	maxDumps int
+
+	mu    sync.Mutex
+	files []string // written by this sink, oldest first
+}
+
+// NewDirSink creates dir if needed. maxDumps <= 0 defaults to 100.
+func NewDirSink(dir string, maxDumps int) (*DirSink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, ErrCreateDumpPath
+	}
+
+	if maxDumps <= 0 {
+		maxDumps = 100
+	}
+
+	return &DirSink{dir: dir, maxDumps: maxDumps}, nil
+}
+
+// Write implements Sink.
+func (s *DirSink) Write(_ context.Context, name string, snapshot []byte) error {
+	filePath := filepath.Join(s.dir, filepath.Base(name))
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return ErrCreateDumpFile
+	}
+	defer f.Close()
+
+	if _, err = f.Write(snapshot); err != nil {
+		return ErrWriteDump
+	}
+
+	s.track(filePath)
+
+	go s.cleanup()
+
+	return nil
+}
+
+// track records filePath as the newest dump; rewriting a name moves it to the end.
+func (s *DirSink) track(filePath string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.files = slices.DeleteFunc(s.files, func(f string) bool { return f == filePath })
+	s.files = append(s.files, filePath)
+}
+
+// cleanup keeps only the N newest dump files, deleting older ones.
+func (s *DirSink) cleanup() {
+	s.mu.Lock()
+
+	if len(s.files) <= s.maxDumps {
+		s.mu.Unlock()
+
+		return
+	}
+
+	stale := slices.Clone(s.files[:len(s.files)-s.maxDumps])
+	s.files = slices.Clone(s.files[len(s.files)-s.maxDumps:])
+	s.mu.Unlock()
+
+	for _, old := range stale {
+		_ = os.Remove(old)
+	}
+}
+
+// ObjectUploader stores objects in an S3-compatible bucket.
+// It matches the UploadFile method of the go-sdk s3 client.
+type ObjectUploader interface {
+	UploadFile(ctx context.Context, bucketName, objectName string, reader *bytes.Reader) error
+}
+
+// ObjectSink ships snapshots off-node to an S3-compatible bucket.
+type ObjectSink struct {
+	uploader ObjectUploader
+	bucket   string
+	prefix   string
+}
+
+// NewObjectSink creates a sink uploading snapshots to bucket under prefix.
+func NewObjectSink(uploader ObjectUploader, bucket, prefix string) *ObjectSink {
+	return &ObjectSink{uploader: uploader, bucket: bucket, prefix: prefix}
+}
+
+// Write implements Sink.
+func (s *ObjectSink) Write(ctx context.Context, name string, snapshot []byte) error {
+	if err := s.uploader.UploadFile(ctx, s.bucket, path.Join(s.prefix, name), bytes.NewReader(snapshot)); err != nil {
+		return fmt.Errorf("%w: %w", ErrWriteDump, err)
+	}
+
+	return nil
+}
+
+// periodicCleanup enforces rotation even if no new dumps are made.
+func (s *DirSink) periodicCleanup(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.cleanup()
+		}
+	}
+}
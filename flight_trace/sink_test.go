@@ -0,0 +1,29 @@
+package flight_trace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirSinkCleanupKeepsForeignFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	foreign := filepath.Join(dir, "profile.out")
+	require.NoError(t, os.WriteFile(foreign, []byte("cpu"), 0o600))
+
+	sink, err := NewDirSink(dir, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Write(context.Background(), "trace-a.out", []byte("a")))
+	require.NoError(t, sink.Write(context.Background(), "trace-b.out", []byte("b")))
+
+	sink.cleanup()
+
+	require.NoFileExists(t, filepath.Join(dir, "trace-a.out"))
+	require.FileExists(t, filepath.Join(dir, "trace-b.out"))
+	require.FileExists(t, foreign)
+}
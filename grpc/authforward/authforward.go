@@ -110,6 +110,10 @@ func TokenFromOutgoingMetadata(ctx context.Context) string {
 // SetOutgoingToken sets (not appends) the authorization token in outgoing metadata.
 // This prevents accumulation of multiple authorization values across hops.
 func SetOutgoingToken(ctx context.Context, token string) context.Context {
+	return setOutgoingValue(ctx, MetadataKey, token)
+}
+
+func setOutgoingValue(ctx context.Context, key, token string) context.Context {
 	if token == "" {
 		return ctx
 	}
@@ -124,7 +128,7 @@ func SetOutgoingToken(ctx context.Context, token string) context.Context {
 	newMD := outgoingMD.Copy()
 
 	// Set (replace) the authorization value - prevents accumulation
-	newMD.Set(MetadataKey, token)
+	newMD.Set(key, token)
 
 	return metadata.NewOutgoingContext(ctx, newMD)
 }
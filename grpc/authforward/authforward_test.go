@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -218,7 +219,7 @@ func TestCaptureAndForward(t *testing.T) {
 	require.Equal(t, "Bearer user-token", token)
 
 	// Client interceptor forwards token
-	clientCtx := forwardToken(serverCtx, "/test.Service/Method")
+	clientCtx := forwardToken(serverCtx, "/test.Service/Method", MetadataKey)
 
 	// Verify token is in outgoing metadata
 	outToken := TokenFromOutgoingMetadata(clientCtx)
@@ -236,7 +237,7 @@ func TestForwardToken_DoesNotDuplicate(t *testing.T) {
 	ctx = WithToken(ctx, "Bearer from-context")
 
 	// Forward should not overwrite existing
-	ctx = forwardToken(ctx, "/test.Service/Method")
+	ctx = forwardToken(ctx, "/test.Service/Method", MetadataKey)
 
 	outMD, _ := metadata.FromOutgoingContext(ctx)
 	values := outMD.Get("authorization")
@@ -258,3 +259,51 @@ func TestMetadataTokenExtractor_MultipleValues(t *testing.T) {
 	assert.Empty(t, token)
 	assert.ErrorIs(t, err, ErrMultipleAuthorizationValues)
 }
+
+func TestForwardToken_CustomMetadataKey(t *testing.T) {
+	t.Parallel()
+
+	md := metadata.Pairs("x-id-token", "Bearer existing")
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	ctx = WithToken(ctx, "Bearer from-context")
+
+	cfg := newClientConfig(WithMetadataKey("X-ID-Token"))
+	require.Equal(t, "x-id-token", cfg.metadataKey)
+
+	ctx = forwardToken(ctx, "/test.Service/Method", cfg.metadataKey)
+
+	outMD, _ := metadata.FromOutgoingContext(ctx)
+
+	values := outMD.Get("x-id-token")
+	require.Len(t, values, 1)
+	assert.Equal(t, "Bearer from-context", values[0])
+	assert.Empty(t, outMD.Get(MetadataKey))
+}
+
+func TestUnaryClientInterceptor_Options(t *testing.T) {
+	t.Parallel()
+
+	ctx := WithToken(context.Background(), "Bearer user-token")
+
+	invoke := func(interceptor grpc.UnaryClientInterceptor) metadata.MD {
+		var got metadata.MD
+
+		err := interceptor(ctx, "/test.Service/Method", nil, nil, nil,
+			func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				got, _ = metadata.FromOutgoingContext(ctx)
+
+				return nil
+			},
+		)
+		require.NoError(t, err)
+
+		return got
+	}
+
+	md := invoke(UnaryClientInterceptor(WithMetadataKey("x-id-token")))
+	assert.Equal(t, []string{"Bearer user-token"}, md.Get("x-id-token"))
+	assert.Empty(t, md.Get(MetadataKey))
+
+	md = invoke(UnaryClientInterceptor(WithEnabled(false)))
+	assert.Empty(t, md.Get(MetadataKey))
+}
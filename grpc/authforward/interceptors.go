@@ -8,6 +8,7 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 var tracer = otel.Tracer("authforward")
@@ -62,7 +63,9 @@ func captureToken(ctx context.Context) context.Context {
 
 // UnaryClientInterceptor forwards the Authorization token from context
 // to outgoing gRPC metadata for downstream services.
-func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+func UnaryClientInterceptor(opts ...ClientOption) grpc.UnaryClientInterceptor {
+	cfg := newClientConfig(opts...)
+
 	return func(
 		ctx context.Context,
 		method string,
@@ -71,7 +74,9 @@ func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
 		invoker grpc.UnaryInvoker,
 		opts ...grpc.CallOption,
 	) error {
-		ctx = forwardToken(ctx, method)
+		if cfg.enabled {
+			ctx = forwardToken(ctx, method, cfg.metadataKey)
+		}
 
 		return invoker(ctx, method, req, reply, conn, opts...)
 	}
@@ -79,7 +84,9 @@ func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
 
 // StreamClientInterceptor forwards the Authorization token from context
 // to outgoing gRPC metadata for downstream services.
-func StreamClientInterceptor() grpc.StreamClientInterceptor {
+func StreamClientInterceptor(opts ...ClientOption) grpc.StreamClientInterceptor {
+	cfg := newClientConfig(opts...)
+
 	return func(
 		ctx context.Context,
 		desc *grpc.StreamDesc,
@@ -88,14 +95,16 @@ func StreamClientInterceptor() grpc.StreamClientInterceptor {
 		streamer grpc.Streamer,
 		opts ...grpc.CallOption,
 	) (grpc.ClientStream, error) {
-		ctx = forwardToken(ctx, method)
+		if cfg.enabled {
+			ctx = forwardToken(ctx, method, cfg.metadataKey)
+		}
 
 		return streamer(ctx, desc, conn, method, opts...)
 	}
 }
 
-// forwardToken copies token from context to outgoing metadata.
-func forwardToken(ctx context.Context, method string) context.Context {
+// forwardToken copies token from context to outgoing metadata under key.
+func forwardToken(ctx context.Context, method, key string) context.Context {
 	_, span := tracer.Start(ctx, "authforward.ForwardToken",
 		trace.WithAttributes(attribute.String("rpc.method", method)),
 	)
@@ -110,14 +119,14 @@ func forwardToken(ctx context.Context, method string) context.Context {
 		return ctx
 	}
 
-	if existing := TokenFromOutgoingMetadata(ctx); existing != "" {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(key)) > 0 {
 		span.SetAttributes(attribute.Bool("auth.token_replaced", true))
 	}
 
 	span.SetAttributes(attribute.Bool("auth.token_present", true))
 	span.SetStatus(codes.Ok, "token forwarded")
 
-	return SetOutgoingToken(ctx, token)
+	return setOutgoingValue(ctx, key, token)
 }
 
 // =============================================================================
@@ -0,0 +1,44 @@
+package authforward
+
+import "strings"
+
+// ClientOption configures the client forwarding interceptors.
+type ClientOption func(*clientConfig)
+
+type clientConfig struct {
+	metadataKey string
+	enabled     bool
+}
+
+func newClientConfig(opts ...ClientOption) clientConfig {
+	cfg := clientConfig{
+		metadataKey: MetadataKey,
+		enabled:     true,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+
+	return cfg
+}
+
+// WithMetadataKey forwards the token under a custom metadata key (e.g. "x-id-token")
+// instead of "authorization". Keys are lowercased for gRPC metadata compliance.
+func WithMetadataKey(key string) ClientOption {
+	return func(cfg *clientConfig) {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key != "" {
+			cfg.metadataKey = key
+		}
+	}
+}
+
+// WithEnabled toggles forwarding; disabled interceptors pass calls through untouched.
+func WithEnabled(enabled bool) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.enabled = enabled
+	}
+}
@@ -2,7 +2,7 @@
 //
 // This package validates JWT tokens at service boundaries using JWKS for
 // signature verification. It is designed to work with Oathkeeper's id_token
-// mutator but can be used with any RS256 JWT issuer.
+// mutator but can be used with any RS256/RS384/RS512 JWT issuer.
 //
 // Security considerations:
 // - Always validate issuer and audience claims
@@ -16,10 +16,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/shortlink-org/go-sdk/logger"
 )
 
 // ErrMissingToken indicates no token was provided.
@@ -30,6 +33,11 @@ const (
 	DefaultLeeway = 30 * time.Second
 )
 
+// supportedAlgorithms are the signing algorithms JWKS RSA keys can verify.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var supportedAlgorithms = []string{"RS256", "RS384", "RS512"}
+
 // Validator validates JWT tokens.
 type Validator struct {
 	jwks          JWKSFetcher
@@ -39,6 +47,8 @@ type Validator struct {
 	skipIssuer    bool
 	leeway        time.Duration
 	customKeyfunc jwt.Keyfunc
+	clock         Clock
+	algorithms    []string
 }
 
 // ValidatorConfig configures the JWT validator.
@@ -67,8 +77,15 @@ type ValidatorConfig struct {
 	KeyFetcher JWKSFetcher
 	// CustomKeyfunc overrides the default JWKS-based key lookup (for testing)
 	CustomKeyfunc jwt.Keyfunc
-	// Clock overrides time source for JWKS (for testing)
+	// Clock overrides the time source for exp/nbf/iat checks and JWKS caching (for testing)
 	Clock Clock
+	// Logger logs JWKS key rotations at debug level (optional).
+	Logger logger.Logger
+	// JWKSPrefetch fetches JWKS in the background on construction (see JWKSConfig.Prefetch).
+	JWKSPrefetch bool
+	// AllowedAlgorithms lists the accepted signing algorithms: RS256, RS384 and/or RS512
+	// (default: RS256). Tokens signed with any other algorithm fail with ErrAlgorithmNotAllowed.
+	AllowedAlgorithms []string
 }
 
 // NewValidator creates a new JWT validator.
@@ -91,6 +108,20 @@ func NewValidator(cfg ValidatorConfig) (*Validator, error) {
 		cfg.Leeway = DefaultLeeway
 	}
 
+	if len(cfg.AllowedAlgorithms) == 0 {
+		cfg.AllowedAlgorithms = []string{"RS256"}
+	}
+
+	for _, alg := range cfg.AllowedAlgorithms {
+		if !slices.Contains(supportedAlgorithms, alg) {
+			return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, alg)
+		}
+	}
+
+	if cfg.Clock == nil {
+		cfg.Clock = realClock{}
+	}
+
 	validator := &Validator{
 		issuer:        cfg.Issuer,
 		audience:      cfg.Audience,
@@ -98,6 +129,8 @@ func NewValidator(cfg ValidatorConfig) (*Validator, error) {
 		skipIssuer:    cfg.SkipIssuer,
 		leeway:        cfg.Leeway,
 		customKeyfunc: cfg.CustomKeyfunc,
+		clock:         cfg.Clock,
+		algorithms:    slices.Clone(cfg.AllowedAlgorithms),
 	}
 
 	if cfg.KeyFetcher != nil {
@@ -110,6 +143,8 @@ func NewValidator(cfg ValidatorConfig) (*Validator, error) {
 			BackoffMin:  cfg.JWKSBackoffMin,
 			BackoffMax:  cfg.JWKSBackoffMax,
 			Clock:       cfg.Clock,
+			Logger:      cfg.Logger,
+			Prefetch:    cfg.JWKSPrefetch,
 		})
 	}
 
@@ -142,7 +177,7 @@ func (v *Validator) Validate(ctx context.Context, tokenString string) ValidateRe
 	// Build parser options
 	opts := []jwt.ParserOption{
 		jwt.WithLeeway(v.leeway),
-		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithTimeFunc(v.clock.Now),
 	}
 
 	if !v.skipIssuer && v.issuer != "" {
@@ -168,7 +203,7 @@ func (v *Validator) Validate(ctx context.Context, tokenString string) ValidateRe
 	// Parse and validate
 	claims := &Claims{}
 
-	token, err := parser.ParseWithClaims(tokenString, claims, keyfunc)
+	token, err := parser.ParseWithClaims(tokenString, claims, v.allowAlgorithms(keyfunc))
 	if err != nil {
 		if isKnownValidationError(err) {
 			return ValidateResult{Error: err}
@@ -187,7 +222,30 @@ func (v *Validator) Validate(ctx context.Context, tokenString string) ValidateRe
 	}
 }
 
-// Close releases resources.
+// allowAlgorithms rejects tokens signed with an algorithm outside the allowlist
+// before any key lookup. The keyfunc runs before the signature is verified, so this
+// replaces jwt.WithValidMethods while returning a typed error.
+func (v *Validator) allowAlgorithms(keyfunc jwt.Keyfunc) jwt.Keyfunc {
+	return func(token *jwt.Token) (any, error) {
+		if !slices.Contains(v.algorithms, token.Method.Alg()) {
+			return nil, fmt.Errorf("%w: %s", ErrAlgorithmNotAllowed, token.Method.Alg())
+		}
+
+		return keyfunc(token)
+	}
+}
+
+// Ready reports whether the validator can verify tokens without fetching JWKS first.
+// Validators with a custom key lookup that does not report readiness are always ready.
+func (v *Validator) Ready() bool {
+	if ready, ok := v.jwks.(interface{ Ready() bool }); ok {
+		return ready.Ready()
+	}
+
+	return true
+}
+
+// Close releases resources held by the JWKS fetcher: its background work and idle connections.
 func (v *Validator) Close() error {
 	if v.jwks != nil {
 		return v.jwks.Close()
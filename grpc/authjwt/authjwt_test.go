@@ -10,6 +10,8 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const testRSAKeyBits = 2048
@@ -41,7 +43,13 @@ func mockKeyfunc(_ *jwt.Token) (any, error) {
 func createTestToken(t *testing.T, claims *Claims) string {
 	t.Helper()
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	return createTestTokenWithMethod(t, jwt.SigningMethodRS256, claims)
+}
+
+func createTestTokenWithMethod(t *testing.T, method jwt.SigningMethod, claims *Claims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
 	token.Header["kid"] = "test-key-1"
 
 	tokenString, err := token.SignedString(testPrivateKey)
@@ -331,3 +339,95 @@ func TestShouldSkip(t *testing.T) {
 		})
 	}
 }
+
+func TestValidator_ClockExpiresToken(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+
+	validator, err := NewValidator(ValidatorConfig{
+		Issuer:        "https://shortlink.best",
+		Audience:      "shortlink-api",
+		Leeway:        10 * time.Second,
+		CustomKeyfunc: mockKeyfunc,
+		Clock:         clock,
+	})
+	require.NoError(t, err)
+
+	token := createTestToken(t, &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-123",
+			Issuer:    "https://shortlink.best",
+			Audience:  jwt.ClaimStrings{"shortlink-api"},
+			ExpiresAt: jwt.NewNumericDate(clock.Now().Add(time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(clock.Now()),
+		},
+	})
+
+	result := validator.Validate(context.Background(), token)
+	require.True(t, result.Valid)
+	require.NoError(t, result.Error)
+
+	// Still within leeway after exp.
+	clock.Advance(time.Minute + 5*time.Second)
+
+	result = validator.Validate(context.Background(), token)
+	require.True(t, result.Valid)
+
+	clock.Advance(10 * time.Second)
+
+	result = validator.Validate(context.Background(), token)
+	assert.False(t, result.Valid)
+	assert.ErrorIs(t, result.Error, jwt.ErrTokenExpired)
+}
+
+func TestValidator_AllowedAlgorithms(t *testing.T) {
+	t.Parallel()
+
+	token := createTestTokenWithMethod(t, jwt.SigningMethodRS512, &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-123",
+			Issuer:    "https://shortlink.best",
+			Audience:  jwt.ClaimStrings{"shortlink-api"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+
+	// Default allowlist is RS256 only.
+	validator, err := NewValidator(ValidatorConfig{
+		Issuer:        "https://shortlink.best",
+		Audience:      "shortlink-api",
+		CustomKeyfunc: mockKeyfunc,
+	})
+	require.NoError(t, err)
+
+	result := validator.Validate(context.Background(), token)
+	require.False(t, result.Valid)
+	require.ErrorIs(t, result.Error, ErrAlgorithmNotAllowed)
+	assert.Equal(t, "algorithm_not_allowed", classifyError(result.Error))
+	assert.Equal(t, codes.Unauthenticated, status.Code(ToGRPCStatus(result.Error)))
+
+	validator, err = NewValidator(ValidatorConfig{
+		Issuer:            "https://shortlink.best",
+		Audience:          "shortlink-api",
+		CustomKeyfunc:     mockKeyfunc,
+		AllowedAlgorithms: []string{"RS256", "RS512"},
+	})
+	require.NoError(t, err)
+
+	result = validator.Validate(context.Background(), token)
+	require.NoError(t, result.Error)
+	assert.True(t, result.Valid)
+}
+
+func TestNewValidator_RejectsUnsupportedAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewValidator(ValidatorConfig{
+		Issuer:            "https://shortlink.best",
+		Audience:          "shortlink-api",
+		CustomKeyfunc:     mockKeyfunc,
+		AllowedAlgorithms: []string{"HS256"},
+	})
+	require.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+}
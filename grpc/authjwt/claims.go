@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/shortlink-org/go-sdk/auth/session"
 )
 
 type claimsContextKey struct{}
@@ -26,6 +28,36 @@ type Claims struct {
 	Metadata   map[string]any `json:"metadata,omitempty"`
 }
 
+// MetadataString reads a string from the claims metadata.
+// Values are coerced like session.MetadataString.
+func (c *Claims) MetadataString(key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	return session.MetadataString(c.Metadata, key)
+}
+
+// MetadataStringSlice reads a list of strings from the claims metadata.
+// Values are coerced like session.MetadataStringSlice.
+func (c *Claims) MetadataStringSlice(key string) ([]string, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	return session.MetadataStringSlice(c.Metadata, key)
+}
+
+// MetadataInt reads an int from the claims metadata.
+// Values are coerced like session.MetadataInt.
+func (c *Claims) MetadataInt(key string) (int, bool) {
+	if c == nil {
+		return 0, false
+	}
+
+	return session.MetadataInt(c.Metadata, key)
+}
+
 // WithClaims stores validated claims in context.
 func WithClaims(ctx context.Context, claims *Claims) context.Context {
 	return context.WithValue(ctx, claimsKey, claims)
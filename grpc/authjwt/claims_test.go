@@ -0,0 +1,95 @@
+package authjwt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/auth/session"
+)
+
+// metadataReader is implemented by both authjwt.Claims and session.Claims.
+type metadataReader interface {
+	MetadataString(key string) (string, bool)
+	MetadataStringSlice(key string) ([]string, bool)
+	MetadataInt(key string) (int, bool)
+}
+
+func TestClaimsMetadataAccessors(t *testing.T) {
+	// Decoded from JSON, as the interceptors do.
+	var metadata map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"org": "acme",
+		"roles": ["admin", "editor"],
+		"role": "viewer",
+		"mixed": ["admin", 1],
+		"seats": 25,
+		"ratio": 0.5
+	}`), &metadata))
+
+	readers := map[string]metadataReader{
+		"authjwt": &Claims{Metadata: metadata},
+		"session": &session.Claims{Metadata: metadata},
+	}
+
+	for name, claims := range readers {
+		t.Run(name, func(t *testing.T) {
+			t.Run("present", func(t *testing.T) {
+				org, ok := claims.MetadataString("org")
+				assert.True(t, ok)
+				assert.Equal(t, "acme", org)
+
+				roles, ok := claims.MetadataStringSlice("roles")
+				assert.True(t, ok)
+				assert.Equal(t, []string{"admin", "editor"}, roles)
+
+				role, ok := claims.MetadataStringSlice("role")
+				assert.True(t, ok)
+				assert.Equal(t, []string{"viewer"}, role)
+
+				seats, ok := claims.MetadataInt("seats")
+				assert.True(t, ok)
+				assert.Equal(t, 25, seats)
+			})
+
+			t.Run("wrong type", func(t *testing.T) {
+				_, ok := claims.MetadataString("roles")
+				assert.False(t, ok)
+
+				_, ok = claims.MetadataStringSlice("mixed")
+				assert.False(t, ok)
+
+				_, ok = claims.MetadataInt("org")
+				assert.False(t, ok)
+
+				_, ok = claims.MetadataInt("ratio")
+				assert.False(t, ok)
+			})
+
+			t.Run("absent", func(t *testing.T) {
+				_, ok := claims.MetadataString("missing")
+				assert.False(t, ok)
+
+				_, ok = claims.MetadataStringSlice("missing")
+				assert.False(t, ok)
+
+				_, ok = claims.MetadataInt("missing")
+				assert.False(t, ok)
+			})
+		})
+	}
+
+	t.Run("nil claims", func(t *testing.T) {
+		var claims *Claims
+
+		_, ok := claims.MetadataString("org")
+		assert.False(t, ok)
+
+		var sessionClaims *session.Claims
+
+		_, ok = sessionClaims.MetadataInt("seats")
+		assert.False(t, ok)
+	})
+}
@@ -33,6 +33,10 @@ var (
 	ErrMultipleAuthHeaders = errors.New("multiple authorization headers")
 	// ErrUnexpectedSignMethod is returned when token uses unexpected signing method.
 	ErrUnexpectedSignMethod = errors.New("unexpected signing method")
+	// ErrAlgorithmNotAllowed is returned when the token's algorithm is not in ValidatorConfig.AllowedAlgorithms.
+	ErrAlgorithmNotAllowed = errors.New("signing algorithm not allowed")
+	// ErrUnsupportedAlgorithm is returned when ValidatorConfig.AllowedAlgorithms lists an algorithm other than RS256, RS384 or RS512.
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm in configuration")
 )
 
 // errorMappings defines how errors map to gRPC status codes.
@@ -54,12 +58,14 @@ var errorMappings = []struct {
 	{ErrKeyNotFound, codes.Unauthenticated, "unknown signing key"},
 	{ErrMissingKid, codes.InvalidArgument, "missing key id in token"},
 	{ErrUnexpectedSignMethod, codes.InvalidArgument, "unsupported signing method"},
+	{ErrAlgorithmNotAllowed, codes.Unauthenticated, "signing algorithm not allowed"},
 	{ErrNoValidKeys, codes.Unavailable, "authentication service unavailable"},
 	{ErrUnexpectedStatus, codes.Unavailable, "authentication service unavailable"},
 	{ErrJWKSBackoff, codes.Unavailable, "authentication service unavailable"},
 	{ErrInvalidToken, codes.Unauthenticated, "invalid token"},
 	{ErrIssuerRequired, codes.Internal, "authentication configuration error"},
 	{ErrAudienceRequired, codes.Internal, "authentication configuration error"},
+	{ErrUnsupportedAlgorithm, codes.Internal, "authentication configuration error"},
 }
 
 // ToGRPCStatus converts a JWT validation error to an appropriate gRPC status.
@@ -86,6 +92,7 @@ func isKnownValidationError(err error) bool {
 		errors.Is(err, ErrKeyNotFound),
 		errors.Is(err, ErrMissingKid),
 		errors.Is(err, ErrUnexpectedSignMethod),
+		errors.Is(err, ErrAlgorithmNotAllowed),
 		errors.Is(err, ErrNoValidKeys),
 		errors.Is(err, ErrUnexpectedStatus),
 		errors.Is(err, ErrJWKSBackoff),
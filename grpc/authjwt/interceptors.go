@@ -55,6 +55,9 @@ type InterceptorConfig struct {
 	SkipMethods []string
 	// Logger logs authentication failures (optional).
 	Logger logger.Logger
+	// CancelStreamOnExpiry cancels the stream context once the token's exp claim
+	// (plus validator leeway) passes, so long-lived streams don't outlive the token.
+	CancelStreamOnExpiry bool
 }
 
 // UnaryServerInterceptor validates JWT tokens on incoming unary requests.
@@ -103,10 +106,29 @@ func StreamServerInterceptor(validator *Validator, cfg InterceptorConfig) grpc.S
 			return err
 		}
 
+		if cfg.CancelStreamOnExpiry {
+			var cancel context.CancelFunc
+
+			ctx, cancel = withTokenExpiry(ctx, validator.clock, validator.leeway)
+			defer cancel()
+		}
+
 		return handler(srv, &wrappedServerStream{ServerStream: stream, wrappedCtx: ctx})
 	}
 }
 
+// withTokenExpiry derives a context that is canceled with jwt.ErrTokenExpired
+// as cause once the validated token expires. The remaining lifetime is measured
+// with the validator clock, the one exp was checked against.
+func withTokenExpiry(ctx context.Context, clock Clock, leeway time.Duration) (context.Context, context.CancelFunc) {
+	expiresAt := GetExpiresAt(ctx)
+	if expiresAt.IsZero() {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeoutCause(ctx, expiresAt.Add(leeway).Sub(clock.Now()), jwt.ErrTokenExpired)
+}
+
 func validateRequest(ctx context.Context, validator *Validator, method string, log logger.Logger) (context.Context, error) {
 	start := time.Now()
 
@@ -214,6 +236,8 @@ func classifyError(err error) string {
 		return "invalid_issuer"
 	case errors.Is(err, ErrKeyNotFound):
 		return "unknown_kid"
+	case errors.Is(err, ErrAlgorithmNotAllowed):
+		return "algorithm_not_allowed"
 	case errors.Is(err, ErrNoValidKeys), errors.Is(err, ErrUnexpectedStatus), errors.Is(err, ErrJWKSBackoff):
 		return "jwks_unavailable"
 	default:
@@ -278,3 +302,29 @@ type wrappedServerStream struct {
 func (wrapper *wrappedServerStream) Context() context.Context {
 	return wrapper.wrappedCtx
 }
+
+// SendMsg refuses to send once the token backing the stream has expired.
+func (wrapper *wrappedServerStream) SendMsg(m any) error {
+	if err := wrapper.expiredErr(); err != nil {
+		return err
+	}
+
+	return wrapper.ServerStream.SendMsg(m)
+}
+
+// RecvMsg refuses to receive once the token backing the stream has expired.
+func (wrapper *wrappedServerStream) RecvMsg(m any) error {
+	if err := wrapper.expiredErr(); err != nil {
+		return err
+	}
+
+	return wrapper.ServerStream.RecvMsg(m)
+}
+
+func (wrapper *wrappedServerStream) expiredErr() error {
+	if errors.Is(context.Cause(wrapper.wrappedCtx), jwt.ErrTokenExpired) {
+		return ToGRPCStatus(jwt.ErrTokenExpired)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -128,3 +130,108 @@ func TestValidateRequest_SetsSessionClaims(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "user-123", userID)
 }
+
+type fakeServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
+
+func (*fakeServerStream) RecvMsg(any) error { return nil }
+
+func (*fakeServerStream) SendMsg(any) error { return nil }
+
+func TestStreamServerInterceptor_CancelsStreamOnTokenExpiry(t *testing.T) {
+	t.Parallel()
+
+	_, pub := getInterceptorKeys(t)
+	validator, err := NewValidator(ValidatorConfig{
+		Issuer:        "https://shortlink.best",
+		Audience:      "shortlink-api",
+		Leeway:        time.Millisecond,
+		CustomKeyfunc: func(_ *jwt.Token) (any, error) { return pub, nil },
+	})
+	require.NoError(t, err)
+
+	token := createInterceptorToken(t, &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-123",
+			Issuer:    "https://shortlink.best",
+			Audience:  jwt.ClaimStrings{"shortlink-api"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(2 * time.Second)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	})
+
+	md := metadata.Pairs("authorization", "Bearer "+token)
+	stream := &fakeServerStream{ctx: metadata.NewIncomingContext(context.Background(), md)}
+
+	interceptor := StreamServerInterceptor(validator, InterceptorConfig{CancelStreamOnExpiry: true})
+
+	start := time.Now()
+	gotErr := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/test.Service/Watch"},
+		func(_ any, ss grpc.ServerStream) error {
+			require.NoError(t, ss.RecvMsg(nil))
+
+			select {
+			case <-ss.Context().Done():
+			case <-time.After(5 * time.Second):
+				return errors.New("stream was not canceled")
+			}
+
+			require.ErrorIs(t, context.Cause(ss.Context()), jwt.ErrTokenExpired)
+
+			return ss.SendMsg(nil)
+		})
+
+	require.Error(t, gotErr)
+	assert.Equal(t, codes.Unauthenticated, status.Code(gotErr))
+	assert.Less(t, time.Since(start), 4*time.Second)
+}
+
+func TestStreamServerInterceptor_ExpiryUsesValidatorClock(t *testing.T) {
+	t.Parallel()
+
+	// The validator clock is far behind the wall clock, so the token is still valid for it.
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+
+	_, pub := getInterceptorKeys(t)
+	validator, err := NewValidator(ValidatorConfig{
+		Issuer:        "https://shortlink.best",
+		Audience:      "shortlink-api",
+		Leeway:        time.Millisecond,
+		CustomKeyfunc: func(_ *jwt.Token) (any, error) { return pub, nil },
+		Clock:         clock,
+	})
+	require.NoError(t, err)
+
+	token := createInterceptorToken(t, &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-123",
+			Issuer:    "https://shortlink.best",
+			Audience:  jwt.ClaimStrings{"shortlink-api"},
+			ExpiresAt: jwt.NewNumericDate(clock.Now().Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(clock.Now()),
+		},
+	})
+
+	md := metadata.Pairs("authorization", "Bearer "+token)
+	stream := &fakeServerStream{ctx: metadata.NewIncomingContext(context.Background(), md)}
+
+	interceptor := StreamServerInterceptor(validator, InterceptorConfig{CancelStreamOnExpiry: true})
+
+	gotErr := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/test.Service/Watch"},
+		func(_ any, ss grpc.ServerStream) error {
+			require.NoError(t, ss.Context().Err())
+
+			deadline, ok := ss.Context().Deadline()
+			require.True(t, ok)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), deadline, time.Minute)
+
+			return nil
+		})
+
+	require.NoError(t, gotErr)
+}
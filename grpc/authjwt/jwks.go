@@ -7,14 +7,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
+	"maps"
 	"math/big"
 	"net/http"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/shortlink-org/go-sdk/logger"
 )
 
 const (
@@ -54,6 +59,12 @@ var (
 			Buckets: prometheus.DefBuckets,
 		},
 	)
+	jwksKeysRotatedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "jwks_keys_rotated_total",
+			Help: "Total JWKS refreshes whose key IDs differ from the previous key set.",
+		},
+	)
 )
 
 // JWKSFetcher fetches and caches JWKS keys for validation.
@@ -72,6 +83,7 @@ type jwksFetcher struct {
 	backoffMin time.Duration
 	backoffMax time.Duration
 	clock      Clock
+	log        logger.Logger
 
 	mu        sync.RWMutex
 	keys      map[string]*rsa.PublicKey
@@ -85,6 +97,12 @@ type jwksFetcher struct {
 	// backoff state (guarded by fetchMu)
 	backoff   time.Duration
 	nextRetry time.Time
+
+	// prefetch loop, started when JWKSConfig.Prefetch is set
+	stopPrefetch context.CancelFunc
+	prefetchDone chan struct{}
+
+	closeOnce sync.Once
 }
 
 // JWKSConfig configures the JWKS fetcher.
@@ -101,6 +119,11 @@ type JWKSConfig struct {
 	BackoffMax time.Duration
 	// Clock overrides the time source (default: real clock)
 	Clock Clock
+	// Logger logs key rotations at debug level (optional).
+	Logger logger.Logger
+	// Prefetch fetches keys in the background on construction, retrying with
+	// backoff until the first success, so the first request does not wait on JWKS.
+	Prefetch bool
 }
 
 // NewJWKSFetcher creates a new JWKS fetcher.
@@ -135,16 +158,65 @@ func NewJWKSFetcher(cfg JWKSConfig) *jwksFetcher {
 		backoffMin: cfg.BackoffMin,
 		backoffMax: cfg.BackoffMax,
 		clock:      cfg.Clock,
+		log:        cfg.Logger,
+		// A transport of its own, so Close only drops this fetcher's idle connections.
 		httpClient: &http.Client{
-			Timeout: cfg.HTTPTimeout,
+			Timeout:   cfg.HTTPTimeout,
+			Transport: newJWKSTransport(),
 		},
 		keys: make(map[string]*rsa.PublicKey),
 	}
 	fetcher.fetchCond = sync.NewCond(&fetcher.fetchMu)
 
+	if cfg.Prefetch {
+		ctx, cancel := context.WithCancel(context.Background())
+		fetcher.stopPrefetch = cancel
+		fetcher.prefetchDone = make(chan struct{})
+
+		go fetcher.prefetch(ctx)
+	}
+
 	return fetcher
 }
 
+// prefetch refreshes the key set until the first success or until ctx is canceled.
+func (fetcher *jwksFetcher) prefetch(ctx context.Context) {
+	defer close(fetcher.prefetchDone)
+
+	for {
+		err := fetcher.refresh(ctx)
+		if err == nil {
+			return
+		}
+
+		timer := time.NewTimer(fetcher.retryDelay())
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay returns how long to wait before the next fetch is allowed.
+func (fetcher *jwksFetcher) retryDelay() time.Duration {
+	fetcher.fetchMu.Lock()
+	defer fetcher.fetchMu.Unlock()
+
+	return max(fetcher.nextRetry.Sub(fetcher.clock.Now()), fetcher.backoffMin)
+}
+
+// Ready reports whether a key set has been fetched.
+func (fetcher *jwksFetcher) Ready() bool {
+	fetcher.mu.RLock()
+	defer fetcher.mu.RUnlock()
+
+	return len(fetcher.keys) > 0
+}
+
 // GetKey retrieves a public key by key ID (kid).
 // If the key is not in cache, it will attempt to refresh from the JWKS URL.
 func (fetcher *jwksFetcher) GetKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
@@ -202,11 +274,30 @@ func (fetcher *jwksFetcher) KeyFunc(ctx context.Context) jwt.Keyfunc {
 	}
 }
 
-// Close releases resources. Currently a no-op but included for future use.
+// Close cancels the prefetch loop, including its in-flight fetch, and closes idle
+// connections. It is safe to call more than once; the fetcher keeps serving cached keys
+// and can still fetch on demand afterwards.
 func (fetcher *jwksFetcher) Close() error {
+	fetcher.closeOnce.Do(func() {
+		if fetcher.stopPrefetch != nil {
+			fetcher.stopPrefetch()
+			<-fetcher.prefetchDone
+		}
+
+		fetcher.httpClient.CloseIdleConnections()
+	})
+
 	return nil
 }
 
+func newJWKSTransport() http.RoundTripper {
+	if transport, ok := http.DefaultTransport.(*http.Transport); ok {
+		return transport.Clone()
+	}
+
+	return http.DefaultTransport
+}
+
 // refresh fetches the JWKS from the remote URL.
 // Uses a condition variable to prevent thundering herd.
 func (fetcher *jwksFetcher) refresh(ctx context.Context) error {
@@ -266,15 +357,57 @@ func (fetcher *jwksFetcher) doFetch(ctx context.Context) error {
 	}
 
 	fetcher.mu.Lock()
+	previous := fetcher.keys
 	fetcher.keys = keys
 	fetcher.fetchedAt = fetcher.clock.Now()
 	fetcher.mu.Unlock()
 
 	fetcher.recordFetchSuccess(fetcher.clock.Now().Sub(start))
+	fetcher.recordRotation(ctx, previous, keys)
 
 	return nil
 }
 
+// recordRotation reports kids added or removed compared with the previous key set.
+// The first successful fetch is not a rotation.
+func (fetcher *jwksFetcher) recordRotation(ctx context.Context, previous, current map[string]*rsa.PublicKey) {
+	if len(previous) == 0 {
+		return
+	}
+
+	added := diffKids(current, previous)
+	removed := diffKids(previous, current)
+
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+
+	jwksKeysRotatedTotal.Inc()
+
+	if fetcher.log != nil {
+		fetcher.log.DebugWithContext(ctx, "jwks keys rotated",
+			slog.String("url", fetcher.url),
+			slog.Any("added_kids", added),
+			slog.Any("removed_kids", removed),
+		)
+	}
+}
+
+// diffKids returns the sorted kids present in a but not in b.
+func diffKids(a, b map[string]*rsa.PublicKey) []string {
+	var kids []string
+
+	for kid := range maps.Keys(a) {
+		if _, ok := b[kid]; !ok {
+			kids = append(kids, kid)
+		}
+	}
+
+	slices.Sort(kids)
+
+	return kids
+}
+
 func (fetcher *jwksFetcher) recordFetchSuccess(duration time.Duration) {
 	jwksFetchTotal.WithLabelValues("success").Inc()
 	jwksFetchSeconds.Observe(duration.Seconds())
@@ -1,20 +1,25 @@
 package authjwt
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/base64"
 	"encoding/json"
 	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/logger"
 )
 
 const rsaTestKeyBits = 2048
@@ -122,3 +127,166 @@ func TestJWKSFetcher_Backoff(t *testing.T) {
 
 	require.GreaterOrEqual(t, calls.Load(), int32(2))
 }
+
+func TestJWKSFetcher_RecordsKeyRotation(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, rsaTestKeyBits)
+	require.NoError(t, err)
+
+	newKey, err := rsa.GenerateKey(rand.Reader, rsaTestKeyBits)
+	require.NoError(t, err)
+
+	var rotated atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		body := jwksBody(t, "kid-old", &oldKey.PublicKey)
+		if rotated.Load() {
+			body = jwksBody(t, "kid-new", &newKey.PublicKey)
+		}
+
+		_, werr := w.Write(body)
+		assert.NoError(t, werr)
+	}))
+	t.Cleanup(server.Close)
+
+	var logs bytes.Buffer
+	log, err := logger.New(logger.Configuration{Writer: &logs, Level: logger.DEBUG_LEVEL})
+	require.NoError(t, err)
+
+	clock := &fakeClock{now: time.Now()}
+	fetcher := NewJWKSFetcher(JWKSConfig{
+		URL:         server.URL,
+		CacheTTL:    time.Minute,
+		HTTPTimeout: time.Second,
+		Clock:       clock,
+		Logger:      log,
+	})
+
+	before := testutil.ToFloat64(jwksKeysRotatedTotal)
+
+	_, err = fetcher.GetKey(context.Background(), "kid-old")
+	require.NoError(t, err)
+	assert.InDelta(t, before, testutil.ToFloat64(jwksKeysRotatedTotal), 0, "initial fetch is not a rotation")
+
+	rotated.Store(true)
+	clock.Advance(2 * time.Minute)
+
+	_, err = fetcher.GetKey(context.Background(), "kid-new")
+	require.NoError(t, err)
+	assert.InDelta(t, before+1, testutil.ToFloat64(jwksKeysRotatedTotal), 0)
+
+	assert.Contains(t, logs.String(), "jwks keys rotated")
+	assert.Contains(t, logs.String(), "kid-new")
+	assert.Contains(t, logs.String(), "kid-old")
+}
+
+func TestJWKSFetcher_Prefetch(t *testing.T) {
+	t.Parallel()
+
+	priv, err := rsa.GenerateKey(rand.Reader, rsaTestKeyBits)
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// Fail the first attempt so prefetch has to retry after backoff.
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+		_, werr := w.Write(jwksBody(t, "kid-1", &priv.PublicKey))
+		assert.NoError(t, werr)
+	}))
+	t.Cleanup(server.Close)
+
+	fetcher := NewJWKSFetcher(JWKSConfig{
+		URL:         server.URL,
+		HTTPTimeout: time.Second,
+		BackoffMin:  10 * time.Millisecond,
+		Prefetch:    true,
+	})
+	t.Cleanup(func() { require.NoError(t, fetcher.Close()) })
+
+	require.Eventually(t, fetcher.Ready, 2*time.Second, 5*time.Millisecond)
+
+	key, err := fetcher.GetKey(context.Background(), "kid-1")
+	require.NoError(t, err)
+	require.Equal(t, priv.N, key.N)
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestValidator_ReadyWithoutPrefetch(t *testing.T) {
+	t.Parallel()
+
+	validator, err := NewValidator(ValidatorConfig{
+		JWKSURL:      "http://127.0.0.1:0/jwks.json",
+		SkipIssuer:   true,
+		SkipAudience: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, validator.Close()) })
+
+	require.False(t, validator.Ready())
+}
+
+func TestJWKSFetcher_CloseReleasesResources(t *testing.T) {
+	t.Parallel()
+
+	priv, err := rsa.GenerateKey(rand.Reader, rsaTestKeyBits)
+	require.NoError(t, err)
+
+	var hang atomic.Bool
+
+	var closedConns atomic.Int32
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hang.Load() {
+			<-r.Context().Done()
+
+			return
+		}
+
+		_, werr := w.Write(jwksBody(t, "kid-1", &priv.PublicKey))
+		assert.NoError(t, werr)
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			closedConns.Add(1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+
+	// Idle keep-alive connections are closed.
+	fetcher := NewJWKSFetcher(JWKSConfig{URL: server.URL, HTTPTimeout: time.Minute})
+
+	_, err = fetcher.GetKey(context.Background(), "kid-1")
+	require.NoError(t, err)
+	require.Zero(t, closedConns.Load())
+
+	require.NoError(t, fetcher.Close())
+	require.NoError(t, fetcher.Close())
+	require.Eventually(t, func() bool { return closedConns.Load() == 1 }, 2*time.Second, 5*time.Millisecond)
+
+	// An in-flight prefetch is canceled instead of waiting for the HTTP timeout.
+	hang.Store(true)
+
+	prefetching := NewJWKSFetcher(JWKSConfig{URL: server.URL, HTTPTimeout: time.Minute, Prefetch: true})
+
+	closed := make(chan error, 1)
+
+	go func() { closed <- prefetching.Close() }()
+
+	select {
+	case err := <-closed:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close did not cancel the in-flight prefetch")
+	}
+
+	require.NoError(t, prefetching.Close())
+	require.False(t, prefetching.Ready())
+}
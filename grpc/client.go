@@ -21,6 +21,8 @@ type Client struct {
 	interceptorStreamClientList []grpc.StreamClientInterceptor
 	optionsNewClient            []grpc.DialOption
 	hedgeUnary                  grpc.UnaryClientInterceptor
+	mtls                        *mtlsFiles
+	tlsDefault                  bool
 
 	port int
 	host string
@@ -111,7 +113,18 @@ func (c *Client) GetOptions() []grpc.DialOption {
 
 // withTLS - setup TLS.
 func (c *Client) withTLS() error {
-	c.cfg.SetDefault("GRPC_CLIENT_TLS_ENABLED", false) // gRPC TLS
+	if c.mtls != nil {
+		creds, err := newMTLSCredentials(*c.mtls)
+		if err != nil {
+			return fmt.Errorf("failed to setup mTLS: %w", err)
+		}
+
+		c.optionsNewClient = append(c.optionsNewClient, grpc.WithTransportCredentials(creds))
+
+		return nil
+	}
+
+	c.cfg.SetDefault("GRPC_CLIENT_TLS_ENABLED", c.tlsDefault) // gRPC TLS
 	isEnableTLS := c.cfg.GetBool("GRPC_CLIENT_TLS_ENABLED")
 
 	c.cfg.SetDefault("GRPC_CLIENT_CERT_PATH", "ops/cert/intermediate_ca.pem") // gRPC Client cert
@@ -11,7 +11,9 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/shortlink-org/go-sdk/grpc/authforward"
+	"github.com/shortlink-org/go-sdk/grpc/middleware/deadline"
 	grpc_logger "github.com/shortlink-org/go-sdk/grpc/middleware/logger"
+	"github.com/shortlink-org/go-sdk/grpc/middleware/tenant"
 	"github.com/shortlink-org/go-sdk/logger"
 )
 
@@ -122,15 +124,47 @@ func WithMetrics(prom *prometheus.Registry) Option {
 }
 
 // WithAuthForward adds auth token forwarding interceptors.
-func WithAuthForward() Option {
+// Use authforward.WithMetadataKey to change the forwarded header and
+// authforward.WithEnabled(false) to turn forwarding off.
+func WithAuthForward(opts ...authforward.ClientOption) Option {
 	return func(client *Client) {
 		client.interceptorUnaryClientList = append(
 			client.interceptorUnaryClientList,
-			authforward.UnaryClientInterceptor(),
+			authforward.UnaryClientInterceptor(opts...),
 		)
 		client.interceptorStreamClientList = append(
 			client.interceptorStreamClientList,
-			authforward.StreamClientInterceptor(),
+			authforward.StreamClientInterceptor(opts...),
+		)
+	}
+}
+
+// WithDeadlinePropagation sends the remaining context deadline to servers in
+// the x-deadline-remaining-ms metadata key, for deadline-aware load shedding.
+func WithDeadlinePropagation() Option {
+	return func(client *Client) {
+		client.interceptorUnaryClientList = append(
+			client.interceptorUnaryClientList,
+			deadline.UnaryClientInterceptor(),
+		)
+		client.interceptorStreamClientList = append(
+			client.interceptorStreamClientList,
+			deadline.StreamClientInterceptor(),
+		)
+	}
+}
+
+// WithTenant forwards the tenant id from the call context in the tenant-id
+// metadata key. See the tenant middleware package.
+func WithTenant() Option {
+	return func(client *Client) {
+		client.interceptorUnaryClientList = append(
+			client.interceptorUnaryClientList,
+			tenant.UnaryClientInterceptor(),
+		)
+		client.interceptorStreamClientList = append(
+			client.interceptorStreamClientList,
+			tenant.StreamClientInterceptor(),
 		)
 	}
 }
@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// ErrNoCACertificates is returned when the mTLS CA bundle contains no PEM certificates.
+var ErrNoCACertificates = errors.New("grpc: no CA certificates found")
+
+// mtlsFiles holds the client certificate, key, and CA bundle used for mTLS.
+type mtlsFiles struct {
+	certPath string
+	keyPath  string
+	caPath   string
+}
+
+// WithMTLS enables mutual TLS with a client certificate, for backends outside
+// the service mesh. The arguments are defaults for GRPC_CLIENT_MTLS_CERT_PATH,
+// GRPC_CLIENT_MTLS_KEY_PATH and GRPC_CLIENT_MTLS_CA_PATH; an empty CA path
+// verifies the server against the system roots.
+//
+// mTLS takes precedence over GRPC_CLIENT_TLS_ENABLED.
+func WithMTLS(certPath, keyPath, caPath string) Option {
+	return func(client *Client) {
+		client.cfg.SetDefault("GRPC_CLIENT_MTLS_CERT_PATH", certPath) // gRPC Client certificate
+		client.cfg.SetDefault("GRPC_CLIENT_MTLS_KEY_PATH", keyPath)   // gRPC Client private key
+		client.cfg.SetDefault("GRPC_CLIENT_MTLS_CA_PATH", caPath)     // gRPC server CA bundle
+
+		client.mtls = &mtlsFiles{
+			certPath: client.cfg.GetString("GRPC_CLIENT_MTLS_CERT_PATH"),
+			keyPath:  client.cfg.GetString("GRPC_CLIENT_MTLS_KEY_PATH"),
+			caPath:   client.cfg.GetString("GRPC_CLIENT_MTLS_CA_PATH"),
+		}
+	}
+}
+
+// newMTLSCredentials builds transport credentials presenting the client certificate
+// and trusting the CA bundle at caPath (system roots when empty).
+func newMTLSCredentials(files mtlsFiles) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(files.certPath, files.keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if files.caPath != "" {
+		pool, err := loadCertPool(files.caPath)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// loadCertPool reads a PEM CA bundle into a certificate pool.
+func loadCertPool(path string) (*x509.CertPool, error) {
+	caPEM, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("%w: %s", ErrNoCACertificates, path)
+	}
+
+	return pool, nil
+}
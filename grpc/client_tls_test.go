@@ -0,0 +1,135 @@
+package grpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/config"
+)
+
+// testCA is a throwaway certificate authority for TLS tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCA(t *testing.T) testCA {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	return testCA{cert: caCert, key: caKey, der: caDER}
+}
+
+// issue signs a leaf certificate for template and writes it with its key to dir/name.pem and dir/name-key.pem.
+func (ca testCA) issue(t *testing.T, dir, name string, template *x509.Certificate) (certPath, keyPath string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPath = filepath.Join(dir, name+".pem")
+	keyPath = filepath.Join(dir, name+"-key.pem")
+
+	writePEM(t, certPath, "CERTIFICATE", der)
+	writePEM(t, keyPath, "EC PRIVATE KEY", keyDER)
+
+	return certPath, keyPath
+}
+
+// writeTestCerts generates a CA and a client certificate signed by it.
+func writeTestCerts(t *testing.T) mtlsFiles {
+	t.Helper()
+
+	dir := t.TempDir()
+	ca := newTestCA(t)
+
+	files := mtlsFiles{caPath: filepath.Join(dir, "ca.pem")}
+	writePEM(t, files.caPath, "CERTIFICATE", ca.der)
+
+	files.certPath, files.keyPath = ca.issue(t, dir, "client", &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "test-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	return files
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+}
+
+func TestNewMTLSCredentials(t *testing.T) {
+	files := writeTestCerts(t)
+
+	creds, err := newMTLSCredentials(files)
+	require.NoError(t, err)
+	assert.Equal(t, "tls", creds.Info().SecurityProtocol)
+}
+
+func TestNewMTLSCredentials_InvalidCA(t *testing.T) {
+	files := writeTestCerts(t)
+	files.caPath = files.keyPath
+
+	_, err := newMTLSCredentials(files)
+	require.ErrorIs(t, err, ErrNoCACertificates)
+}
+
+func TestSetClientConfig_WithMTLS(t *testing.T) {
+	files := writeTestCerts(t)
+
+	t.Setenv("GRPC_CLIENT_MTLS_CA_PATH", files.caPath)
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	// Env overrides the CA default passed to WithMTLS.
+	client, err := SetClientConfig(cfg, WithMTLS(files.certPath, files.keyPath, "/does/not/exist"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, client.GetOptions())
+
+	_, err = SetClientConfig(cfg, WithMTLS(files.certPath, filepath.Join(t.TempDir(), "missing.pem"), ""))
+	require.Error(t, err)
+}
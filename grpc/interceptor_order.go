@@ -0,0 +1,143 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+// Server interceptor names accepted by GRPC_SERVER_INTERCEPTOR_ORDER.
+//
+// The first name is the outermost interceptor: it sees the request first and
+// the response last. Ordering matters:
+//
+//   - recovery: last by default, so only the handler is guarded and the other
+//     interceptors observe the recovered codes.Internal error. Put it first to
+//     also catch panics raised by interceptors.
+//   - metrics: after the auth interceptors by default, so every request that
+//     reaches it is counted with its final code. Put it before auth_headers
+//     or auth_jwt to count rejected requests too.
+//   - logger: first by default, so it logs every request, including ones
+//     rejected further down the chain.
+//   - auth_headers/auth_jwt before auth_forward: auth_forward only forwards
+//     tokens the previous interceptors accepted.
+const (
+	InterceptorLogger      = "logger"
+	InterceptorVersion     = "version"
+	InterceptorAuthHeaders = "auth_headers"
+	InterceptorAuthJWT     = "auth_jwt"
+	InterceptorAuthForward = "auth_forward"
+	InterceptorPprof       = "pprof"
+	InterceptorFlightTrace = "flight_trace"
+	InterceptorMetrics     = "metrics"
+	// InterceptorStreamMetrics only intercepts streams.
+	InterceptorStreamMetrics = "stream_metrics"
+	InterceptorRecovery      = "recovery"
+)
+
+// defaultInterceptorOrder is the order interceptors are chained in when
+// GRPC_SERVER_INTERCEPTOR_ORDER is empty.
+var defaultInterceptorOrder = []string{
+	InterceptorLogger,
+	InterceptorVersion,
+	InterceptorAuthHeaders,
+	InterceptorAuthJWT,
+	InterceptorAuthForward,
+	InterceptorPprof,
+	InterceptorFlightTrace,
+	InterceptorMetrics,
+	InterceptorStreamMetrics,
+	InterceptorRecovery,
+}
+
+var (
+	// ErrUnknownInterceptor is returned when the interceptor order names an unknown interceptor.
+	ErrUnknownInterceptor = errors.New("grpc: unknown interceptor")
+	// ErrDuplicateInterceptor is returned when the interceptor order names an interceptor twice.
+	ErrDuplicateInterceptor = errors.New("grpc: duplicate interceptor")
+)
+
+// namedInterceptor pairs the unary and stream variants of one server interceptor.
+type namedInterceptor struct {
+	name   string
+	unary  grpc.UnaryServerInterceptor
+	stream grpc.StreamServerInterceptor
+}
+
+// addInterceptor registers an enabled interceptor; the chain is built by applyInterceptorOrder.
+func (s *server) addInterceptor(name string, unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor) {
+	s.interceptors = append(s.interceptors, namedInterceptor{name: name, unary: unary, stream: stream})
+}
+
+// applyInterceptorOrder fills the unary and stream chains from the registered
+// interceptors. Names listed in order come first; enabled interceptors that are
+// not listed follow in the default order. Listed but disabled interceptors are skipped.
+func (s *server) applyInterceptorOrder(order []string) error {
+	resolved, err := resolveInterceptorOrder(order)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range resolved {
+		idx := slices.IndexFunc(s.interceptors, func(i namedInterceptor) bool { return i.name == name })
+		if idx < 0 {
+			continue
+		}
+
+		// Interceptors may cover only one RPC kind.
+		if unary := s.interceptors[idx].unary; unary != nil {
+			s.interceptorUnaryServerList = append(s.interceptorUnaryServerList, unary)
+		}
+
+		if stream := s.interceptors[idx].stream; stream != nil {
+			s.interceptorStreamServerList = append(s.interceptorStreamServerList, stream)
+		}
+		s.interceptorOrder = append(s.interceptorOrder, name)
+	}
+
+	return nil
+}
+
+// resolveInterceptorOrder validates order and completes it with the missing default names.
+func resolveInterceptorOrder(order []string) ([]string, error) {
+	resolved := make([]string, 0, len(defaultInterceptorOrder))
+
+	for _, name := range order {
+		if !slices.Contains(defaultInterceptorOrder, name) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownInterceptor, name)
+		}
+
+		if slices.Contains(resolved, name) {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateInterceptor, name)
+		}
+
+		resolved = append(resolved, name)
+	}
+
+	for _, name := range defaultInterceptorOrder {
+		if !slices.Contains(resolved, name) {
+			resolved = append(resolved, name)
+		}
+	}
+
+	return resolved, nil
+}
+
+// parseInterceptorOrder accepts both list values and a comma-separated string.
+func parseInterceptorOrder(values []string) []string {
+	order := make([]string, 0, len(values))
+
+	for _, value := range values {
+		for name := range strings.SplitSeq(value, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name != "" {
+				order = append(order, name)
+			}
+		}
+	}
+
+	return order
+}
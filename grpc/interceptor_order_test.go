@@ -0,0 +1,173 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/shortlink-org/go-sdk/config"
+	"github.com/shortlink-org/go-sdk/logger"
+)
+
+func TestSetServerConfigInterceptorOrder(t *testing.T) {
+	log, err := logger.New(logger.Configuration{Writer: io.Discard})
+	require.NoError(t, err)
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+	cfg.Set("GRPC_SERVER_INTERCEPTOR_ORDER", "recovery, metrics")
+
+	srv, err := setServerConfig(log, nil, prometheus.NewRegistry(), nil, cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		InterceptorRecovery,
+		InterceptorMetrics,
+		InterceptorLogger,
+		InterceptorAuthHeaders,
+		InterceptorAuthForward,
+		InterceptorPprof,
+		InterceptorFlightTrace,
+		InterceptorStreamMetrics,
+	}, srv.interceptorOrder)
+	// stream_metrics has no unary variant.
+	assert.Len(t, srv.interceptorUnaryServerList, len(srv.interceptorOrder)-1)
+	assert.Len(t, srv.interceptorStreamServerList, len(srv.interceptorOrder))
+}
+
+func TestApplyInterceptorOrderChainsInConfiguredOrder(t *testing.T) {
+	var calls []string
+
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			calls = append(calls, name)
+
+			return handler(ctx, req)
+		}
+	}
+
+	srv := &server{}
+	srv.addInterceptor(InterceptorLogger, record(InterceptorLogger), nil)
+	srv.addInterceptor(InterceptorMetrics, record(InterceptorMetrics), nil)
+	srv.addInterceptor(InterceptorRecovery, record(InterceptorRecovery), nil)
+
+	require.NoError(t, srv.applyInterceptorOrder([]string{InterceptorRecovery, InterceptorAuthJWT, InterceptorMetrics}))
+
+	// Run the chain the way grpc.ChainUnaryInterceptor does: first is outermost.
+	handler := grpc.UnaryHandler(func(context.Context, any) (any, error) { return "ok", nil })
+	for i := len(srv.interceptorUnaryServerList) - 1; i >= 0; i-- {
+		interceptor, next := srv.interceptorUnaryServerList[i], handler
+		handler = func(ctx context.Context, req any) (any, error) {
+			return interceptor(ctx, req, &grpc.UnaryServerInfo{}, next)
+		}
+	}
+
+	_, err := handler(context.Background(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{InterceptorRecovery, InterceptorMetrics, InterceptorLogger}, calls)
+	assert.Equal(t, calls, srv.interceptorOrder)
+}
+
+func TestApplyInterceptorOrderRejectsInvalidNames(t *testing.T) {
+	srv := &server{}
+
+	require.ErrorIs(t, srv.applyInterceptorOrder([]string{"tracing"}), ErrUnknownInterceptor)
+	require.ErrorIs(t, srv.applyInterceptorOrder([]string{InterceptorLogger, InterceptorLogger}), ErrDuplicateInterceptor)
+}
+
+func TestParseInterceptorOrder(t *testing.T) {
+	assert.Equal(t,
+		[]string{InterceptorRecovery, InterceptorLogger, InterceptorMetrics},
+		parseInterceptorOrder([]string{" Recovery,logger ", "", "metrics"}),
+	)
+}
+
+// syncBuffer is a bytes.Buffer safe for the server goroutines writing logs.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestSetServerConfigLogsSessionUser(t *testing.T) {
+	var buf syncBuffer
+
+	log, err := logger.New(logger.Configuration{Writer: &buf, Level: logger.DEBUG_LEVEL})
+	require.NoError(t, err)
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	// Default order: the logger runs before the session interceptor resolves the user.
+	srv, err := setServerConfig(log, nil, nil, nil, cfg)
+	require.NoError(t, err)
+
+	// A bare service: the health service is on the session interceptor's skip list.
+	server := grpc.NewServer(srv.optionsNewServer...)
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.v1.Users",
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Get",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				var req healthpb.HealthCheckRequest
+				if err := dec(&req); err != nil {
+					return nil, err
+				}
+
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/test.v1.Users/Get"}
+
+				return interceptor(ctx, &req, info, func(context.Context, any) (any, error) {
+					return nil, status.Error(codes.NotFound, "user not found")
+				})
+			},
+		}},
+	}, struct{}{})
+
+	lis, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() { _ = server.Serve(lis) }()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ctx := metadata.AppendToOutgoingContext(t.Context(), "x-user-id", "user-42")
+
+	// NotFound is logged at DEBUG.
+	err = conn.Invoke(ctx, "/test.v1.Users/Get", &healthpb.HealthCheckRequest{}, &healthpb.HealthCheckResponse{})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	assert.Contains(t, buf.String(), `"enduser.id":"user-42"`)
+}
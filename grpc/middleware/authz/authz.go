@@ -0,0 +1,128 @@
+// Package authz provides gRPC interceptors that authorize calls against
+// declarative per-method role and scope policies.
+package authz
+
+import (
+	"context"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Decision is the outcome of a policy evaluation.
+type Decision string
+
+const (
+	DecisionAllow Decision = "allow"
+	DecisionDeny  Decision = "deny"
+)
+
+var authzDecisionsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "grpc_authz_decisions_total",
+		Help: "Total authorization decisions made by gRPC interceptors",
+	},
+	[]string{"method", "decision"},
+)
+
+// Policy lists what a caller needs to invoke a method.
+// The caller must hold at least one of Roles (when set) and every one of Scopes.
+// A zero Policy allows every caller, including unauthenticated ones.
+type Policy struct {
+	Roles  []string
+	Scopes []string
+}
+
+// Config configures the authorization interceptors.
+type Config struct {
+	// Methods maps full method names ("/pkg.Service/Method") to their policy.
+	Methods map[string]Policy
+	// Default is applied to methods missing from Methods.
+	// Default: DecisionDeny.
+	Default Decision
+}
+
+// Authorize evaluates the policy for method against the claims in ctx and
+// returns a codes.PermissionDenied status when the call is not allowed.
+func Authorize(ctx context.Context, method string, cfg Config) error {
+	decision, reason := evaluate(ctx, method, cfg)
+	authzDecisionsTotal.WithLabelValues(method, string(decision)).Inc()
+
+	if decision == DecisionAllow {
+		return nil
+	}
+
+	return status.Errorf(codes.PermissionDenied, "authz: %s", reason)
+}
+
+// UnaryServerInterceptor authorizes unary calls.
+func UnaryServerInterceptor(cfg Config) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if err := Authorize(ctx, info.FullMethod, cfg); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor authorizes streaming calls once, before the handler runs.
+func StreamServerInterceptor(cfg Config) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := Authorize(stream.Context(), info.FullMethod, cfg); err != nil {
+			return err
+		}
+
+		return handler(srv, stream)
+	}
+}
+
+func evaluate(ctx context.Context, method string, cfg Config) (Decision, string) {
+	policy, ok := cfg.Methods[method]
+	if !ok {
+		if cfg.Default == DecisionAllow {
+			return DecisionAllow, ""
+		}
+
+		return DecisionDeny, "method " + method + " has no policy"
+	}
+
+	if len(policy.Roles) == 0 && len(policy.Scopes) == 0 {
+		return DecisionAllow, ""
+	}
+
+	metadata, ok := claimsMetadata(ctx)
+	if !ok {
+		return DecisionDeny, "no claims in context"
+	}
+
+	if len(policy.Roles) > 0 {
+		roles := Roles(metadata)
+		if !slices.ContainsFunc(policy.Roles, func(role string) bool { return slices.Contains(roles, role) }) {
+			return DecisionDeny, "missing one of roles " + joinQuoted(policy.Roles)
+		}
+	}
+
+	scopes := Scopes(metadata)
+	for _, scope := range policy.Scopes {
+		if !slices.Contains(scopes, scope) {
+			return DecisionDeny, "missing scope \"" + scope + "\""
+		}
+	}
+
+	return DecisionAllow, ""
+}
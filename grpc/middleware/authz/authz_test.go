@@ -0,0 +1,167 @@
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/shortlink-org/go-sdk/auth/session"
+	"github.com/shortlink-org/go-sdk/grpc/authjwt"
+)
+
+const (
+	deleteMethod = "/links.v1.LinkService/Delete"
+	listMethod   = "/links.v1.LinkService/List"
+)
+
+func testConfig(defaultDecision Decision) Config {
+	return Config{
+		Methods: map[string]Policy{
+			deleteMethod: {Roles: []string{"admin", "owner"}, Scopes: []string{"links:write"}},
+			listMethod:   {},
+		},
+		Default: defaultDecision,
+	}
+}
+
+func callUnary(ctx context.Context, cfg Config, method string) (bool, error) {
+	called := false
+	handler := func(context.Context, any) (any, error) {
+		called = true
+
+		return "ok", nil
+	}
+
+	_, err := UnaryServerInterceptor(cfg)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+
+	return called, err
+}
+
+func TestUnaryServerInterceptor_Allow(t *testing.T) {
+	ctx := authjwt.WithClaims(context.Background(), &authjwt.Claims{
+		Metadata: map[string]any{
+			"roles": []any{"viewer", "owner"},
+			"scope": "links:read links:write",
+		},
+	})
+
+	before := testutil.ToFloat64(authzDecisionsTotal.WithLabelValues(deleteMethod, string(DecisionAllow)))
+
+	called, err := callUnary(ctx, testConfig(DecisionDeny), deleteMethod)
+	require.NoError(t, err)
+	assert.True(t, called)
+	assert.InDelta(t, before+1, testutil.ToFloat64(authzDecisionsTotal.WithLabelValues(deleteMethod, string(DecisionAllow))), 0)
+}
+
+func TestUnaryServerInterceptor_AllowFromSessionClaims(t *testing.T) {
+	ctx := session.WithClaims(context.Background(), &session.Claims{
+		Metadata: map[string]any{"roles": "admin", "scopes": []string{"links:write"}},
+	})
+
+	called, err := callUnary(ctx, testConfig(DecisionDeny), deleteMethod)
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestUnaryServerInterceptor_Deny(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		reason string
+	}{
+		{
+			name:   "no claims",
+			ctx:    context.Background(),
+			reason: "no claims in context",
+		},
+		{
+			name: "missing role",
+			ctx: authjwt.WithClaims(context.Background(), &authjwt.Claims{
+				Metadata: map[string]any{"roles": []any{"viewer"}, "scope": "links:write"},
+			}),
+			reason: `missing one of roles "admin", "owner"`,
+		},
+		{
+			name: "missing scope",
+			ctx: authjwt.WithClaims(context.Background(), &authjwt.Claims{
+				Metadata: map[string]any{"roles": []any{"admin"}, "scope": "links:read"},
+			}),
+			reason: `missing scope "links:write"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := testutil.ToFloat64(authzDecisionsTotal.WithLabelValues(deleteMethod, string(DecisionDeny)))
+
+			called, err := callUnary(tt.ctx, testConfig(DecisionAllow), deleteMethod)
+			require.Error(t, err)
+			assert.False(t, called)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+			assert.Contains(t, status.Convert(err).Message(), tt.reason)
+			assert.InDelta(t, before+1, testutil.ToFloat64(authzDecisionsTotal.WithLabelValues(deleteMethod, string(DecisionDeny))), 0)
+		})
+	}
+}
+
+func TestUnaryServerInterceptor_DefaultPolicy(t *testing.T) {
+	const unlisted = "/links.v1.LinkService/Export"
+
+	called, err := callUnary(context.Background(), testConfig(DecisionAllow), unlisted)
+	require.NoError(t, err)
+	assert.True(t, called)
+
+	called, err = callUnary(context.Background(), testConfig(DecisionDeny), unlisted)
+	require.Error(t, err)
+	assert.False(t, called)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Zero Default denies.
+	_, err = callUnary(context.Background(), testConfig(""), unlisted)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestUnaryServerInterceptor_EmptyPolicyAllowsAnonymous(t *testing.T) {
+	called, err := callUnary(context.Background(), testConfig(DecisionDeny), listMethod)
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context //nolint:containedctx // test stream
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	interceptor := StreamServerInterceptor(testConfig(DecisionDeny))
+	info := &grpc.StreamServerInfo{FullMethod: deleteMethod}
+
+	called := false
+	handler := func(any, grpc.ServerStream) error {
+		called = true
+
+		return nil
+	}
+
+	err := interceptor(nil, &testServerStream{ctx: context.Background()}, info, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.False(t, called)
+
+	ctx := authjwt.WithClaims(context.Background(), &authjwt.Claims{
+		Metadata: map[string]any{"roles": []any{"admin"}, "scopes": []any{"links:write"}},
+	})
+
+	require.NoError(t, interceptor(nil, &testServerStream{ctx: ctx}, info, handler))
+	assert.True(t, called)
+}
@@ -0,0 +1,77 @@
+package authz
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shortlink-org/go-sdk/auth/session"
+	"github.com/shortlink-org/go-sdk/grpc/authjwt"
+)
+
+const (
+	rolesClaim  = "roles"
+	scopesClaim = "scopes"
+	scopeClaim  = "scope"
+)
+
+// claimsMetadata returns the identity metadata from authjwt claims, falling back to session claims.
+func claimsMetadata(ctx context.Context) (map[string]any, bool) {
+	if claims := authjwt.ClaimsFromContext(ctx); claims != nil {
+		return claims.Metadata, true
+	}
+
+	if claims, err := session.GetClaims(ctx); err == nil && claims != nil {
+		return claims.Metadata, true
+	}
+
+	return nil, false
+}
+
+// Roles reads the "roles" entry of identity metadata.
+// Both JSON arrays and comma-separated strings are accepted.
+func Roles(metadata map[string]any) []string {
+	return stringList(metadata[rolesClaim], ",")
+}
+
+// Scopes reads the "scopes" entry of identity metadata, or the OAuth-style
+// space-separated "scope" entry when "scopes" is absent.
+func Scopes(metadata map[string]any) []string {
+	if scopes, ok := metadata[scopesClaim]; ok {
+		return stringList(scopes, " ")
+	}
+
+	return stringList(metadata[scopeClaim], " ")
+}
+
+func stringList(value any, sep string) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []any:
+		out := make([]string, 0, len(v))
+
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+
+		return out
+	case string:
+		var out []string
+
+		for part := range strings.SplitSeq(v, sep) {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+
+		return out
+	default:
+		return nil
+	}
+}
+
+func joinQuoted(values []string) string {
+	return "\"" + strings.Join(values, "\", \"") + "\""
+}
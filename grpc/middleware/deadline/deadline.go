@@ -0,0 +1,84 @@
+// Package deadline propagates the caller's remaining deadline budget in gRPC
+// metadata so downstream servers can shed requests that cannot finish in time.
+package deadline
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey carries the remaining deadline budget in milliseconds.
+const MetadataKey = "x-deadline-remaining-ms"
+
+// UnaryClientInterceptor sets MetadataKey when the call context has a deadline.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		conn *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(withRemaining(ctx), method, req, reply, conn, opts...)
+	}
+}
+
+// StreamClientInterceptor sets MetadataKey when the stream context has a deadline.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		conn *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		return streamer(withRemaining(ctx), desc, conn, method, opts...)
+	}
+}
+
+// RemainingFromIncoming returns the budget propagated by the caller.
+// The second result is false when the caller sent no (or a malformed) budget.
+func RemainingFromIncoming(ctx context.Context) (time.Duration, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, false
+	}
+
+	values := md.Get(MetadataKey)
+	if len(values) != 1 {
+		return 0, false
+	}
+
+	ms, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil || ms < 0 {
+		return 0, false
+	}
+
+	return time.Duration(ms) * time.Millisecond, true
+}
+
+func withRemaining(ctx context.Context) context.Context {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return ctx
+	}
+
+	remaining := max(time.Until(deadline).Milliseconds(), 0)
+
+	md, exists := metadata.FromOutgoingContext(ctx)
+	if !exists {
+		md = metadata.MD{}
+	}
+
+	// Set (not append) so retries and multi-hop calls carry a single, fresh value.
+	md = md.Copy()
+	md.Set(MetadataKey, strconv.FormatInt(remaining, 10))
+
+	return metadata.NewOutgoingContext(ctx, md)
+}
@@ -0,0 +1,71 @@
+package deadline
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func captureOutgoing(t *testing.T, ctx context.Context) metadata.MD {
+	t.Helper()
+
+	var got metadata.MD
+
+	err := UnaryClientInterceptor()(ctx, "/test.Service/Method", nil, nil, nil,
+		func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			got, _ = metadata.FromOutgoingContext(ctx)
+
+			return nil
+		},
+	)
+	require.NoError(t, err)
+
+	return got
+}
+
+func TestUnaryClientInterceptor_PropagatesRemainingBudget(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	ctx = metadata.AppendToOutgoingContext(ctx, MetadataKey, "999999")
+
+	values := captureOutgoing(t, ctx).Get(MetadataKey)
+	require.Len(t, values, 1)
+
+	ms, err := strconv.ParseInt(values[0], 10, 64)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, ms, int64(2000))
+	assert.Greater(t, ms, int64(1500))
+}
+
+func TestUnaryClientInterceptor_NoDeadline(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, captureOutgoing(t, context.Background()).Get(MetadataKey))
+}
+
+func TestRemainingFromIncoming(t *testing.T) {
+	t.Parallel()
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "1500"))
+
+	remaining, ok := RemainingFromIncoming(ctx)
+	require.True(t, ok)
+	assert.Equal(t, 1500*time.Millisecond, remaining)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "soon"))
+
+	_, ok = RemainingFromIncoming(ctx)
+	assert.False(t, ok)
+
+	_, ok = RemainingFromIncoming(context.Background())
+	assert.False(t, ok)
+}
@@ -6,7 +6,9 @@ package flight_trace
 import (
 	"context"
 	"log/slog"
+	"math/rand/v2"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,29 +27,34 @@ const debugTraceKey = "X-DEBUG-TRACE"
 
 // UnaryServerInterceptor records Flight Recorder dumps based on conditions:
 // - Incoming metadata contains "X-DEBUG-TRACE: true"
-// - The request latency exceeds FLIGHT_TRACE_LATENCY_THRESHOLD.
+// - The call is sampled (see sampler) and its latency exceeds FLIGHT_TRACE_LATENCY_THRESHOLD.
+//
+// The FLIGHT_TRACE_* settings are read once, when the interceptor is created.
 func UnaryServerInterceptor(
 	flightRecorder *flight_trace.Recorder,
 	log logger.Logger,
 	cfg *config.Config,
 ) grpc.UnaryServerInterceptor {
-	cfg.SetDefault("FLIGHT_TRACE_LATENCY_THRESHOLD", "1s")
+	setDefaults(cfg)
+
+	sampling := newSampler(cfg)
+	threshold := cfg.GetDuration("FLIGHT_TRACE_LATENCY_THRESHOLD")
 
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 		if flightRecorder == nil {
 			return handler(ctx, req)
 		}
 
+		debug := debugRequested(ctx)
+		if !debug && !sampling.sampled(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
 		start := time.Now()
 		resp, err := handler(ctx, req)
 		duration := time.Since(start)
 
-		md, _ := metadata.FromIncomingContext(ctx)
-		vals := md.Get(debugTraceKey)
-		shouldDump := len(vals) > 0 && vals[0] == "true"
-
-		threshold := cfg.GetDuration("FLIGHT_TRACE_LATENCY_THRESHOLD")
-		shouldDump = shouldDump || duration > threshold
+		shouldDump := debug || duration > threshold
 
 		if shouldDump {
 			fileName := "grpc-" + uuid.NewString() + ".out"
@@ -78,23 +85,26 @@ func StreamServerInterceptor(
 	log logger.Logger,
 	cfg *config.Config,
 ) grpc.StreamServerInterceptor {
-	cfg.SetDefault("FLIGHT_TRACE_LATENCY_THRESHOLD", "1s")
+	setDefaults(cfg)
+
+	sampling := newSampler(cfg)
+	threshold := cfg.GetDuration("FLIGHT_TRACE_LATENCY_THRESHOLD")
 
 	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		if flightRecorder == nil {
 			return handler(srv, stream)
 		}
 
+		debug := debugRequested(stream.Context())
+		if !debug && !sampling.sampled(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
 		start := time.Now()
 		err := handler(srv, stream)
 		duration := time.Since(start)
 
-		md, _ := metadata.FromIncomingContext(stream.Context())
-		vals := md.Get(debugTraceKey)
-		shouldDump := len(vals) > 0 && vals[0] == "true"
-
-		threshold := cfg.GetDuration("FLIGHT_TRACE_LATENCY_THRESHOLD")
-		shouldDump = shouldDump || duration > threshold
+		shouldDump := debug || duration > threshold
 
 		if shouldDump {
 			fileName := "grpc-" + uuid.NewString() + ".out"
@@ -118,3 +128,50 @@ func StreamServerInterceptor(
 		return err
 	}
 }
+
+func setDefaults(cfg *config.Config) {
+	cfg.SetDefault("FLIGHT_TRACE_LATENCY_THRESHOLD", "1s")
+	cfg.SetDefault("FLIGHT_TRACE_SAMPLE_RATE", 1.0) // Fraction of calls checked against the latency threshold
+	cfg.SetDefault("FLIGHT_TRACE_METHODS", "")      // Method prefixes always checked, e.g. "/links.v1.LinkService/"
+}
+
+// debugRequested reports whether the caller asked for a dump with X-DEBUG-TRACE.
+func debugRequested(ctx context.Context) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	vals := md.Get(debugTraceKey)
+
+	return len(vals) > 0 && vals[0] == "true"
+}
+
+// sampler decides which calls are checked against the latency threshold:
+// methods matching a FLIGHT_TRACE_METHODS prefix always are, others with
+// probability FLIGHT_TRACE_SAMPLE_RATE.
+type sampler struct {
+	methods []string
+	rate    float64
+}
+
+func newSampler(cfg *config.Config) sampler {
+	return sampler{
+		methods: cfg.GetStringSliceCSV("FLIGHT_TRACE_METHODS"),
+		rate:    cfg.GetFloat64("FLIGHT_TRACE_SAMPLE_RATE"),
+	}
+}
+
+// sampled reports whether a call to method is checked against the latency threshold.
+func (s sampler) sampled(method string) bool {
+	for _, prefix := range s.methods {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+
+	switch {
+	case s.rate >= 1:
+		return true
+	case s.rate <= 0:
+		return false
+	default:
+		return rand.Float64() < s.rate //nolint:gosec // sampling, not security
+	}
+}
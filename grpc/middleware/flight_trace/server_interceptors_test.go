@@ -0,0 +1,129 @@
+package flight_trace
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/shortlink-org/go-sdk/config"
+	"github.com/shortlink-org/go-sdk/flight_trace"
+	"github.com/shortlink-org/go-sdk/logger"
+)
+
+type countingSink struct {
+	mu    sync.Mutex
+	dumps int
+}
+
+func (s *countingSink) Write(context.Context, string, []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dumps++
+
+	return nil
+}
+
+func (s *countingSink) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.dumps
+}
+
+// Only one flight recorder may be active per process, so tests share it.
+var (
+	testSink     = &countingSink{}
+	testRecorder = sync.OnceValues(func() (*flight_trace.Recorder, error) {
+		cfg, err := config.New()
+		if err != nil {
+			return nil, err
+		}
+
+		return flight_trace.New(context.Background(), cfg, flight_trace.WithSink(testSink))
+	})
+)
+
+func TestUnaryServerInterceptor_SampleRate(t *testing.T) {
+	ctx := context.Background()
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	log, err := logger.New(logger.Configuration{Writer: io.Discard})
+	require.NoError(t, err)
+
+	rec, err := testRecorder()
+	require.NoError(t, err)
+	require.NotNil(t, rec)
+
+	sink := testSink
+
+	// Every call exceeds the threshold, so sampling alone decides.
+	cfg.Set("FLIGHT_TRACE_LATENCY_THRESHOLD", "0s")
+
+	const calls = 5
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.TestService/Get"}
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	t.Run("zero records nothing", func(t *testing.T) {
+		cfg.Set("FLIGHT_TRACE_SAMPLE_RATE", 0.0)
+		interceptor := UnaryServerInterceptor(rec, log, cfg)
+		before := sink.count()
+
+		for range calls {
+			_, err := interceptor(ctx, nil, info, handler)
+			require.NoError(t, err)
+		}
+
+		require.Never(t, func() bool { return sink.count() != before }, 200*time.Millisecond, 10*time.Millisecond)
+	})
+
+	t.Run("one records all", func(t *testing.T) {
+		cfg.Set("FLIGHT_TRACE_SAMPLE_RATE", 1.0)
+		interceptor := UnaryServerInterceptor(rec, log, cfg)
+		before := sink.count()
+
+		// Dumps are async and the recorder serializes snapshots; wait for each.
+		for i := range calls {
+			_, err := interceptor(ctx, nil, info, handler)
+			require.NoError(t, err)
+			require.Eventually(t, func() bool { return sink.count() == before+i+1 }, 5*time.Second, 10*time.Millisecond)
+		}
+	})
+
+	t.Run("listed method bypasses sample rate", func(t *testing.T) {
+		cfg.Set("FLIGHT_TRACE_SAMPLE_RATE", 0.0)
+		cfg.Set("FLIGHT_TRACE_METHODS", "/test.v1.TestService/")
+		t.Cleanup(func() { cfg.Set("FLIGHT_TRACE_METHODS", "") })
+
+		interceptor := UnaryServerInterceptor(rec, log, cfg)
+		before := sink.count()
+
+		_, err := interceptor(ctx, nil, info, handler)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool { return sink.count() == before+1 }, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestSampler_ReadsConfigOnce(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	cfg.Set("FLIGHT_TRACE_SAMPLE_RATE", 0.0)
+	cfg.Set("FLIGHT_TRACE_METHODS", "/test.v1.TestService/")
+
+	sampling := newSampler(cfg)
+
+	cfg.Set("FLIGHT_TRACE_SAMPLE_RATE", 1.0)
+	cfg.Set("FLIGHT_TRACE_METHODS", "")
+
+	require.True(t, sampling.sampled("/test.v1.TestService/Get"))
+	require.False(t, sampling.sampled("/test.v1.OtherService/Get"))
+}
@@ -19,6 +19,11 @@ import (
 // UnaryClientInterceptor returns a new unary client interceptor that optionally
 // logs the execution of external gRPC calls.
 func UnaryClientInterceptor(log logger.Logger) grpc.UnaryClientInterceptor {
+	return UnaryClientInterceptorWithConfig(log, InterceptorConfig{})
+}
+
+// UnaryClientInterceptorWithConfig is like UnaryClientInterceptor with configurable levels and slow-call threshold.
+func UnaryClientInterceptorWithConfig(log logger.Logger, cfg InterceptorConfig) grpc.UnaryClientInterceptor {
 	return func(
 		ctx context.Context,
 		method string,
@@ -48,9 +53,7 @@ func UnaryClientInterceptor(log logger.Logger) grpc.UnaryClientInterceptor {
 			slog.Int64("duration (mks)", duration.Microseconds()),
 		}
 
-		if err != nil {
-			printLog(ctx, log, err, fields...)
-		}
+		cfg.printLog(ctx, log, err, duration, fields...)
 
 		return err
 	}
@@ -59,6 +62,11 @@ func UnaryClientInterceptor(log logger.Logger) grpc.UnaryClientInterceptor {
 // StreamClientInterceptor returns a new streaming client interceptor that optionally
 // logs the execution of external gRPC calls.
 func StreamClientInterceptor(log logger.Logger) grpc.StreamClientInterceptor {
+	return StreamClientInterceptorWithConfig(log, InterceptorConfig{})
+}
+
+// StreamClientInterceptorWithConfig is like StreamClientInterceptor with configurable levels and slow-call threshold.
+func StreamClientInterceptorWithConfig(log logger.Logger, cfg InterceptorConfig) grpc.StreamClientInterceptor {
 	return func(
 		ctx context.Context,
 		desc *grpc.StreamDesc,
@@ -75,9 +83,8 @@ func StreamClientInterceptor(log logger.Logger) grpc.StreamClientInterceptor {
 			slog.String("code", status.Code(err).String()),
 		}
 
-		if err != nil {
-			printLog(ctx, log, err, fields...)
-		}
+		// Only stream setup is observed here, so the slow threshold does not apply.
+		cfg.printLog(ctx, log, err, 0, fields...)
 
 		return clientStream, err
 	}
@@ -4,17 +4,130 @@ package grpc_logger
 import (
 	"context"
 	"log/slog"
+	"strings"
+	"time"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
+	"github.com/shortlink-org/go-sdk/auth/userid"
 	"github.com/shortlink-org/go-sdk/logger"
+	"github.com/shortlink-org/go-sdk/logger/tracer"
 )
 
-func printLog(ctx context.Context, log logger.Logger, err error, fields ...slog.Attr) {
-	switch status.Code(err) {
+// defaultMetadataKeys are the incoming metadata keys logged when InterceptorConfig.MetadataKeys is nil.
+var defaultMetadataKeys = []string{"user-agent"}
+
+// InterceptorConfig controls the level RPCs are logged at.
+// The zero value keeps the default behavior: successful calls are not logged
+// and failures use the built-in code → level mapping.
+type InterceptorConfig struct {
+	// Levels overrides the level per status code; codes not listed use the default mapping.
+	// codes.OK is only logged when listed here, e.g. {codes.OK: slog.LevelDebug}.
+	Levels map[codes.Code]slog.Level
+	// SlowThreshold logs calls that take at least this long at WARN or above,
+	// including successful ones. Zero disables slow-call logging.
+	SlowThreshold time.Duration
+	// LogPayloads logs every message sent or received on server streams at DEBUG:
+	// message type, size and populated fields. Off by default; messages are not
+	// inspected unless the logger has DEBUG enabled.
+	LogPayloads bool
+	// RedactFields lists proto field names whose values are masked in payload logs,
+	// at any nesting depth. Fields with the debug_redact option are always masked.
+	RedactFields []string
+	// MetadataKeys lists incoming metadata keys logged as attributes, with dashes
+	// turned into underscores ("user-agent" → user_agent). Nil logs user-agent only;
+	// an empty slice logs none. Never list credentials such as authorization.
+	MetadataKeys []string
+}
+
+// requestAttrs describes the caller: peer address, allowlisted metadata and the
+// session user. The user is read from ctx, or from rec when an interceptor that
+// runs after the logger (e.g. the session or JWT one) resolved it.
+func (cfg InterceptorConfig) requestAttrs(ctx context.Context, rec *userid.Recorder) []slog.Attr {
+	var attrs []slog.Attr
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		attrs = append(attrs, slog.String("peer.address", p.Addr.String()))
+	}
+
+	keys := cfg.MetadataKeys
+	if keys == nil {
+		keys = defaultMetadataKeys
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, key := range keys {
+			if values := md.Get(key); len(values) > 0 {
+				name := strings.ReplaceAll(strings.ToLower(key), "-", "_")
+				attrs = append(attrs, slog.String(name, strings.Join(values, ",")))
+			}
+		}
+	}
+
+	userID, _ := userid.FromContext(ctx)
+	if userID == "" && rec != nil {
+		userID = rec.UserID()
+	}
+
+	if userID != "" {
+		attrs = append(attrs, slog.String("enduser.id", userID))
+	}
+
+	return attrs
+}
+
+func (cfg InterceptorConfig) printLog(
+	ctx context.Context,
+	log logger.Logger,
+	err error,
+	duration time.Duration,
+	fields ...slog.Attr,
+) {
+	code := status.Code(err)
+
+	level, ok := cfg.Levels[code]
+	if !ok {
+		level, ok = defaultLevel(code)
+	}
+
+	if cfg.SlowThreshold > 0 && duration >= cfg.SlowThreshold {
+		fields = append(fields, slog.Bool("slow", true))
+		level = max(level, slog.LevelWarn)
+		ok = true
+	}
+
+	if !ok {
+		return
+	}
+
+	fields = append(fields, tracer.CorrelationFields(ctx)...)
+
+	msg := "rpc completed"
+	if err != nil {
+		msg = err.Error()
+	}
+
+	switch {
+	case level >= slog.LevelError:
+		log.ErrorWithContext(ctx, msg, fields...)
+	case level >= slog.LevelWarn:
+		log.WarnWithContext(ctx, msg, fields...)
+	case level >= slog.LevelInfo:
+		log.InfoWithContext(ctx, msg, fields...)
+	default:
+		log.DebugWithContext(ctx, msg, fields...)
+	}
+}
+
+// defaultLevel maps a status code to its log level; codes.OK is not logged.
+func defaultLevel(code codes.Code) (slog.Level, bool) {
+	switch code {
+	case codes.OK:
+		return slog.LevelDebug, false
 	case
-		codes.OK,
 		codes.Canceled,
 		codes.InvalidArgument,
 		codes.NotFound,
@@ -23,12 +136,12 @@ func printLog(ctx context.Context, log logger.Logger, err error, fields ...slog.
 		codes.FailedPrecondition,
 		codes.Aborted,
 		codes.OutOfRange:
-		log.DebugWithContext(ctx, err.Error(), fields...)
+		return slog.LevelDebug, true
 	case codes.Unknown, codes.DeadlineExceeded, codes.PermissionDenied, codes.Unauthenticated:
-		log.InfoWithContext(ctx, err.Error(), fields...)
+		return slog.LevelInfo, true
 	case codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
-		log.WarnWithContext(ctx, err.Error(), fields...)
+		return slog.LevelWarn, true
 	default:
-		log.InfoWithContext(ctx, err.Error(), fields...)
+		return slog.LevelInfo, true
 	}
 }
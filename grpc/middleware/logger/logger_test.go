@@ -0,0 +1,226 @@
+package grpc_logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/shortlink-org/go-sdk/auth/session"
+	"github.com/shortlink-org/go-sdk/logger"
+	"github.com/shortlink-org/go-sdk/logger/tracer"
+)
+
+func newTestLogger(t *testing.T) (logger.Logger, *bytes.Buffer) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	log, err := logger.New(logger.Configuration{Writer: &buf, Level: logger.DEBUG_LEVEL})
+	require.NoError(t, err)
+
+	return log, &buf
+}
+
+func logLevels(t *testing.T, buf *bytes.Buffer) []string {
+	t.Helper()
+
+	var levels []string
+
+	for line := range strings.SplitSeq(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var entry struct {
+			Level string `json:"level"`
+		}
+
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		levels = append(levels, entry.Level)
+	}
+
+	return levels
+}
+
+func callUnary(interceptor grpc.UnaryServerInterceptor, err error, took time.Duration) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/links.v1.LinkService/Get"}
+
+	_, _ = interceptor(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		time.Sleep(took)
+
+		return nil, err
+	})
+}
+
+func TestUnaryServerInterceptorWithConfig_Levels(t *testing.T) {
+	log, buf := newTestLogger(t)
+
+	interceptor := UnaryServerInterceptorWithConfig(log, InterceptorConfig{
+		Levels: map[codes.Code]slog.Level{
+			codes.OK:       slog.LevelDebug,
+			codes.Internal: slog.LevelError,
+		},
+	})
+
+	callUnary(interceptor, nil, 0)
+	callUnary(interceptor, status.Error(codes.Internal, "boom"), 0)
+
+	assert.Equal(t, []string{"DEBUG", "ERROR"}, logLevels(t, buf))
+}
+
+func TestUnaryServerInterceptor_DefaultSkipsSuccess(t *testing.T) {
+	log, buf := newTestLogger(t)
+
+	interceptor := UnaryServerInterceptor(log)
+
+	callUnary(interceptor, nil, 0)
+	callUnary(interceptor, status.Error(codes.Internal, "boom"), 0)
+
+	assert.Equal(t, []string{"WARN"}, logLevels(t, buf))
+}
+
+func TestUnaryServerInterceptorWithConfig_SlowThreshold(t *testing.T) {
+	log, buf := newTestLogger(t)
+
+	interceptor := UnaryServerInterceptorWithConfig(log, InterceptorConfig{
+		SlowThreshold: 10 * time.Millisecond,
+	})
+
+	callUnary(interceptor, nil, 0)
+	callUnary(interceptor, nil, 20*time.Millisecond)
+
+	assert.Equal(t, []string{"WARN"}, logLevels(t, buf))
+	assert.Contains(t, buf.String(), `"slow":true`)
+}
+
+// payloadTestStream replays a fixed message on RecvMsg and accepts any SendMsg.
+type payloadTestStream struct {
+	grpc.ServerStream
+
+	recv proto.Message
+}
+
+func (s *payloadTestStream) Context() context.Context { return context.Background() }
+
+func (s *payloadTestStream) SendMsg(any) error { return nil }
+
+func (s *payloadTestStream) RecvMsg(m any) error {
+	proto.Merge(m.(proto.Message), s.recv) //nolint:forcetypeassert // test stream only carries proto messages
+
+	return nil
+}
+
+func callStream(t *testing.T, interceptor grpc.StreamServerInterceptor, recv proto.Message) {
+	t.Helper()
+
+	info := &grpc.StreamServerInfo{FullMethod: "/links.v1.LinkService/Watch"}
+
+	err := interceptor(nil, &payloadTestStream{recv: recv}, info, func(_ any, stream grpc.ServerStream) error {
+		if err := stream.RecvMsg(&wrapperspb.StringValue{}); err != nil {
+			return err
+		}
+
+		return stream.SendMsg(wrapperspb.Int64(42))
+	})
+	require.NoError(t, err)
+}
+
+func TestStreamServerInterceptorWithConfig_PayloadRedaction(t *testing.T) {
+	log, buf := newTestLogger(t)
+
+	interceptor := StreamServerInterceptorWithConfig(log, InterceptorConfig{
+		LogPayloads:  true,
+		RedactFields: []string{"value"},
+	})
+
+	callStream(t, interceptor, wrapperspb.String("secret-token"))
+
+	assert.Equal(t, []string{"DEBUG", "DEBUG"}, logLevels(t, buf))
+	assert.Contains(t, buf.String(), `"grpc.message_type":"google.protobuf.StringValue"`)
+	assert.Contains(t, buf.String(), `"grpc.direction":"recv"`)
+	assert.Contains(t, buf.String(), `"grpc.direction":"send"`)
+	assert.Contains(t, buf.String(), `"value":"[REDACTED]"`)
+	assert.NotContains(t, buf.String(), "secret-token")
+}
+
+func TestStreamServerInterceptorWithConfig_PayloadsRequireDebug(t *testing.T) {
+	var buf bytes.Buffer
+
+	log, err := logger.New(logger.Configuration{Writer: &buf, Level: logger.INFO_LEVEL})
+	require.NoError(t, err)
+
+	interceptor := StreamServerInterceptorWithConfig(log, InterceptorConfig{LogPayloads: true})
+
+	callStream(t, interceptor, wrapperspb.String("hello"))
+
+	assert.Empty(t, buf.String())
+}
+
+func TestUnaryServerInterceptor_RequestAttrs(t *testing.T) {
+	log, buf := newTestLogger(t)
+
+	interceptor := UnaryServerInterceptorWithConfig(log, InterceptorConfig{
+		Levels: map[codes.Code]slog.Level{codes.OK: slog.LevelInfo},
+	})
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 7), Port: 51234},
+	})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(
+		"user-agent", "grpc-go/1.80.0",
+		"authorization", "Bearer secret-token",
+	))
+	ctx = session.WithUserID(ctx, "user-42")
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/links.v1.LinkService/Get"}
+	_, err := interceptor(ctx, nil, info, func(context.Context, any) (any, error) {
+		return nil, nil //nolint:nilnil // test handler
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), `"peer.address":"10.0.0.7:51234"`)
+	assert.Contains(t, buf.String(), `"user_agent":"grpc-go/1.80.0"`)
+	assert.Contains(t, buf.String(), `"enduser.id":"user-42"`)
+	assert.NotContains(t, buf.String(), "secret-token")
+}
+
+func TestUnaryServerInterceptor_CorrelationFields(t *testing.T) {
+	log, buf := newTestLogger(t)
+
+	interceptor := UnaryServerInterceptorWithConfig(log, InterceptorConfig{
+		Levels: map[codes.Code]slog.Level{codes.OK: slog.LevelInfo},
+	})
+
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanCtx)
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/links.v1.LinkService/Get"}
+	_, err := interceptor(ctx, nil, info, func(context.Context, any) (any, error) {
+		return nil, nil //nolint:nilnil // test handler
+	})
+	require.NoError(t, err)
+
+	traceID := `"` + tracer.TraceIDKey + `":"` + spanCtx.TraceID().String() + `"`
+	assert.Equal(t, 1, strings.Count(buf.String(), traceID), buf.String())
+	assert.Contains(t, buf.String(), `"`+tracer.SpanIDKey+`":"`+spanCtx.SpanID().String()+`"`)
+}
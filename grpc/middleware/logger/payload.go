@@ -0,0 +1,149 @@
+package grpc_logger
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/shortlink-org/go-sdk/logger"
+)
+
+// redactedValue replaces the value of redacted fields in payload logs.
+const redactedValue = "[REDACTED]"
+
+// levelEnabler is implemented by loggers that can report whether a level is enabled.
+type levelEnabler interface {
+	Enabled(ctx context.Context, level slog.Level) bool
+}
+
+// debugEnabled reports whether log writes DEBUG records; loggers that cannot tell are assumed enabled.
+func debugEnabled(ctx context.Context, log logger.Logger) bool {
+	enabler, ok := log.(levelEnabler)
+
+	return !ok || enabler.Enabled(ctx, slog.LevelDebug)
+}
+
+// payloadStream logs a summary of every message sent or received on a server stream.
+type payloadStream struct {
+	grpc.ServerStream
+
+	log    logger.Logger
+	method string
+	redact []string
+}
+
+// SendMsg logs m after it is sent.
+func (s *payloadStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.logPayload("send", m)
+	}
+
+	return err
+}
+
+// RecvMsg logs m after it is received.
+func (s *payloadStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.logPayload("recv", m)
+	}
+
+	return err
+}
+
+func (s *payloadStream) logPayload(direction string, m any) {
+	ctx := s.Context()
+	if !debugEnabled(ctx, s.log) {
+		return
+	}
+
+	fields := []slog.Attr{
+		slog.String("grpc.method", s.method),
+		slog.String("grpc.direction", direction),
+	}
+
+	if msg, ok := m.(proto.Message); ok {
+		fields = append(fields,
+			slog.String("grpc.message_type", string(proto.MessageName(msg))),
+			slog.Int("grpc.message_size", proto.Size(msg)),
+			slog.Any("grpc.message", summarize(msg.ProtoReflect(), s.redact)),
+		)
+	}
+
+	s.log.DebugWithContext(ctx, "rpc stream message", fields...)
+}
+
+// summarize renders the populated fields of msg, masking fields named in redact
+// or marked with the debug_redact field option. Nested messages are rendered recursively.
+func summarize(msg protoreflect.Message, redact []string) map[string]any {
+	summary := make(map[string]any)
+
+	msg.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		name := string(fd.Name())
+
+		if isRedacted(fd, redact) {
+			summary[name] = redactedValue
+
+			return true
+		}
+
+		switch {
+		case fd.IsList():
+			list := value.List()
+			items := make([]any, list.Len())
+
+			for i := range list.Len() {
+				items[i] = summarizeValue(fd, list.Get(i), redact)
+			}
+
+			summary[name] = items
+		case fd.IsMap():
+			entries := make(map[string]any, value.Map().Len())
+
+			value.Map().Range(func(key protoreflect.MapKey, item protoreflect.Value) bool {
+				entries[key.String()] = summarizeValue(fd.MapValue(), item, redact)
+
+				return true
+			})
+
+			summary[name] = entries
+		default:
+			summary[name] = summarizeValue(fd, value, redact)
+		}
+
+		return true
+	})
+
+	return summary
+}
+
+func summarizeValue(fd protoreflect.FieldDescriptor, value protoreflect.Value, redact []string) any {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return summarize(value.Message(), redact)
+	case protoreflect.EnumKind:
+		if enum := fd.Enum().Values().ByNumber(value.Enum()); enum != nil {
+			return string(enum.Name())
+		}
+
+		return int32(value.Enum())
+	default:
+		return value.Interface()
+	}
+}
+
+func isRedacted(fd protoreflect.FieldDescriptor, redact []string) bool {
+	if slices.Contains(redact, string(fd.Name())) {
+		return true
+	}
+
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+
+	return ok && opts.GetDebugRedact()
+}
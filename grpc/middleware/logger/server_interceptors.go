@@ -13,14 +13,21 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/shortlink-org/go-sdk/auth/userid"
 	"github.com/shortlink-org/go-sdk/logger"
 )
 
 // UnaryServerInterceptor returns a new unary server interceptors that adds zap.Logger to the context.
 func UnaryServerInterceptor(log logger.Logger) grpc.UnaryServerInterceptor {
+	return UnaryServerInterceptorWithConfig(log, InterceptorConfig{})
+}
+
+// UnaryServerInterceptorWithConfig is like UnaryServerInterceptor with configurable levels and slow-call threshold.
+func UnaryServerInterceptorWithConfig(log logger.Logger, cfg InterceptorConfig) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 		startTime := time.Now()
-		resp, err := handler(ctx, req)
+		handlerCtx, rec := userid.WithRecorder(ctx)
+		resp, err := handler(handlerCtx, req)
 		duration := time.Since(startTime)
 
 		if span := trace.SpanFromContext(ctx); span.IsRecording() {
@@ -39,10 +46,9 @@ func UnaryServerInterceptor(log logger.Logger) grpc.UnaryServerInterceptor {
 			slog.String("code", status.Code(err).String()),
 			slog.Int64("duration (mks)", duration.Microseconds()),
 		}
+		fields = append(fields, cfg.requestAttrs(ctx, rec)...)
 
-		if err != nil {
-			printLog(ctx, log, err, fields...)
-		}
+		cfg.printLog(ctx, log, err, duration, fields...)
 
 		return resp, err
 	}
@@ -50,11 +56,30 @@ func UnaryServerInterceptor(log logger.Logger) grpc.UnaryServerInterceptor {
 
 // StreamServerInterceptor returns a new streaming server interceptor that adds zap.Logger to the context.
 func StreamServerInterceptor(log logger.Logger) grpc.StreamServerInterceptor {
+	return StreamServerInterceptorWithConfig(log, InterceptorConfig{})
+}
+
+// StreamServerInterceptorWithConfig is like StreamServerInterceptor with configurable levels,
+// slow-call threshold and optional payload logging.
+func StreamServerInterceptorWithConfig(log logger.Logger, cfg InterceptorConfig) grpc.StreamServerInterceptor {
 	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		startTime := time.Now()
 		wrapped := grpc_middleware.WrapServerStream(stream)
 
-		err := handler(srv, wrapped)
+		var rec *userid.Recorder
+		wrapped.WrappedContext, rec = userid.WithRecorder(wrapped.WrappedContext)
+
+		var handlerStream grpc.ServerStream = wrapped
+		if cfg.LogPayloads {
+			handlerStream = &payloadStream{
+				ServerStream: wrapped,
+				log:          log,
+				method:       info.FullMethod,
+				redact:       cfg.RedactFields,
+			}
+		}
+
+		err := handler(srv, handlerStream)
 		duration := time.Since(startTime)
 
 		fields := []slog.Attr{
@@ -63,10 +88,9 @@ func StreamServerInterceptor(log logger.Logger) grpc.StreamServerInterceptor {
 			slog.String("code", status.Code(err).String()),
 			slog.Int64("duration (mks)", duration.Microseconds()),
 		}
+		fields = append(fields, cfg.requestAttrs(wrapped.Context(), rec)...)
 
-		if err != nil {
-			printLog(wrapped.Context(), log, err, fields...)
-		}
+		cfg.printLog(wrapped.Context(), log, err, duration, fields...)
 
 		return err
 	}
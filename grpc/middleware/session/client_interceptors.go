@@ -6,9 +6,12 @@ package sessioninterceptor
 import (
 	"context"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
@@ -38,7 +41,24 @@ type contextKey struct{ name string }
 // ContextAuthorizationKey is used to store the original Authorization header in context.
 var ContextAuthorizationKey = &contextKey{"authorization"}
 
-var tracerClient = otel.Tracer("session.interceptor.client")
+var (
+	tracerClient = otel.Tracer("session.interceptor.client")
+
+	authClientIdentityResolutionTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_client_identity_resolutions_total",
+			Help: "Total number of user identity resolutions for outgoing gRPC calls.",
+		},
+		[]string{"source", "outcome", "reason"},
+	)
+)
+
+// Client identity sources, the counterparts of the server's "metadata" and "context".
+const (
+	clientSourceContext = "context"
+	clientSourceClaims  = "claims"
+	clientSourceNone    = "none"
+)
 
 // WithAuthorization stores the Authorization header value in context.
 // Call this in your HTTP middleware to make it available for gRPC calls.
@@ -68,11 +88,8 @@ func attachAuthMetadata(ctx context.Context) (context.Context, error) {
 	// Get Authorization header from context
 	auth := GetAuthorization(ctx)
 
-	// Get user ID from JWT claims
-	userID, getUserErr := session.GetUserID(ctx)
-	if getUserErr != nil {
-		userID = ""
-	}
+	userID, source := resolveClientIdentity(ctx)
+	observeClientIdentityResolution(ctx, source, userID != "")
 
 	// Build metadata
 	pairs := make([]string, 0, initialPairsCapacity)
@@ -112,6 +129,43 @@ func attachAuthMetadata(ctx context.Context) (context.Context, error) {
 	return metadata.NewOutgoingContext(ctx, newMD), nil
 }
 
+// resolveClientIdentity returns the user id to forward and where it came from:
+// the context user id, then the subject of the session claims.
+func resolveClientIdentity(ctx context.Context) (string, string) {
+	if userID, err := session.GetUserID(ctx); err == nil && userID != "" {
+		return userID, clientSourceContext
+	}
+
+	if claims, err := session.GetClaims(ctx); err == nil && claims.Subject != "" {
+		return claims.Subject, clientSourceClaims
+	}
+
+	return "", clientSourceNone
+}
+
+// observeClientIdentityResolution counts an outgoing identity resolution with the outcome and
+// reason values of auth_identity_resolutions_total, and the trace id as exemplar when the span is sampled.
+func observeClientIdentityResolution(ctx context.Context, source string, resolved bool) {
+	outcome, reason := "success", "ok"
+	if !resolved {
+		outcome, reason = "error", ReasonMissingUserID
+	}
+
+	counter := authClientIdentityResolutionTotal.WithLabelValues(source, outcome, reason)
+
+	if adder, ok := counter.(prometheus.ExemplarAdder); ok {
+		if sc := trace.SpanContextFromContext(ctx); sc.IsSampled() && sc.HasTraceID() {
+			adder.AddWithExemplar(1, prometheus.Labels{
+				"trace_id": sc.TraceID().String(),
+			})
+
+			return
+		}
+	}
+
+	counter.Inc()
+}
+
 // SessionUnaryClientInterceptor attaches JWT token and user-id to outgoing metadata for unary calls.
 func SessionUnaryClientInterceptor() grpc.UnaryClientInterceptor {
 	return func(
@@ -0,0 +1,70 @@
+package sessioninterceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/shortlink-org/go-sdk/auth/session"
+)
+
+func TestSessionUnaryClientInterceptor_ResolutionMetric(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		wantLabels []string
+		wantUserID string
+	}{
+		{
+			name:       "context user id",
+			ctx:        session.WithUserID(context.Background(), "user-1"),
+			wantLabels: []string{"context", "success", "ok"},
+			wantUserID: "user-1",
+		},
+		{
+			name:       "session claims",
+			ctx:        session.WithClaims(context.Background(), &session.Claims{Subject: "user-2"}),
+			wantLabels: []string{"claims", "success", "ok"},
+			wantUserID: "user-2",
+		},
+		{
+			name:       "anonymous",
+			ctx:        context.Background(),
+			wantLabels: []string{"none", "error", ReasonMissingUserID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := authClientIdentityResolutionTotal.WithLabelValues(tt.wantLabels...)
+			before := testutil.ToFloat64(counter)
+
+			var forwarded []string
+
+			interceptor := SessionUnaryClientInterceptor()
+
+			err := interceptor(tt.ctx, "/orders.v1.OrderService/Get", nil, nil, nil,
+				func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+					md, _ := metadata.FromOutgoingContext(ctx)
+					forwarded = md.Get(userIDKey)
+
+					return nil
+				},
+			)
+			require.NoError(t, err)
+
+			assert.InDelta(t, before+1, testutil.ToFloat64(counter), 0)
+
+			if tt.wantUserID == "" {
+				assert.Empty(t, forwarded)
+			} else {
+				assert.Equal(t, []string{tt.wantUserID}, forwarded)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	otelcodes "go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	grpcCodes "google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -25,6 +26,17 @@ const (
 	expectedMethodParts = 3
 )
 
+// ErrorDomain is the errdetails.ErrorInfo domain attached to identity resolution failures.
+const ErrorDomain = "session_interceptor"
+
+// Reasons reported in errdetails.ErrorInfo for identity resolution failures.
+// The resolution source ("metadata" or "context") is in ErrorInfo.Metadata["source"].
+const (
+	ReasonMissingMetadata = "missing_metadata"
+	ReasonMissingUserID   = "missing_user_id"
+	ReasonInternalError   = "internal_error"
+)
+
 // skipMethodPrefixes defines gRPC methods that should bypass session validation.
 // Includes health checks and reflection services (both v1 and v1alpha).
 var skipMethodPrefixes = []string{
@@ -54,18 +66,32 @@ var (
 	)
 )
 
+// InterceptorConfig configures the session interceptors.
+type InterceptorConfig struct {
+	// SkipMethods is a list of method prefixes that bypass identity resolution,
+	// e.g. "/myservice.Public/". Health and reflection services are always skipped.
+	SkipMethods []string
+}
+
 // SessionUnaryServerInterceptor extracts user identity from incoming gRPC metadata.
 // It looks for:
 // 1) user-id in metadata (set by BFF).
 // 2) authorization header for JWT validation (optional).
 func SessionUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return SessionUnaryServerInterceptorWithConfig(InterceptorConfig{})
+}
+
+// SessionUnaryServerInterceptorWithConfig is SessionUnaryServerInterceptor with a custom skip list.
+func SessionUnaryServerInterceptorWithConfig(cfg InterceptorConfig) grpc.UnaryServerInterceptor {
+	skipMethods := mergeSkipMethods(cfg.SkipMethods)
+
 	return func(
 		ctx context.Context,
 		req any,
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (any, error) {
-		if shouldSkipMethod(info.FullMethod) {
+		if shouldSkipMethod(info.FullMethod, skipMethods) {
 			return handler(ctx, req)
 		}
 
@@ -109,7 +135,7 @@ func handleUnarySession(
 			ctx: ctx, source: source, outcome: outcome, reason: reason, start: start,
 		})
 
-		return nil, status.Error(code, err.Error())
+		return nil, authStatusError(code, reason, source, err)
 	}
 
 	observeIdentityResolution(identityResolutionObserveParams{
@@ -140,13 +166,20 @@ func handleUnarySession(
 
 // SessionStreamServerInterceptor applies identity resolution for streaming RPCs.
 func SessionStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return SessionStreamServerInterceptorWithConfig(InterceptorConfig{})
+}
+
+// SessionStreamServerInterceptorWithConfig is SessionStreamServerInterceptor with a custom skip list.
+func SessionStreamServerInterceptorWithConfig(cfg InterceptorConfig) grpc.StreamServerInterceptor {
+	skipMethods := mergeSkipMethods(cfg.SkipMethods)
+
 	return func(
 		srv any,
 		stream grpc.ServerStream,
 		info *grpc.StreamServerInfo,
 		handler grpc.StreamHandler,
 	) error {
-		if shouldSkipMethod(info.FullMethod) {
+		if shouldSkipMethod(info.FullMethod, skipMethods) {
 			return handler(srv, stream)
 		}
 
@@ -184,7 +217,7 @@ func handleStreamSession(
 			ctx: ctx, source: source, outcome: "error", reason: reasonStr, start: start,
 		})
 
-		return status.Error(code, err.Error())
+		return authStatusError(code, reasonStr, source, err)
 	}
 
 	observeIdentityResolution(identityResolutionObserveParams{
@@ -320,14 +353,30 @@ func (s *sessionWrappedServerStream) Context() context.Context {
 func classifyAuthError(err error) (grpcCodes.Code, string) {
 	switch {
 	case errors.Is(err, ErrServerMissingMetadata):
-		return grpcCodes.Unauthenticated, "missing_metadata"
+		return grpcCodes.Unauthenticated, ReasonMissingMetadata
 
 	case errors.Is(err, ErrServerMissingUserID):
-		return grpcCodes.Unauthenticated, "missing_user_id"
+		return grpcCodes.Unauthenticated, ReasonMissingUserID
 
 	default:
-		return grpcCodes.Internal, "internal_error"
+		return grpcCodes.Internal, ReasonInternalError
+	}
+}
+
+// authStatusError keeps err's message and attaches reason and source as errdetails.ErrorInfo.
+func authStatusError(code grpcCodes.Code, reason, source string, err error) error {
+	st := status.New(code, err.Error())
+
+	detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   ErrorDomain,
+		Metadata: map[string]string{"source": source},
+	})
+	if detailErr != nil {
+		return st.Err()
 	}
+
+	return detailed.Err()
 }
 
 // --- Metrics (with exemplars) ---
@@ -365,8 +414,8 @@ func observeIdentityResolution(p identityResolutionObserveParams) {
 
 // --- Utils ---
 
-func shouldSkipMethod(fullMethod string) bool {
-	for _, prefix := range skipMethodPrefixes {
+func shouldSkipMethod(fullMethod string, skipMethods []string) bool {
+	for _, prefix := range skipMethods {
 		if strings.HasPrefix(fullMethod, prefix) {
 			return true
 		}
@@ -375,6 +424,13 @@ func shouldSkipMethod(fullMethod string) bool {
 	return false
 }
 
+func mergeSkipMethods(custom []string) []string {
+	merged := make([]string, 0, len(skipMethodPrefixes)+len(custom))
+	merged = append(merged, skipMethodPrefixes...)
+
+	return append(merged, custom...)
+}
+
 func splitFullMethodName(full string) (string, string) {
 	parts := strings.Split(full, "/")
 	if len(parts) != expectedMethodParts {
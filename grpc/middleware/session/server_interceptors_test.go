@@ -0,0 +1,68 @@
+package sessioninterceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/shortlink-org/go-sdk/auth/session"
+)
+
+func TestSessionUnaryServerInterceptor_ErrorDetails(t *testing.T) {
+	interceptor := SessionUnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/orders.v1.OrderService/Get"}
+
+	_, err := interceptor(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		t.Fatal("handler must not be called")
+
+		return nil, nil
+	})
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.Unauthenticated, st.Code())
+	assert.Equal(t, ErrServerMissingUserID.Error(), st.Message())
+
+	require.Len(t, st.Details(), 1)
+
+	errInfo, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, ReasonMissingUserID, errInfo.GetReason())
+	assert.Equal(t, ErrorDomain, errInfo.GetDomain())
+	assert.Equal(t, "context", errInfo.GetMetadata()["source"])
+}
+
+func TestSessionUnaryServerInterceptorWithConfig_SkipMethods(t *testing.T) {
+	interceptor := SessionUnaryServerInterceptorWithConfig(InterceptorConfig{
+		SkipMethods: []string{"/catalog.v1.PublicService/"},
+	})
+
+	called := false
+	skipped := &grpc.UnaryServerInfo{FullMethod: "/catalog.v1.PublicService/List"}
+
+	_, err := interceptor(context.Background(), nil, skipped, func(ctx context.Context, _ any) (any, error) {
+		called = true
+
+		_, err := session.GetUserID(ctx)
+		assert.Error(t, err)
+
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.True(t, called)
+
+	enforced := &grpc.UnaryServerInfo{FullMethod: "/catalog.v1.PrivateService/List"}
+
+	_, err = interceptor(context.Background(), nil, enforced, func(context.Context, any) (any, error) {
+		t.Fatal("handler must not be called")
+
+		return nil, nil
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,103 @@
+// Package streammetrics records per-stream message counts and lifetimes for
+// streaming RPCs, which per-call handling time alone does not describe.
+package streammetrics
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+const (
+	directionSent     = "sent"
+	directionReceived = "received"
+)
+
+// Metrics holds the stream collectors registered by New.
+type Metrics struct {
+	messages  *prometheus.CounterVec
+	perStream *prometheus.HistogramVec
+	duration  *prometheus.HistogramVec
+}
+
+// New registers the stream collectors with reg.
+func New(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		messages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_stream_messages_total",
+			Help: "Total number of messages sent or received on server streams.",
+		}, []string{"grpc_method", "direction"}),
+		perStream: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_stream_messages_per_stream",
+			Help:    "Number of messages sent or received per server stream.",
+			Buckets: []float64{1, 2, 5, 10, 50, 100, 500, 1000, 5000, 10000},
+		}, []string{"grpc_method", "direction"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_stream_duration_seconds",
+			Help:    "Lifetime of server streams in seconds.",
+			Buckets: []float64{0.01, 0.1, 1, 5, 15, 30, 60, 300, 900, 1800, 3600},
+		}, []string{"grpc_method"}),
+	}
+
+	for _, collector := range []prometheus.Collector{m.messages, m.perStream, m.duration} {
+		if err := reg.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// StreamServerInterceptor counts the messages of every stream and records its lifetime once the handler returns.
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		counted := &countingStream{
+			ServerStream: stream,
+			sentTotal:    m.messages.WithLabelValues(info.FullMethod, directionSent),
+			recvTotal:    m.messages.WithLabelValues(info.FullMethod, directionReceived),
+		}
+
+		err := handler(srv, counted)
+
+		m.perStream.WithLabelValues(info.FullMethod, directionSent).Observe(float64(counted.sent.Load()))
+		m.perStream.WithLabelValues(info.FullMethod, directionReceived).Observe(float64(counted.received.Load()))
+		m.duration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
+
+// countingStream counts successfully sent and received messages.
+type countingStream struct {
+	grpc.ServerStream
+
+	sentTotal prometheus.Counter
+	recvTotal prometheus.Counter
+
+	sent     atomic.Int64
+	received atomic.Int64
+}
+
+func (s *countingStream) SendMsg(msg any) error {
+	err := s.ServerStream.SendMsg(msg)
+	if err == nil {
+		s.sent.Add(1)
+		s.sentTotal.Inc()
+	}
+
+	return err
+}
+
+func (s *countingStream) RecvMsg(msg any) error {
+	err := s.ServerStream.RecvMsg(msg)
+	if err == nil {
+		s.received.Add(1)
+		s.recvTotal.Inc()
+	}
+
+	return err
+}
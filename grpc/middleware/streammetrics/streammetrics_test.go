@@ -0,0 +1,58 @@
+package streammetrics
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestStreamServerInterceptor_CountsMessages(t *testing.T) {
+	metrics, err := New(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer(grpc.ChainStreamInterceptor(metrics.StreamServerInterceptor()))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	go func() { _ = srv.Serve(lis) }()
+
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := healthpb.NewHealthClient(conn).Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	const method = "/grpc.health.v1.Health/Watch"
+
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(metrics.messages.WithLabelValues(method, directionSent)) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.InDelta(t, 1, testutil.ToFloat64(metrics.messages.WithLabelValues(method, directionReceived)), 0)
+
+	cancel()
+
+	require.Eventually(t, func() bool {
+		return testutil.CollectAndCount(metrics.duration) == 1
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,195 @@
+// Package tenant carries the tenant id of multi-tenant services in the
+// tenant-id gRPC metadata key and the request context.
+package tenant
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/shortlink-org/go-sdk/auth/session"
+	"github.com/shortlink-org/go-sdk/grpc/authjwt"
+)
+
+const (
+	// MetadataKey carries the tenant id between services.
+	MetadataKey = "tenant-id"
+
+	// ClaimKey is the identity metadata entry holding the tenant id.
+	ClaimKey = "tenant_id"
+)
+
+type contextKey struct{}
+
+// Config configures the server interceptors.
+type Config struct {
+	// Required rejects calls without a tenant id with codes.InvalidArgument.
+	Required bool
+	// SkipMethods lists full method names ("/pkg.Service/Method") exempt from Required.
+	SkipMethods []string
+}
+
+// WithID stores the tenant id in ctx. Client interceptors forward it downstream.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// GetID returns the tenant id stored by WithID or the server interceptors.
+func GetID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+
+	return id, ok && id != ""
+}
+
+// Resolve returns ctx carrying the caller's tenant id.
+// A tenant id in the validated claims wins over metadata; a metadata value that
+// contradicts it is rejected with codes.PermissionDenied. When neither is set and
+// cfg.Required applies to method, codes.InvalidArgument is returned.
+func Resolve(ctx context.Context, method string, cfg Config) (context.Context, error) {
+	fromMetadata := fromIncoming(ctx)
+	fromClaims := fromClaims(ctx)
+
+	id := fromMetadata
+
+	if fromClaims != "" {
+		if fromMetadata != "" && fromMetadata != fromClaims {
+			return ctx, status.Error(codes.PermissionDenied, "tenant: tenant-id does not match claims")
+		}
+
+		id = fromClaims
+	}
+
+	if id == "" {
+		if cfg.Required && !slices.Contains(cfg.SkipMethods, method) {
+			return ctx, status.Error(codes.InvalidArgument, "tenant: tenant-id is required")
+		}
+
+		return ctx, nil
+	}
+
+	return WithID(ctx, id), nil
+}
+
+// UnaryServerInterceptor resolves the tenant id of unary calls.
+func UnaryServerInterceptor(cfg Config) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		ctx, err := Resolve(ctx, info.FullMethod, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor resolves the tenant id of streaming calls.
+func StreamServerInterceptor(cfg Config) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := Resolve(stream.Context(), info.FullMethod, cfg)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &wrappedServerStream{ServerStream: stream, wrappedCtx: ctx})
+	}
+}
+
+// UnaryClientInterceptor forwards the tenant id from ctx in outgoing metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		conn *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(forward(ctx), method, req, reply, conn, opts...)
+	}
+}
+
+// StreamClientInterceptor forwards the tenant id from ctx in outgoing metadata.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		conn *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		return streamer(forward(ctx), desc, conn, method, opts...)
+	}
+}
+
+func fromIncoming(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(MetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// fromClaims reads ClaimKey from authjwt claims, falling back to session claims.
+func fromClaims(ctx context.Context) string {
+	var identity map[string]any
+
+	if claims := authjwt.ClaimsFromContext(ctx); claims != nil {
+		identity = claims.Metadata
+	} else if claims, err := session.GetClaims(ctx); err == nil && claims != nil {
+		identity = claims.Metadata
+	}
+
+	id, _ := identity[ClaimKey].(string)
+
+	return id
+}
+
+func forward(ctx context.Context) context.Context {
+	id, ok := GetID(ctx)
+	if !ok {
+		return ctx
+	}
+
+	md, exists := metadata.FromOutgoingContext(ctx)
+	if !exists {
+		md = metadata.MD{}
+	}
+
+	// Set (not append) so multi-hop calls carry a single value.
+	md = md.Copy()
+	md.Set(MetadataKey, id)
+
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+//nolint:containedctx // Required for grpc stream context override pattern
+type wrappedServerStream struct {
+	grpc.ServerStream
+
+	wrappedCtx context.Context
+}
+
+func (wrapper *wrappedServerStream) Context() context.Context {
+	return wrapper.wrappedCtx
+}
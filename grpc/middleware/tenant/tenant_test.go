@@ -0,0 +1,92 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/shortlink-org/go-sdk/grpc/authjwt"
+)
+
+const testMethod = "/links.v1.LinkService/Get"
+
+func callUnary(t *testing.T, ctx context.Context, cfg Config) (string, error) {
+	t.Helper()
+
+	var got string
+
+	_, err := UnaryServerInterceptor(cfg)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: testMethod},
+		func(ctx context.Context, _ any) (any, error) {
+			got, _ = GetID(ctx)
+
+			return nil, nil
+		},
+	)
+
+	return got, err
+}
+
+func TestUnaryServerInterceptor_Present(t *testing.T) {
+	t.Parallel()
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "acme"))
+
+	id, err := callUnary(t, ctx, Config{Required: true})
+	require.NoError(t, err)
+	assert.Equal(t, "acme", id)
+}
+
+func TestUnaryServerInterceptor_AbsentRequired(t *testing.T) {
+	t.Parallel()
+
+	_, err := callUnary(t, context.Background(), Config{Required: true})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = callUnary(t, context.Background(), Config{Required: true, SkipMethods: []string{testMethod}})
+	require.NoError(t, err)
+
+	id, err := callUnary(t, context.Background(), Config{})
+	require.NoError(t, err)
+	assert.Empty(t, id)
+}
+
+func TestUnaryServerInterceptor_FromClaims(t *testing.T) {
+	t.Parallel()
+
+	ctx := authjwt.WithClaims(context.Background(), &authjwt.Claims{
+		Metadata: map[string]any{ClaimKey: "acme"},
+	})
+
+	id, err := callUnary(t, ctx, Config{Required: true})
+	require.NoError(t, err)
+	assert.Equal(t, "acme", id)
+
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(MetadataKey, "other"))
+
+	_, err = callUnary(t, ctx, Config{Required: true})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestUnaryClientInterceptor_Forwards(t *testing.T) {
+	t.Parallel()
+
+	ctx := metadata.AppendToOutgoingContext(WithID(context.Background(), "acme"), MetadataKey, "stale")
+
+	var got metadata.MD
+
+	err := UnaryClientInterceptor()(ctx, testMethod, nil, nil, nil,
+		func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			got, _ = metadata.FromOutgoingContext(ctx)
+
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme"}, got.Get(MetadataKey))
+}
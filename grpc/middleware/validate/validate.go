@@ -0,0 +1,93 @@
+// Package validate provides gRPC interceptors that check decoded request
+// messages against registered specifications.
+package validate
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/shortlink-org/go-sdk/specification"
+)
+
+// Registry maps request message types to the specification they must satisfy.
+type Registry struct {
+	mu    sync.RWMutex
+	rules map[reflect.Type]func(any) error
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		rules: make(map[reflect.Type]func(any) error),
+	}
+}
+
+// Register attaches spec to requests of type *T, replacing any previous rule.
+func Register[T any](registry *Registry, spec specification.Specification[T]) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.rules[reflect.TypeFor[*T]()] = func(req any) error {
+		//nolint:forcetypeassert // rules are keyed by the request type
+		return spec.IsSatisfiedBy(req.(*T))
+	}
+}
+
+// Validate runs the specification registered for req's type.
+// Requests without a registered specification pass.
+func (r *Registry) Validate(req any) error {
+	if r == nil || req == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	rule, ok := r.rules[reflect.TypeOf(req)]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil
+	}
+
+	if err := rule(req); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return nil
+}
+
+// UnaryServerInterceptor rejects requests failing their specification with codes.InvalidArgument.
+func UnaryServerInterceptor(registry *Registry) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := registry.Validate(req); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor validates every message received on the stream.
+func StreamServerInterceptor(registry *Registry) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingServerStream{ServerStream: stream, registry: registry})
+	}
+}
+
+type validatingServerStream struct {
+	grpc.ServerStream
+
+	registry *Registry
+}
+
+func (s *validatingServerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return s.registry.Validate(m)
+}
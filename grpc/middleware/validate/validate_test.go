@@ -0,0 +1,103 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type createUserRequest struct {
+	Age int
+}
+
+type minAgeSpec struct {
+	min int
+}
+
+func (s minAgeSpec) IsSatisfiedBy(req *createUserRequest) error {
+	if req.Age < s.min {
+		return fmt.Errorf("age %d is below minimum %d", req.Age, s.min)
+	}
+
+	return nil
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	Register[createUserRequest](registry, minAgeSpec{min: 18})
+
+	interceptor := UnaryServerInterceptor(registry)
+	info := &grpc.UnaryServerInfo{FullMethod: "/users.v1.UserService/Create"}
+
+	called := false
+	handler := func(context.Context, any) (any, error) {
+		called = true
+
+		return "ok", nil
+	}
+
+	_, err := interceptor(context.Background(), &createUserRequest{Age: 16}, info, handler)
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "age 16 is below minimum 18")
+	assert.False(t, called)
+
+	resp, err := interceptor(context.Background(), &createUserRequest{Age: 30}, info, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+	assert.True(t, called)
+}
+
+func TestUnaryServerInterceptor_UnregisteredTypePasses(t *testing.T) {
+	t.Parallel()
+
+	interceptor := UnaryServerInterceptor(NewRegistry())
+
+	resp, err := interceptor(context.Background(), &createUserRequest{}, &grpc.UnaryServerInfo{},
+		func(context.Context, any) (any, error) { return "ok", nil },
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+
+	msg *createUserRequest
+}
+
+func (s *fakeServerStream) RecvMsg(m any) error {
+	req, ok := m.(*createUserRequest)
+	if !ok {
+		return errors.New("unexpected message type")
+	}
+
+	*req = *s.msg
+
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	Register[createUserRequest](registry, minAgeSpec{min: 18})
+
+	interceptor := StreamServerInterceptor(registry)
+
+	err := interceptor(nil, &fakeServerStream{msg: &createUserRequest{Age: 10}}, &grpc.StreamServerInfo{},
+		func(_ any, stream grpc.ServerStream) error {
+			return stream.RecvMsg(&createUserRequest{})
+		},
+	)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
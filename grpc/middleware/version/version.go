@@ -0,0 +1,52 @@
+// Package version stamps the service version on gRPC responses, so callers can
+// tell which build served a request, e.g. during canary rollouts.
+package version
+
+import (
+	"context"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TrailerKey carries the service version in response trailers.
+const TrailerKey = "x-service-version"
+
+// unknownVersion is reported when neither configuration nor build info provide a version.
+const unknownVersion = "unknown"
+
+// UnaryServerInterceptor sets TrailerKey to version on every unary response, including errors.
+func UnaryServerInterceptor(version string) grpc.UnaryServerInterceptor {
+	trailer := metadata.Pairs(TrailerKey, version)
+
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		_ = grpc.SetTrailer(ctx, trailer) //nolint:errcheck // only fails outside a server transport
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor sets TrailerKey to version on every stream.
+func StreamServerInterceptor(version string) grpc.StreamServerInterceptor {
+	trailer := metadata.Pairs(TrailerKey, version)
+
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		stream.SetTrailer(trailer)
+
+		return handler(srv, stream)
+	}
+}
+
+// Resolve returns configured when set, otherwise the main module version from the build info.
+func Resolve(configured string) string {
+	if configured != "" {
+		return configured
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+
+	return unknownVersion
+}
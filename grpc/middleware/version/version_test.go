@@ -0,0 +1,44 @@
+package version
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestUnaryServerInterceptor_SetsTrailer(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(UnaryServerInterceptor("v1.2.3")))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	go func() { _ = srv.Serve(lis) }()
+
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	var trailer metadata.MD
+
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{}, grpc.Trailer(&trailer))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"v1.2.3"}, trailer.Get(TrailerKey))
+}
+
+func TestResolve(t *testing.T) {
+	assert.Equal(t, "v2.0.0", Resolve("v2.0.0"))
+	assert.NotEmpty(t, Resolve(""))
+}
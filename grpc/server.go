@@ -2,10 +2,12 @@ package grpc
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log/slog"
 	"net"
 	"runtime/debug"
+	"sync"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
@@ -27,6 +29,8 @@ import (
 	grpc_logger "github.com/shortlink-org/go-sdk/grpc/middleware/logger"
 	pprof_interceptor "github.com/shortlink-org/go-sdk/grpc/middleware/pprof"
 	session_interceptor "github.com/shortlink-org/go-sdk/grpc/middleware/session"
+	"github.com/shortlink-org/go-sdk/grpc/middleware/streammetrics"
+	version_interceptor "github.com/shortlink-org/go-sdk/grpc/middleware/version"
 	"github.com/shortlink-org/go-sdk/logger"
 )
 
@@ -35,6 +39,17 @@ type Server struct {
 	Run      func()
 	Server   *grpc.Server
 	Endpoint string
+
+	ready         chan struct{}
+	log           logger.Logger
+	cfg           *config.Config
+	clientOptions []Option
+}
+
+// Ready is closed once Run starts serving. The listener is bound by InitServer,
+// so clients may dial as soon as Ready is closed.
+func (s *Server) Ready() <-chan struct{} {
+	return s.ready
 }
 
 type server struct {
@@ -42,6 +57,14 @@ type server struct {
 	interceptorUnaryServerList  []grpc.UnaryServerInterceptor
 	optionsNewServer            []grpc.ServerOption
 
+	// interceptors are the enabled interceptors; interceptorOrder is the chain built from them.
+	interceptors     []namedInterceptor
+	interceptorOrder []string
+
+	// customUnary and customStream come from WithUnaryInterceptors/WithStreamInterceptors.
+	customUnary  []grpc.UnaryServerInterceptor
+	customStream []grpc.StreamServerInterceptor
+
 	port int
 	host string
 
@@ -59,6 +82,7 @@ func InitServer(
 	prom *prometheus.Registry,
 	flightRecorder *flight_trace.Recorder,
 	cfg *config.Config,
+	opts ...ServerOption,
 ) (*Server, error) {
 	cfg.SetDefault("GRPC_SERVER_ENABLED", true) // gRPC server enable
 
@@ -67,7 +91,7 @@ func InitServer(
 		return nil, nil
 	}
 
-	srv, err := setServerConfig(log, tracer, prom, flightRecorder, cfg) //nolint:contextcheck // ctx is for Listen/Serve/shutdown; wiring-only path; WithLogger chain triggers false positive.
+	srv, err := setServerConfig(log, tracer, prom, flightRecorder, cfg, opts...) //nolint:contextcheck // ctx is for Listen/Serve/shutdown; wiring-only path; WithLogger chain triggers false positive.
 	if err != nil {
 		return nil, err
 	}
@@ -84,8 +108,15 @@ func InitServer(
 	// Initialize the gRPC server.
 	grpcServer := grpc.NewServer(srv.optionsNewServer...)
 
+	ready := make(chan struct{})
+	markReady := sync.OnceFunc(func() { close(ready) })
+
 	grpcServerInstance := &Server{
-		Server: grpcServer,
+		Server:        grpcServer,
+		ready:         ready,
+		log:           log,
+		cfg:           cfg,
+		clientOptions: srv.clientOptions(tracer, prom),
 		Run: func() {
 			// Register reflection service on gRPC server.
 			reflection.Register(grpcServer)
@@ -98,6 +129,8 @@ func InitServer(
 				slog.String("host", srv.host),
 			)
 
+			markReady()
+
 			err = grpcServer.Serve(lis)
 			if err != nil {
 				log.Error(err.Error())
@@ -130,6 +163,7 @@ func setServerConfig(
 	monitor *prometheus.Registry,
 	flightRecorder *flight_trace.Recorder,
 	cfg *config.Config,
+	opts ...ServerOption,
 ) (*server, error) {
 	cfg.SetDefault("GRPC_SERVER_PORT", "50051") // gRPC port
 	grpcPort := cfg.GetInt("GRPC_SERVER_PORT")
@@ -145,9 +179,20 @@ func setServerConfig(
 		cfg: cfg,
 	}
 
+	for _, opt := range opts {
+		opt(srv)
+	}
+
 	srv.WithLogger(log)
 	srv.WithTracer(tracer)
+	srv.WithVersionTrailer()
 	srv.WithAuthHeaders()
+
+	err := srv.WithAuthJWT()
+	if err != nil {
+		return nil, err
+	}
+
 	srv.WithAuthForward()
 	srv.WithPprofLabels()
 	srv.WithFlightTrace(flightRecorder, log)
@@ -155,8 +200,23 @@ func setServerConfig(
 	if monitor != nil {
 		srv.WithMetrics(monitor)
 		srv.WithRecovery(monitor)
+
+		err := srv.WithStreamMetrics(monitor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Outermost first; see InterceptorLogger and friends for the available names.
+	err = srv.applyInterceptorOrder(parseInterceptorOrder(cfg.GetStringSlice("GRPC_SERVER_INTERCEPTOR_ORDER")))
+	if err != nil {
+		return nil, err
 	}
 
+	// Application interceptors always run innermost, right before the handler.
+	srv.interceptorUnaryServerList = append(srv.interceptorUnaryServerList, srv.customUnary...)
+	srv.interceptorStreamServerList = append(srv.interceptorStreamServerList, srv.customStream...)
+
 	srv.optionsNewServer = append(srv.optionsNewServer,
 		// Initialize your gRPC server's interceptor.
 		grpc.ChainUnaryInterceptor(srv.interceptorUnaryServerList...),
@@ -164,7 +224,7 @@ func setServerConfig(
 	)
 
 	// NOTE: made after initialize your gRPC server's interceptor.
-	err := srv.WithTLS()
+	err = srv.WithTLS()
 	if err != nil {
 		return nil, err
 	}
@@ -183,16 +243,25 @@ func (s *server) WithMetrics(prom *prometheus.Registry) {
 
 	exemplarFromCtx := grpc_prometheus.WithExemplarFromContext(exemplarFromContext)
 
-	s.interceptorUnaryServerList = append(
-		s.interceptorUnaryServerList,
+	s.addInterceptor(
+		InterceptorMetrics,
 		s.serverMetrics.UnaryServerInterceptor(exemplarFromCtx),
-	)
-	s.interceptorStreamServerList = append(
-		s.interceptorStreamServerList,
 		s.serverMetrics.StreamServerInterceptor(exemplarFromCtx),
 	)
 }
 
+// WithStreamMetrics - setup per-stream message count and lifetime metrics.
+func (s *server) WithStreamMetrics(prom *prometheus.Registry) error {
+	metrics, err := streammetrics.New(prom)
+	if err != nil {
+		return fmt.Errorf("failed to register stream metrics: %w", err)
+	}
+
+	s.addInterceptor(InterceptorStreamMetrics, nil, metrics.StreamServerInterceptor())
+
+	return nil
+}
+
 // WithTracer - setup tracing.
 func (s *server) WithTracer(tracer trace.TracerProvider) {
 	if tracer == nil {
@@ -225,12 +294,9 @@ func (s *server) WithRecovery(prom *prometheus.Registry) {
 
 	// Recovery handlers should typically be last in the chain so that other middleware
 	// (e.g., logging) can operate in the recovered state instead of being directly affected by any panic
-	s.interceptorUnaryServerList = append(
-		s.interceptorUnaryServerList,
+	s.addInterceptor(
+		InterceptorRecovery,
 		grpc_recovery.UnaryServerInterceptor(recoveryHandler),
-	)
-	s.interceptorStreamServerList = append(
-		s.interceptorStreamServerList,
 		grpc_recovery.StreamServerInterceptor(recoveryHandler),
 	)
 }
@@ -241,23 +307,52 @@ func (s *server) WithLogger(log logger.Logger) {
 	isEnableLogger := s.cfg.GetBool("GRPC_SERVER_LOGGER_ENABLED")
 
 	if isEnableLogger {
-		s.interceptorStreamServerList = append(s.interceptorStreamServerList, grpc_logger.StreamServerInterceptor(log))
-		s.interceptorUnaryServerList = append(s.interceptorUnaryServerList, grpc_logger.UnaryServerInterceptor(log))
+		s.cfg.SetDefault("GRPC_SERVER_LOGGER_PAYLOADS", false)             // Log stream messages at DEBUG (type, size, redacted fields)
+		s.cfg.SetDefault("GRPC_SERVER_LOGGER_METADATA_KEYS", "user-agent") // Incoming metadata logged per RPC; never list credentials
+
+		loggerCfg := grpc_logger.InterceptorConfig{
+			LogPayloads:  s.cfg.GetBool("GRPC_SERVER_LOGGER_PAYLOADS"),
+			RedactFields: s.cfg.GetStringSliceCSV("GRPC_SERVER_LOGGER_REDACT_FIELDS"),
+			MetadataKeys: s.cfg.GetStringSliceCSV("GRPC_SERVER_LOGGER_METADATA_KEYS"),
+		}
+
+		s.addInterceptor(
+			InterceptorLogger,
+			grpc_logger.UnaryServerInterceptorWithConfig(log, loggerCfg),
+			grpc_logger.StreamServerInterceptorWithConfig(log, loggerCfg),
+		)
 	}
 }
 
 // WithTLS - setup TLS.
+//
+// With GRPC_SERVER_MTLS_ENABLED the server also requires a client certificate
+// signed by the CA bundle at GRPC_SERVER_CLIENT_CA_PATH; mTLS implies TLS.
 func (s *server) WithTLS() error {
 	s.cfg.SetDefault("GRPC_SERVER_TLS_ENABLED", false) // gRPC tls
 	isEnableTLS := s.cfg.GetBool("GRPC_SERVER_TLS_ENABLED")
 
+	s.cfg.SetDefault("GRPC_SERVER_MTLS_ENABLED", false) // gRPC mTLS: require client certificates
+	isEnableMTLS := s.cfg.GetBool("GRPC_SERVER_MTLS_ENABLED")
+
 	s.cfg.SetDefault("GRPC_SERVER_CERT_PATH", "ops/cert/shortlink-server.pem") // gRPC server cert
 	certFile := s.cfg.GetString("GRPC_SERVER_CERT_PATH")
 
 	s.cfg.SetDefault("GRPC_SERVER_KEY_PATH", "ops/cert/shortlink-server-key.pem") // gRPC server key
 	keyFile := s.cfg.GetString("GRPC_SERVER_KEY_PATH")
 
-	if isEnableTLS {
+	s.cfg.SetDefault("GRPC_SERVER_CLIENT_CA_PATH", "ops/cert/intermediate_ca.pem") // gRPC client CA bundle
+	clientCAFile := s.cfg.GetString("GRPC_SERVER_CLIENT_CA_PATH")
+
+	switch {
+	case isEnableMTLS:
+		creds, err := newServerMTLSCredentials(certFile, keyFile, clientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to setup mTLS: %w", err)
+		}
+
+		s.optionsNewServer = append(s.optionsNewServer, grpc.Creds(creds))
+	case isEnableTLS:
 		creds, errTLSFromFile := credentials.NewServerTLSFromFile(certFile, keyFile)
 		if errTLSFromFile != nil {
 			return fmt.Errorf("failed to setup TLS: %w", errTLSFromFile)
@@ -269,6 +364,27 @@ func (s *server) WithTLS() error {
 	return nil
 }
 
+// newServerMTLSCredentials builds transport credentials that present the server
+// certificate and require client certificates signed by the CA bundle at caFile.
+func newServerMTLSCredentials(certFile, keyFile, caFile string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	pool, err := loadCertPool(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
 // WithAuthHeaders - map Istio outputClaimToHeaders into context.
 func (s *server) WithAuthHeaders() {
 	s.cfg.SetDefault("GRPC_AUTH_HEADERS_ENABLED", true)
@@ -277,13 +393,14 @@ func (s *server) WithAuthHeaders() {
 		return
 	}
 
-	s.interceptorUnaryServerList = append(
-		s.interceptorUnaryServerList,
-		session_interceptor.SessionUnaryServerInterceptor(),
-	)
-	s.interceptorStreamServerList = append(
-		s.interceptorStreamServerList,
-		session_interceptor.SessionStreamServerInterceptor(),
+	interceptorCfg := session_interceptor.InterceptorConfig{
+		SkipMethods: s.cfg.GetStringSliceCSV("GRPC_SESSION_SKIP_METHODS"),
+	}
+
+	s.addInterceptor(
+		InterceptorAuthHeaders,
+		session_interceptor.SessionUnaryServerInterceptorWithConfig(interceptorCfg),
+		session_interceptor.SessionStreamServerInterceptorWithConfig(interceptorCfg),
 	)
 }
 
@@ -300,19 +417,29 @@ func (s *server) WithAuthJWT() error {
 	s.cfg.SetDefault("GRPC_AUTH_JWKS_HTTP_TIMEOUT", "10s")
 	s.cfg.SetDefault("GRPC_AUTH_JWKS_BACKOFF_MIN", "500ms")
 	s.cfg.SetDefault("GRPC_AUTH_JWKS_BACKOFF_MAX", "30s")
+	s.cfg.SetDefault("GRPC_AUTH_JWKS_PREFETCH", false)
 	s.cfg.SetDefault("GRPC_AUTH_JWT_LEEWAY", "30s")
 
+	// Report every missing key at boot rather than the first one NewValidator hits.
+	err := s.cfg.Require(authJWTRequiredKeys(s.cfg)...)
+	if err != nil {
+		return fmt.Errorf("auth jwt: %w", err)
+	}
+
 	validator, err := authjwt.NewValidator(authjwt.ValidatorConfig{
-		JWKSURL:         s.cfg.GetString("GRPC_AUTH_JWKS_URL"),
-		Issuer:          s.cfg.GetString("GRPC_AUTH_JWT_ISSUER"),
-		Audience:        s.cfg.GetString("GRPC_AUTH_JWT_AUDIENCE"),
-		SkipAudience:    s.cfg.GetBool("GRPC_AUTH_JWT_SKIP_AUDIENCE"),
-		SkipIssuer:      s.cfg.GetBool("GRPC_AUTH_JWT_SKIP_ISSUER"),
-		Leeway:          s.cfg.GetDuration("GRPC_AUTH_JWT_LEEWAY"),
-		JWKSCacheTTL:    s.cfg.GetDuration("GRPC_AUTH_JWKS_CACHE_TTL"),
-		JWKSHTTPTimeout: s.cfg.GetDuration("GRPC_AUTH_JWKS_HTTP_TIMEOUT"),
-		JWKSBackoffMin:  s.cfg.GetDuration("GRPC_AUTH_JWKS_BACKOFF_MIN"),
-		JWKSBackoffMax:  s.cfg.GetDuration("GRPC_AUTH_JWKS_BACKOFF_MAX"),
+		JWKSURL:           s.cfg.GetString("GRPC_AUTH_JWKS_URL"),
+		Issuer:            s.cfg.GetString("GRPC_AUTH_JWT_ISSUER"),
+		Audience:          s.cfg.GetString("GRPC_AUTH_JWT_AUDIENCE"),
+		SkipAudience:      s.cfg.GetBool("GRPC_AUTH_JWT_SKIP_AUDIENCE"),
+		SkipIssuer:        s.cfg.GetBool("GRPC_AUTH_JWT_SKIP_ISSUER"),
+		Leeway:            s.cfg.GetDuration("GRPC_AUTH_JWT_LEEWAY"),
+		JWKSCacheTTL:      s.cfg.GetDuration("GRPC_AUTH_JWKS_CACHE_TTL"),
+		JWKSHTTPTimeout:   s.cfg.GetDuration("GRPC_AUTH_JWKS_HTTP_TIMEOUT"),
+		JWKSBackoffMin:    s.cfg.GetDuration("GRPC_AUTH_JWKS_BACKOFF_MIN"),
+		JWKSBackoffMax:    s.cfg.GetDuration("GRPC_AUTH_JWKS_BACKOFF_MAX"),
+		JWKSPrefetch:      s.cfg.GetBool("GRPC_AUTH_JWKS_PREFETCH"),
+		AllowedAlgorithms: s.cfg.GetStringSliceCSV("GRPC_AUTH_JWT_ALLOWED_ALGORITHMS"),
+		Logger:            s.log,
 	})
 	if err != nil {
 		return err
@@ -320,44 +447,71 @@ func (s *server) WithAuthJWT() error {
 
 	s.authValidator = validator
 
-	s.interceptorUnaryServerList = append(
-		s.interceptorUnaryServerList,
-		authjwt.UnaryServerInterceptor(validator, authjwt.InterceptorConfig{Logger: s.log}),
-	)
-	s.interceptorStreamServerList = append(
-		s.interceptorStreamServerList,
-		authjwt.StreamServerInterceptor(validator, authjwt.InterceptorConfig{Logger: s.log}),
+	interceptorCfg := authjwt.InterceptorConfig{
+		SkipMethods: s.cfg.GetStringSliceCSV("GRPC_AUTH_JWT_SKIP_METHODS"),
+		Logger:      s.log,
+	}
+
+	s.addInterceptor(
+		InterceptorAuthJWT,
+		authjwt.UnaryServerInterceptor(validator, interceptorCfg),
+		authjwt.StreamServerInterceptor(validator, interceptorCfg),
 	)
 
 	return nil
 }
 
+// authJWTRequiredKeys lists the settings WithAuthJWT cannot start without.
+func authJWTRequiredKeys(cfg *config.Config) []string {
+	keys := []string{"GRPC_AUTH_JWKS_URL"}
+
+	if !cfg.GetBool("GRPC_AUTH_JWT_SKIP_ISSUER") {
+		keys = append(keys, "GRPC_AUTH_JWT_ISSUER")
+	}
+
+	if !cfg.GetBool("GRPC_AUTH_JWT_SKIP_AUDIENCE") {
+		keys = append(keys, "GRPC_AUTH_JWT_AUDIENCE")
+	}
+
+	return keys
+}
+
+// WithVersionTrailer - set the x-service-version trailer on every response.
+func (s *server) WithVersionTrailer() {
+	s.cfg.SetDefault("GRPC_SERVER_VERSION_TRAILER_ENABLED", false)
+
+	if !s.cfg.GetBool("GRPC_SERVER_VERSION_TRAILER_ENABLED") {
+		return
+	}
+
+	version := version_interceptor.Resolve(s.cfg.GetString("SERVICE_VERSION"))
+
+	s.addInterceptor(
+		InterceptorVersion,
+		version_interceptor.UnaryServerInterceptor(version),
+		version_interceptor.StreamServerInterceptor(version),
+	)
+}
+
 // WithAuthForward - capture validated token for downstream forwarding.
 func (s *server) WithAuthForward() {
-	s.interceptorUnaryServerList = append(
-		s.interceptorUnaryServerList,
+	s.addInterceptor(
+		InterceptorAuthForward,
 		authforward.UnaryServerInterceptor(),
-	)
-	s.interceptorStreamServerList = append(
-		s.interceptorStreamServerList,
 		authforward.StreamServerInterceptor(),
 	)
 }
 
 // WithPprofLabels - setup pprof labels.
 func (s *server) WithPprofLabels() {
-	s.interceptorUnaryServerList = append(s.interceptorUnaryServerList, pprof_interceptor.UnaryServerInterceptor())
-	s.interceptorStreamServerList = append(s.interceptorStreamServerList, pprof_interceptor.StreamServerInterceptor())
+	s.addInterceptor(InterceptorPprof, pprof_interceptor.UnaryServerInterceptor(), pprof_interceptor.StreamServerInterceptor())
 }
 
 // WithFlightTrace - setup flight trace.
 func (s *server) WithFlightTrace(flightRecorder *flight_trace.Recorder, log logger.Logger) {
-	s.interceptorUnaryServerList = append(
-		s.interceptorUnaryServerList,
+	s.addInterceptor(
+		InterceptorFlightTrace,
 		flight_trace_interceptor.UnaryServerInterceptor(flightRecorder, log, s.cfg),
-	)
-	s.interceptorStreamServerList = append(
-		s.interceptorStreamServerList,
 		flight_trace_interceptor.StreamServerInterceptor(flightRecorder, log, s.cfg),
 	)
 }
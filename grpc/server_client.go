@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// ClientOptions returns client options derived from the server configuration:
+// logging, tracing, metrics, auth forwarding, the GRPC_CLIENT_TIMEOUT timeout and
+// GRPC_SERVER_TLS_ENABLED as the default for GRPC_CLIENT_TLS_ENABLED.
+// Extra options are applied after the derived ones.
+func (s *Server) ClientOptions(options ...Option) []Option {
+	derived := make([]Option, 0, len(s.clientOptions)+len(options))
+	derived = append(derived, s.clientOptions...)
+
+	return append(derived, options...)
+}
+
+// NewClient connects to the downstream configured by GRPC_CLIENT_HOST and
+// GRPC_CLIENT_PORT using ClientOptions, so services that both serve and call
+// gRPC keep one config block for both sides.
+func (s *Server) NewClient(ctx context.Context, options ...Option) (*grpc.ClientConn, func(), error) {
+	return InitClient(ctx, s.log, s.cfg, s.ClientOptions(options...)...)
+}
+
+// clientOptions - mirror the server interceptors on the client side.
+func (s *server) clientOptions(tracer trace.TracerProvider, prom *prometheus.Registry) []Option {
+	s.cfg.SetDefault("GRPC_SERVER_TLS_ENABLED", false)
+
+	options := []Option{
+		withTLSDefault(s.cfg.GetBool("GRPC_SERVER_TLS_ENABLED")),
+		WithLogger(s.log),
+		withTracerProvider(tracer),
+		WithTimeout(),
+		WithAuthForward(),
+	}
+
+	if prom != nil {
+		options = append(options, WithMetrics(prom))
+	}
+
+	return options
+}
+
+// withTLSDefault sets the default for GRPC_CLIENT_TLS_ENABLED.
+func withTLSDefault(enabled bool) Option {
+	return func(client *Client) {
+		client.tlsDefault = enabled
+	}
+}
+
+// withTracerProvider wires up the otel client handler without metrics,
+// matching the server's WithTracer.
+func withTracerProvider(tracer trace.TracerProvider) Option {
+	return func(client *Client) {
+		if tracer == nil {
+			return
+		}
+
+		client.optionsNewClient = append(
+			client.optionsNewClient,
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithTracerProvider(tracer))),
+		)
+	}
+}
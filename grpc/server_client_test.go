@@ -0,0 +1,96 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/shortlink-org/go-sdk/config"
+	"github.com/shortlink-org/go-sdk/grpc/authforward"
+	"github.com/shortlink-org/go-sdk/logger"
+)
+
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	lis, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	port := lis.Addr().(*net.TCPAddr).Port
+	require.NoError(t, lis.Close())
+
+	return port
+}
+
+func TestServer_NewClient(t *testing.T) {
+	// Downstream records the metadata of the last unary call.
+	incoming := make(chan metadata.MD, 1)
+	downstream := grpc.NewServer(grpc.UnaryInterceptor(
+		func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			incoming <- md
+
+			return handler(ctx, req)
+		},
+	))
+	healthpb.RegisterHealthServer(downstream, health.NewServer())
+
+	lis, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() { _ = downstream.Serve(lis) }()
+
+	t.Cleanup(downstream.Stop)
+
+	t.Setenv("GRPC_SERVER_HOST", "127.0.0.1")
+	t.Setenv("GRPC_SERVER_PORT", strconv.Itoa(freePort(t)))
+	t.Setenv("GRPC_CLIENT_HOST", "127.0.0.1")
+	t.Setenv("GRPC_CLIENT_PORT", strconv.Itoa(lis.Addr().(*net.TCPAddr).Port))
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	log, err := logger.New(logger.Configuration{Writer: io.Discard})
+	require.NoError(t, err)
+
+	spans := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	srv, err := InitServer(ctx, log, tracer, prometheus.NewRegistry(), nil, cfg)
+	require.NoError(t, err)
+
+	conn, cleanup, err := srv.NewClient(ctx)
+	require.NoError(t, err)
+
+	t.Cleanup(cleanup)
+
+	callCtx, callCancel := context.WithTimeout(authforward.WithToken(ctx, "token"), 5*time.Second)
+	defer callCancel()
+
+	_, err = healthpb.NewHealthClient(conn).Check(callCtx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	md := <-incoming
+	require.Equal(t, []string{"token"}, md.Get("authorization"))
+
+	ended := spans.Ended()
+	require.Len(t, ended, 1)
+	require.Equal(t, trace.SpanKindClient, ended[0].SpanKind())
+	require.Equal(t, healthpb.Health_Check_FullMethodName[1:], ended[0].Name())
+}
@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"google.golang.org/grpc"
+)
+
+// ServerOption configures InitServer beyond what the GRPC_SERVER_* settings cover.
+type ServerOption func(*server)
+
+// WithUnaryInterceptors appends application interceptors (e.g. authz or tenant
+// resolution) to the unary chain. They run innermost, in the given order: after
+// every built-in interceptor including recovery, right before the handler, so
+// they see authenticated requests and their panics are recovered with the
+// default GRPC_SERVER_INTERCEPTOR_ORDER.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) ServerOption {
+	return func(s *server) {
+		s.customUnary = append(s.customUnary, interceptors...)
+	}
+}
+
+// WithStreamInterceptors is WithUnaryInterceptors for streaming RPCs.
+func WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) ServerOption {
+	return func(s *server) {
+		s.customStream = append(s.customStream, interceptors...)
+	}
+}
@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/shortlink-org/go-sdk/config"
+	"github.com/shortlink-org/go-sdk/logger"
+)
+
+func TestWithUnaryInterceptors(t *testing.T) {
+	lis, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	port := lis.Addr().(*net.TCPAddr).Port
+	require.NoError(t, lis.Close())
+
+	t.Setenv("GRPC_SERVER_HOST", "127.0.0.1")
+	t.Setenv("GRPC_SERVER_PORT", strconv.Itoa(port))
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	log, err := logger.New(logger.Configuration{Writer: io.Discard})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	var (
+		method   atomic.Value
+		panicked atomic.Bool
+	)
+
+	record := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		method.Store(info.FullMethod)
+
+		return handler(ctx, req)
+	}
+	// Runs after recovery, so its panic becomes codes.Internal instead of crashing the server.
+	panicOnce := func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if panicked.CompareAndSwap(false, true) {
+			panic("boom")
+		}
+
+		return handler(ctx, req)
+	}
+
+	srv, err := InitServer(ctx, log, nil, prometheus.NewRegistry(), nil, cfg,
+		WithUnaryInterceptors(record, panicOnce),
+	)
+	require.NoError(t, err)
+
+	healthpb.RegisterHealthServer(srv.Server, health.NewServer())
+
+	go srv.Run()
+	<-srv.Ready()
+
+	conn, err := grpc.NewClient(srv.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	callCtx, callCancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer callCancel()
+
+	client := healthpb.NewHealthClient(conn)
+
+	_, err = client.Check(callCtx, &healthpb.HealthCheckRequest{})
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	_, err = client.Check(callCtx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.Health_Check_FullMethodName, method.Load())
+}
+
+func TestWithStreamInterceptorsAppendsInnermost(t *testing.T) {
+	log, err := logger.New(logger.Configuration{Writer: io.Discard})
+	require.NoError(t, err)
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	custom := func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, ss)
+	}
+
+	srv, err := setServerConfig(log, nil, nil, nil, cfg, WithStreamInterceptors(custom))
+	require.NoError(t, err)
+
+	// Built-ins come first in the configured order; the application interceptor is last.
+	require.Len(t, srv.interceptorStreamServerList, len(srv.interceptorOrder)+1)
+	require.Len(t, srv.interceptorUnaryServerList, len(srv.interceptorOrder))
+}
@@ -0,0 +1,215 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/shortlink-org/go-sdk/config"
+	"github.com/shortlink-org/go-sdk/logger"
+)
+
+// startMTLSServer runs a health server configured through WithTLS with mTLS enabled.
+func startMTLSServer(t *testing.T, ca testCA) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.pem")
+	writePEM(t, caPath, "CERTIFICATE", ca.der)
+
+	certPath, keyPath := ca.issue(t, dir, "server", &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "test-server"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	})
+
+	t.Setenv("GRPC_SERVER_MTLS_ENABLED", "true")
+	t.Setenv("GRPC_SERVER_CERT_PATH", certPath)
+	t.Setenv("GRPC_SERVER_KEY_PATH", keyPath)
+	t.Setenv("GRPC_SERVER_CLIENT_CA_PATH", caPath)
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	srv := &server{cfg: cfg}
+	require.NoError(t, srv.WithTLS())
+
+	grpcServer := grpc.NewServer(srv.optionsNewServer...)
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+
+	lis, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() { _ = grpcServer.Serve(lis) }()
+
+	t.Cleanup(grpcServer.Stop)
+
+	return lis.Addr().String()
+}
+
+func checkHealth(t *testing.T, addr string, creds credentials.TransportCredentials) error {
+	t.Helper()
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+
+	return err
+}
+
+func TestWithTLS_MTLS(t *testing.T) {
+	ca := newTestCA(t)
+	addr := startMTLSServer(t, ca)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+
+	t.Run("client without certificate is rejected", func(t *testing.T) {
+		creds := credentials.NewTLS(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12})
+
+		require.Error(t, checkHealth(t, addr, creds))
+	})
+
+	t.Run("client with certificate from another CA is rejected", func(t *testing.T) {
+		files := writeTestCerts(t)
+
+		cert, err := tls.LoadX509KeyPair(files.certPath, files.keyPath)
+		require.NoError(t, err)
+
+		creds := credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{cert},
+			RootCAs:      pool,
+			MinVersion:   tls.VersionTLS12,
+		})
+
+		require.Error(t, checkHealth(t, addr, creds))
+	})
+
+	t.Run("client with valid certificate connects", func(t *testing.T) {
+		certPath, keyPath := ca.issue(t, t.TempDir(), "client", &x509.Certificate{
+			SerialNumber: big.NewInt(4),
+			Subject:      pkix.Name{CommonName: "test-client"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		require.NoError(t, err)
+
+		creds := credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{cert},
+			RootCAs:      pool,
+			MinVersion:   tls.VersionTLS12,
+		})
+
+		require.NoError(t, checkHealth(t, addr, creds))
+	})
+}
+
+func TestWithTLS_MTLSMissingCA(t *testing.T) {
+	files := writeTestCerts(t)
+
+	t.Setenv("GRPC_SERVER_MTLS_ENABLED", "true")
+	t.Setenv("GRPC_SERVER_CERT_PATH", files.certPath)
+	t.Setenv("GRPC_SERVER_KEY_PATH", files.keyPath)
+	t.Setenv("GRPC_SERVER_CLIENT_CA_PATH", files.keyPath)
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	srv := &server{cfg: cfg}
+	require.ErrorIs(t, srv.WithTLS(), ErrNoCACertificates)
+}
+
+func TestInitServer_Ready(t *testing.T) {
+	lis, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	port := lis.Addr().(*net.TCPAddr).Port
+	require.NoError(t, lis.Close())
+
+	t.Setenv("GRPC_SERVER_HOST", "127.0.0.1")
+	t.Setenv("GRPC_SERVER_PORT", strconv.Itoa(port))
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	log, err := logger.New(logger.Configuration{Writer: io.Discard})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	srv, err := InitServer(ctx, log, nil, prometheus.NewRegistry(), nil, cfg)
+	require.NoError(t, err)
+
+	healthpb.RegisterHealthServer(srv.Server, health.NewServer())
+
+	select {
+	case <-srv.Ready():
+		t.Fatal("Ready closed before Run")
+	default:
+	}
+
+	go srv.Run()
+
+	select {
+	case <-srv.Ready():
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not become ready")
+	}
+
+	require.NoError(t, checkHealth(t, srv.Endpoint, insecure.NewCredentials()))
+}
+
+func TestSetServerConfig_AuthJWTRequiredKeys(t *testing.T) {
+	log, err := logger.New(logger.Configuration{Writer: io.Discard})
+	require.NoError(t, err)
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+	t.Cleanup(cfg.Reset)
+
+	cfg.Set("GRPC_AUTH_JWT_ENABLED", true)
+	cfg.Set("GRPC_AUTH_JWT_ISSUER", "https://auth.example.com")
+
+	_, err = setServerConfig(log, nil, nil, nil, cfg)
+	require.ErrorIs(t, err, config.ErrMissingKeys)
+	require.ErrorContains(t, err, "GRPC_AUTH_JWKS_URL, GRPC_AUTH_JWT_AUDIENCE")
+
+	cfg.Set("GRPC_AUTH_JWKS_URL", "https://auth.example.com/.well-known/jwks.json")
+	cfg.Set("GRPC_AUTH_JWT_SKIP_AUDIENCE", true)
+
+	srv, err := setServerConfig(log, nil, nil, nil, cfg)
+	require.NoError(t, err)
+	require.Contains(t, srv.interceptorOrder, InterceptorAuthJWT)
+	require.NoError(t, srv.authValidator.Close())
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/shortlink-org/go-sdk/http/client/internal/types"
 	"github.com/shortlink-org/go-sdk/http/client/middleware/deadline"
+	"github.com/shortlink-org/go-sdk/http/client/middleware/metrics"
 	"github.com/shortlink-org/go-sdk/http/client/middleware/metrics429"
 	"github.com/shortlink-org/go-sdk/http/client/middleware/otelwait"
 	"github.com/shortlink-org/go-sdk/http/client/middleware/serverlimit"
@@ -41,8 +42,20 @@ func New(opts ...Option) (*http.Client, error) {
 	}
 
 	base := cfg.base
+
+	if cfg.dnsCache != nil {
+		transport, ok := base.(*http.Transport)
+		if !ok {
+			return nil, ErrDNSCacheTransport
+		}
+
+		transport = transport.Clone()
+		transport.DialContext = cfg.dnsCache.Dialer(transport.DialContext)
+		base = transport
+	}
+
 	if cfg.hedgeEnabled {
-		base = hedge.New(cfg.base, cfg.hedgeOpts...)
+		base = hedge.New(base, cfg.hedgeOpts...)
 	}
 
 	// OTEL HTTP wrapping for propagation
@@ -75,6 +88,10 @@ func New(opts ...Option) (*http.Client, error) {
 			Metrics: cfg.metrics,
 			Client:  cfg.clientName,
 		}),
+		metrics.Middleware(metrics.Config{
+			Metrics: cfg.metrics,
+			Client:  cfg.clientName,
+		}),
 	)
 
 	client := new(http.Client)
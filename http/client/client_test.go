@@ -3,13 +3,19 @@ package http_client
 import (
 	"context"
 	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	io_prometheus_client "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/http/client/middleware/dnscache"
 )
 
 func TestClientRecords429Metrics(t *testing.T) {
@@ -95,3 +101,53 @@ func matchLabels(metricLabels []*io_prometheus_client.LabelPair, expected map[st
 
 	return true
 }
+
+type countingResolver struct {
+	addr    string
+	lookups atomic.Int32
+}
+
+func (r *countingResolver) LookupHost(context.Context, string) ([]string, error) {
+	r.lookups.Add(1)
+
+	return []string{r.addr}, nil
+}
+
+func TestClientDNSCacheReusesAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	host, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+
+	resolver := &countingResolver{addr: host}
+
+	client, err := New(
+		// Without keep-alives every request dials, so every request resolves.
+		WithBaseTransport(&http.Transport{DisableKeepAlives: true}),
+		WithDNSCache(dnscache.New(dnscache.Config{Resolver: resolver, TTL: time.Minute})),
+	)
+	require.NoError(t, err)
+
+	for range 2 {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://service.test:"+port+"/", http.NoBody)
+		require.NoError(t, err)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	require.Equal(t, int32(1), resolver.lookups.Load())
+}
+
+func TestClientDNSCacheRequiresHTTPTransport(t *testing.T) {
+	_, err := New(
+		WithBaseTransport(RoundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, nil })),
+		WithDNSCache(dnscache.New(dnscache.Config{})),
+	)
+	require.ErrorIs(t, err, ErrDNSCacheTransport)
+}
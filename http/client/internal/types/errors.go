@@ -5,4 +5,6 @@ import "errors"
 var (
 	ErrInvalidLimiterConfig = errors.New("http_client: invalid limiter config")
 	ErrDeadlineTooClose     = errors.New("http_client: deadline too close")
+	ErrWouldExceedMaxWait   = errors.New("http_client: limiter wait would exceed max wait")
+	ErrDNSCacheTransport    = errors.New("http_client: dns cache requires an *http.Transport base")
 )
@@ -34,12 +34,15 @@ const (
 	LabelHost   = "host"
 	LabelMethod = "method"
 	LabelSource = "source"
+	LabelStatus = "status"
 )
 
 type Metrics struct {
 	RateLimitWaitSeconds   *prometheus.HistogramVec
 	RateLimit429Total      *prometheus.CounterVec
 	DeadlineCancelledTotal *prometheus.CounterVec
+	RequestsTotal          *prometheus.CounterVec
+	ResponseBytes          *prometheus.HistogramVec
 }
 
 func NewMetrics(namespace, subsystem string) *Metrics {
@@ -72,6 +75,25 @@ func NewMetrics(namespace, subsystem string) *Metrics {
 			},
 			[]string{LabelClient, LabelHost, LabelMethod},
 		),
+		RequestsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{ //nolint:exhaustruct // Prometheus options have many optional fields
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "requests_total",
+				Help:      "Total number of HTTP request attempts by response status (\"error\" for transport failures).",
+			},
+			[]string{LabelClient, LabelHost, LabelMethod, LabelStatus},
+		),
+		ResponseBytes: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{ //nolint:exhaustruct // Prometheus options have many optional fields
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "response_bytes",
+				Help:      "Size of HTTP response bodies read by the caller.",
+				Buckets:   prometheus.ExponentialBuckets(100, 10, 6), //nolint:mnd // 100B .. 10MB
+			},
+			[]string{LabelClient, LabelHost, LabelMethod},
+		),
 	}
 }
 
@@ -91,5 +113,15 @@ func (m *Metrics) Register(reg prometheus.Registerer) error {
 		return fmt.Errorf("register deadline_canceled: %w", err)
 	}
 
+	err = reg.Register(m.RequestsTotal)
+	if err != nil {
+		return fmt.Errorf("register requests_total: %w", err)
+	}
+
+	err = reg.Register(m.ResponseBytes)
+	if err != nil {
+		return fmt.Errorf("register response_bytes: %w", err)
+	}
+
 	return nil
 }
@@ -2,6 +2,7 @@ package http_client
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"sync"
 	"time"
@@ -12,6 +13,9 @@ import (
 // Limiter is an alias for types.Limiter for backward compatibility.
 type Limiter = types.Limiter
 
+// ErrWouldExceedMaxWait is returned by WaitWithMax when the next token is further away than the budget.
+var ErrWouldExceedMaxWait = types.ErrWouldExceedMaxWait
+
 type tokenBucketLimiter struct {
 	mu sync.Mutex
 
@@ -66,12 +70,7 @@ func (l *tokenBucketLimiter) Wait(ctx context.Context) (time.Duration, error) {
 			return total, nil
 		}
 
-		need := 1 - l.tokens
-		sec := need / l.rate
-
-		wait := max(time.Duration(sec*float64(time.Second)), time.Millisecond)
-
-		wait = l.jitter(wait)
+		wait := l.jitter(l.waitFor(1 - l.tokens))
 
 		l.mu.Unlock()
 
@@ -89,6 +88,54 @@ func (l *tokenBucketLimiter) Wait(ctx context.Context) (time.Duration, error) {
 	}
 }
 
+// WaitWithMax is like Wait for latency-budgeted callers: when the wait for the next
+// token exceeds maxWait it returns that wait and ErrWouldExceedMaxWait immediately,
+// without sleeping or taking a token. Within budget the token is reserved before
+// sleeping, so concurrent callers cannot push this wait past maxWait.
+func (l *tokenBucketLimiter) WaitWithMax(ctx context.Context, maxWait time.Duration) (time.Duration, error) {
+	l.mu.Lock()
+	l.refill(time.Now())
+
+	if l.tokens >= 1 {
+		l.tokens -= 1
+		l.mu.Unlock()
+
+		return 0, nil
+	}
+
+	wait := l.jitter(l.waitFor(1 - l.tokens))
+	if wait > maxWait {
+		l.mu.Unlock()
+
+		return wait, fmt.Errorf("%w: need %s, max %s", ErrWouldExceedMaxWait, wait, maxWait)
+	}
+
+	// Reserve the token; Wait callers see the debt and wait for it to be repaid.
+	l.tokens -= 1
+	l.mu.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens += 1
+		l.mu.Unlock()
+
+		return wait, ctx.Err()
+	case <-timer.C:
+		return wait, nil
+	}
+}
+
+// waitFor returns how long the bucket needs to refill need tokens, at least a millisecond.
+func (l *tokenBucketLimiter) waitFor(need float64) time.Duration {
+	sec := need / l.rate
+
+	return max(time.Duration(sec*float64(time.Second)), time.Millisecond)
+}
+
 func (l *tokenBucketLimiter) refill(now time.Time) {
 	elapsed := now.Sub(l.last).Seconds()
 	if elapsed <= 0 {
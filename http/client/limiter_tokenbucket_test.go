@@ -188,3 +188,45 @@ func TestTokenBucketLimiter_ConcurrentAccess(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func TestTokenBucketLimiter_WaitWithMax_WithinBudget(t *testing.T) {
+	limiter, err := NewTokenBucketLimiter(20, 1, 0) // one token every 50ms
+	require.NoError(t, err)
+
+	wait, err := limiter.WaitWithMax(context.Background(), time.Second)
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(0), wait)
+
+	start := time.Now()
+	wait, err = limiter.WaitWithMax(context.Background(), time.Second)
+	require.NoError(t, err)
+	require.Positive(t, wait)
+	require.LessOrEqual(t, wait, time.Second)
+	require.GreaterOrEqual(t, time.Since(start), wait)
+}
+
+func TestTokenBucketLimiter_WaitWithMax_OverBudget(t *testing.T) {
+	limiter, err := NewTokenBucketLimiter(testSlowRatePerSec, 1, 0) // one token every 10s
+	require.NoError(t, err)
+
+	_, err = limiter.Wait(context.Background())
+	require.NoError(t, err)
+
+	// Freeze refills so the bucket holds exactly zero tokens across the call.
+	limiter.mu.Lock()
+	limiter.tokens = 0
+	limiter.last = time.Now().Add(time.Hour)
+	limiter.mu.Unlock()
+
+	start := time.Now()
+	wait, err := limiter.WaitWithMax(context.Background(), 100*time.Millisecond)
+	require.ErrorIs(t, err, ErrWouldExceedMaxWait)
+	require.Greater(t, wait, 100*time.Millisecond)
+	require.Less(t, time.Since(start), 50*time.Millisecond, "over-budget waits must not sleep")
+
+	// The rejected call did not take the next token.
+	limiter.mu.Lock()
+	tokens := limiter.tokens
+	limiter.mu.Unlock()
+	require.Zero(t, tokens)
+}
@@ -16,4 +16,5 @@ const (
 	LabelHost   = types.LabelHost
 	LabelMethod = types.LabelMethod
 	LabelSource = types.LabelSource
+	LabelStatus = types.LabelStatus
 )
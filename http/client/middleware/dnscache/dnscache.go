@@ -0,0 +1,264 @@
+// Package dnscache caches host lookups for outgoing connections. It plugs in
+// at the transport level as a dial wrapper, so every request that opens a new
+// connection resolves through the cache instead of hitting the resolver.
+package dnscache
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultTTL is how long a successful lookup is served from the cache.
+	DefaultTTL = 30 * time.Second
+	// DefaultNegativeTTL is how long a "host not found" answer is served from the cache.
+	DefaultNegativeTTL = 5 * time.Second
+	// DefaultLookupTimeout bounds a single resolver call.
+	DefaultLookupTimeout = 5 * time.Second
+
+	// refreshRetryDelay spaces out background refreshes of an entry whose last refresh failed.
+	refreshRetryDelay = time.Second
+	// minDialTimeout is the least time an address gets from a shared deadline, as in net.Dialer.
+	minDialTimeout = 2 * time.Second
+)
+
+// Resolver looks up the addresses of a host. *net.Resolver implements it.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// DialFunc matches http.Transport.DialContext.
+type DialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// Config configures a Cache. The zero value uses the defaults above.
+type Config struct {
+	// Resolver answers cache misses (default: net.DefaultResolver).
+	Resolver Resolver
+	// TTL is how long a successful lookup is cached (default: 30s).
+	TTL time.Duration
+	// NegativeTTL is how long a "host not found" answer is cached (default: 5s).
+	// A negative value disables negative caching.
+	NegativeTTL time.Duration
+	// RefreshBefore refreshes a cached entry in the background when it is hit
+	// this long before it expires (default: TTL/5), capped at half the TTL.
+	RefreshBefore time.Duration
+	// LookupTimeout bounds a single resolver call, which is shared by concurrent
+	// callers and outlives the one that started it (default: 5s).
+	LookupTimeout time.Duration
+}
+
+// Cache is a DNS cache safe for concurrent use. Entries are kept per host for
+// the lifetime of the Cache, so share one Cache per set of clients.
+type Cache struct {
+	cfg Config
+
+	mu      sync.RWMutex
+	entries map[string]entry
+
+	group singleflight.Group
+}
+
+type entry struct {
+	addrs     []string
+	err       error
+	refreshAt time.Time
+	expiresAt time.Time
+}
+
+// New creates a Cache.
+func New(cfg Config) *Cache {
+	if cfg.Resolver == nil {
+		cfg.Resolver = net.DefaultResolver
+	}
+
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultTTL
+	}
+
+	if cfg.NegativeTTL == 0 {
+		cfg.NegativeTTL = DefaultNegativeTTL
+	}
+
+	if cfg.RefreshBefore <= 0 {
+		cfg.RefreshBefore = cfg.TTL / 5 //nolint:mnd // refresh in the last 20% of the TTL
+	}
+
+	cfg.RefreshBefore = min(cfg.RefreshBefore, cfg.TTL/2)
+
+	if cfg.LookupTimeout <= 0 {
+		cfg.LookupTimeout = DefaultLookupTimeout
+	}
+
+	return &Cache{
+		cfg:     cfg,
+		entries: make(map[string]entry),
+	}
+}
+
+// LookupHost returns the cached addresses of host, resolving it on a miss or
+// after expiry. Entries hit within RefreshBefore of expiry are served as-is
+// and refreshed in the background. Concurrent misses share one lookup; each
+// caller still returns as soon as its own ctx is done.
+func (c *Cache) LookupHost(ctx context.Context, host string) ([]string, error) {
+	c.mu.RLock()
+	cached, ok := c.entries[host]
+	c.mu.RUnlock()
+
+	now := time.Now()
+
+	if ok && now.Before(cached.expiresAt) {
+		if cached.err == nil && !now.Before(cached.refreshAt) {
+			c.group.DoChan(host, func() (any, error) {
+				return c.resolve(context.WithoutCancel(ctx), host)
+			})
+		}
+
+		return cached.addrs, cached.err
+	}
+
+	// The shared lookup must outlive the caller that started it.
+	result := c.group.DoChan(host, func() (any, error) {
+		return c.resolve(context.WithoutCancel(ctx), host)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+
+		addrs, _ := res.Val.([]string)
+
+		return addrs, nil
+	}
+}
+
+func (c *Cache) resolve(ctx context.Context, host string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.LookupTimeout)
+	defer cancel()
+
+	addrs, err := c.cfg.Resolver.LookupHost(ctx, host)
+	now := time.Now()
+
+	switch {
+	case err == nil:
+		c.store(host, entry{
+			addrs:     addrs,
+			refreshAt: now.Add(c.cfg.TTL - c.cfg.RefreshBefore),
+			expiresAt: now.Add(c.cfg.TTL),
+		})
+	case isNotFound(err) && c.cfg.NegativeTTL > 0:
+		c.store(host, entry{
+			err:       err,
+			expiresAt: now.Add(c.cfg.NegativeTTL),
+		})
+	}
+
+	// Other failures (timeouts, unreachable resolvers) are not cached; an
+	// entry that is still valid keeps being served until it expires, and its
+	// next background refresh waits refreshRetryDelay.
+	if err != nil && !isNotFound(err) {
+		c.deferRefresh(host, now.Add(refreshRetryDelay))
+	}
+
+	return addrs, err
+}
+
+func (c *Cache) deferRefresh(host string, refreshAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[host]; ok && e.err == nil {
+		e.refreshAt = refreshAt
+		c.entries[host] = e
+	}
+}
+
+func (c *Cache) store(host string, e entry) {
+	c.mu.Lock()
+	c.entries[host] = e
+	c.mu.Unlock()
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// Dialer wraps next so that host names are resolved through the cache. The
+// cached addresses are tried in order until one connects; like net.Dialer,
+// each attempt gets an equal share of the time left before the ctx deadline.
+// IP literals and addresses without a port are passed to next unchanged.
+func (c *Cache) Dialer(next DialFunc) DialFunc {
+	if next == nil {
+		next = (&net.Dialer{}).DialContext //nolint:exhaustruct // zero dialer matches net.Dial
+	}
+
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil || net.ParseIP(host) != nil {
+			return next(ctx, network, address)
+		}
+
+		addrs, err := c.LookupHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		var errs []error
+
+		for i, addr := range addrs {
+			conn, dialErr := dialPartial(ctx, next, network, net.JoinHostPort(addr, port), len(addrs)-i)
+			if dialErr == nil {
+				return conn, nil
+			}
+
+			errs = append(errs, dialErr)
+
+			if ctx.Err() != nil {
+				break
+			}
+		}
+
+		if len(errs) == 0 {
+			return nil, &net.DNSError{Err: "no addresses", Name: host, IsNotFound: true} //nolint:exhaustruct // only the relevant fields
+		}
+
+		return nil, errors.Join(errs...)
+	}
+}
+
+// dialPartial dials address with a deadline that leaves time for the
+// addrsRemaining-1 addresses after it.
+func dialPartial(ctx context.Context, next DialFunc, network, address string, addrsRemaining int) (net.Conn, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok || addrsRemaining <= 1 {
+		return next(ctx, network, address)
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, partialDeadline(time.Now(), deadline, addrsRemaining))
+	defer cancel()
+
+	return next(ctx, network, address)
+}
+
+// partialDeadline splits the time until deadline evenly across addrsRemaining
+// addresses, giving each at least minDialTimeout while that much is left.
+func partialDeadline(now, deadline time.Time, addrsRemaining int) time.Time {
+	remaining := deadline.Sub(now)
+	if remaining <= 0 {
+		return deadline
+	}
+
+	timeout := max(remaining/time.Duration(addrsRemaining), min(remaining, minDialTimeout))
+
+	return now.Add(timeout)
+}
@@ -0,0 +1,270 @@
+package dnscache
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// stubResolver answers from a fixed table and counts lookups per host.
+type stubResolver struct {
+	mu      sync.Mutex
+	hosts   map[string][]string
+	lookups map[string]int
+	block   chan struct{}
+	err     error
+}
+
+func newStubResolver(hosts map[string][]string) *stubResolver {
+	return &stubResolver{hosts: hosts, lookups: make(map[string]int)}
+}
+
+func (r *stubResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.mu.Lock()
+	r.lookups[host]++
+	addrs, ok := r.hosts[host]
+	block, err := r.block, r.err
+	r.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if block != nil {
+		select {
+		case <-block:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	return addrs, nil
+}
+
+func (r *stubResolver) count(host string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.lookups[host]
+}
+
+func (r *stubResolver) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.err = err
+}
+
+func (r *stubResolver) set(host string, addrs ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.hosts[host] = addrs
+}
+
+func TestLookupHost_CachesWithinTTL(t *testing.T) {
+	resolver := newStubResolver(map[string][]string{"api.test": {"10.0.0.1"}})
+	cache := New(Config{Resolver: resolver, TTL: time.Minute})
+
+	for range 3 {
+		addrs, err := cache.LookupHost(context.Background(), "api.test")
+		require.NoError(t, err)
+		require.Equal(t, []string{"10.0.0.1"}, addrs)
+	}
+
+	require.Equal(t, 1, resolver.count("api.test"))
+}
+
+func TestLookupHost_NegativeCache(t *testing.T) {
+	resolver := newStubResolver(map[string][]string{})
+	cache := New(Config{Resolver: resolver, NegativeTTL: time.Minute})
+
+	for range 2 {
+		_, err := cache.LookupHost(context.Background(), "missing.test")
+
+		var dnsErr *net.DNSError
+		require.ErrorAs(t, err, &dnsErr)
+		require.True(t, dnsErr.IsNotFound)
+	}
+
+	require.Equal(t, 1, resolver.count("missing.test"))
+}
+
+func TestLookupHost_NegativeCacheDisabled(t *testing.T) {
+	resolver := newStubResolver(map[string][]string{})
+	cache := New(Config{Resolver: resolver, NegativeTTL: -1})
+
+	for range 2 {
+		_, err := cache.LookupHost(context.Background(), "missing.test")
+		require.Error(t, err)
+	}
+
+	require.Equal(t, 2, resolver.count("missing.test"))
+}
+
+func TestLookupHost_RespectsDeadline(t *testing.T) {
+	resolver := newStubResolver(map[string][]string{"slow.test": {"10.0.0.1"}})
+	resolver.block = make(chan struct{})
+	t.Cleanup(func() { close(resolver.block) })
+
+	cache := New(Config{Resolver: resolver})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := cache.LookupHost(ctx, "slow.test")
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestLookupHost_RefreshesInBackground(t *testing.T) {
+	resolver := newStubResolver(map[string][]string{"api.test": {"10.0.0.1"}})
+	// RefreshBefore is capped at TTL/2.
+	cache := New(Config{Resolver: resolver, TTL: 2 * time.Second, RefreshBefore: time.Hour})
+
+	_, err := cache.LookupHost(context.Background(), "api.test")
+	require.NoError(t, err)
+
+	resolver.set("api.test", "10.0.0.2")
+	time.Sleep(1100 * time.Millisecond)
+
+	// The entry is inside its refresh window: the cached answer is served
+	// immediately while the new one is fetched in the background.
+	addrs, err := cache.LookupHost(context.Background(), "api.test")
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.1"}, addrs)
+
+	require.Eventually(t, func() bool {
+		return resolver.count("api.test") == 2
+	}, time.Second, 5*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		addrs, err := cache.LookupHost(context.Background(), "api.test")
+
+		return err == nil && len(addrs) == 1 && addrs[0] == "10.0.0.2"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestLookupHost_FailedRefreshIsNotRetriedOnEveryHit(t *testing.T) {
+	resolver := newStubResolver(map[string][]string{"api.test": {"10.0.0.1"}})
+	cache := New(Config{Resolver: resolver, TTL: 2 * time.Second, RefreshBefore: time.Second})
+
+	_, err := cache.LookupHost(context.Background(), "api.test")
+	require.NoError(t, err)
+
+	resolver.fail(errors.New("resolver unreachable"))
+	time.Sleep(1100 * time.Millisecond)
+
+	_, err = cache.LookupHost(context.Background(), "api.test")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return resolver.count("api.test") == 2
+	}, time.Second, 5*time.Millisecond)
+
+	// The failed refresh is retried after refreshRetryDelay, not on each hit.
+	for range 5 {
+		addrs, err := cache.LookupHost(context.Background(), "api.test")
+		require.NoError(t, err)
+		require.Equal(t, []string{"10.0.0.1"}, addrs)
+	}
+
+	require.Never(t, func() bool {
+		return resolver.count("api.test") != 2
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestDialer_SplitsDeadlineAcrossAddresses(t *testing.T) {
+	resolver := newStubResolver(map[string][]string{"api.test": {"10.0.0.1", "10.0.0.2"}})
+	cache := New(Config{Resolver: resolver})
+
+	var deadlines []time.Time
+
+	dial := cache.Dialer(func(ctx context.Context, _, _ string) (net.Conn, error) {
+		deadline, _ := ctx.Deadline()
+		deadlines = append(deadlines, deadline)
+
+		return nil, errors.New("refused")
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	deadline, _ := ctx.Deadline()
+
+	_, err := dial(ctx, "tcp", "api.test:443")
+	require.Error(t, err)
+	require.Len(t, deadlines, 2)
+
+	// The first address gets half of the time left, the last one all of it.
+	require.WithinDuration(t, deadline.Add(-5*time.Second), deadlines[0], time.Second)
+	require.Equal(t, deadline, deadlines[1])
+}
+
+func TestPartialDeadline(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		remaining time.Duration
+		addrs     int
+		want      time.Duration
+	}{
+		{name: "even split", remaining: 9 * time.Second, addrs: 3, want: 3 * time.Second},
+		{name: "minimum per address", remaining: 3 * time.Second, addrs: 3, want: minDialTimeout},
+		{name: "less than the minimum left", remaining: time.Second, addrs: 3, want: time.Second},
+		{name: "deadline passed", remaining: -time.Second, addrs: 2, want: -time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := partialDeadline(now, now.Add(tt.remaining), tt.addrs)
+			require.Equal(t, now.Add(tt.want), got)
+		})
+	}
+}
+
+func TestDialer_TriesAddressesInOrder(t *testing.T) {
+	resolver := newStubResolver(map[string][]string{"api.test": {"10.0.0.1", "10.0.0.2"}})
+	cache := New(Config{Resolver: resolver})
+
+	errRefused := errors.New("refused")
+
+	var dialed []string
+
+	dial := cache.Dialer(func(_ context.Context, _, address string) (net.Conn, error) {
+		dialed = append(dialed, address)
+
+		if address == "10.0.0.2:443" {
+			client, server := net.Pipe()
+			t.Cleanup(func() {
+				_ = client.Close()
+				_ = server.Close()
+			})
+
+			return client, nil
+		}
+
+		return nil, errRefused
+	})
+
+	conn, err := dial(context.Background(), "tcp", "api.test:443")
+	require.NoError(t, err)
+	require.NotNil(t, conn)
+	require.Equal(t, []string{"10.0.0.1:443", "10.0.0.2:443"}, dialed)
+
+	// IP literals bypass the cache.
+	_, err = dial(context.Background(), "tcp", "10.0.0.9:443")
+	require.ErrorIs(t, err, errRefused)
+	require.Equal(t, 1, resolver.count("api.test"))
+}
@@ -0,0 +1,84 @@
+// Package metrics records client-side request outcomes: attempts by status
+// and response body sizes.
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/shortlink-org/go-sdk/http/client/internal/types"
+)
+
+// statusError labels attempts that failed before a response was received.
+const statusError = "error"
+
+type Config struct {
+	Metrics *types.Metrics
+	Client  string
+}
+
+// Middleware counts every RoundTrip call, so placed below a retrying
+// middleware it records each attempt separately.
+func Middleware(cfg Config) types.Middleware {
+	if cfg.Metrics == nil {
+		return func(next http.RoundTripper) http.RoundTripper { return next }
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return types.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				cfg.Metrics.RequestsTotal.
+					WithLabelValues(cfg.Client, req.URL.Host, req.Method, statusError).
+					Inc()
+
+				return nil, err
+			}
+
+			cfg.Metrics.RequestsTotal.
+				WithLabelValues(cfg.Client, req.URL.Host, req.Method, strconv.Itoa(resp.StatusCode)).
+				Inc()
+
+			if resp.Body != nil && resp.Body != http.NoBody {
+				resp.Body = &countingBody{
+					ReadCloser: resp.Body,
+					observe: func(n int64) {
+						cfg.Metrics.ResponseBytes.
+							WithLabelValues(cfg.Client, req.URL.Host, req.Method).
+							Observe(float64(n))
+					},
+				}
+			}
+
+			return resp, nil
+		})
+	}
+}
+
+// countingBody reports the number of bytes read once the body hits EOF or is closed.
+type countingBody struct {
+	io.ReadCloser
+
+	n       int64
+	once    sync.Once
+	observe func(int64)
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+
+	if err == io.EOF {
+		b.once.Do(func() { b.observe(b.n) })
+	}
+
+	return n, err //nolint:wrapcheck // io.Reader contract requires the unwrapped error
+}
+
+func (b *countingBody) Close() error {
+	b.once.Do(func() { b.observe(b.n) })
+
+	return b.ReadCloser.Close() //nolint:wrapcheck // pass through the underlying body error
+}
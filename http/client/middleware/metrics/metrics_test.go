@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/http/client/internal/types"
+)
+
+const counterDeltaEpsilon = 1e-9
+
+func newMetrics(t *testing.T) (*types.Metrics, *prometheus.Registry) {
+	t.Helper()
+
+	metrics := types.NewMetrics("test", "client")
+	reg := prometheus.NewRegistry()
+	require.NoError(t, metrics.Register(reg))
+
+	return metrics, reg
+}
+
+func TestMetricsMiddleware_RecordsStatus(t *testing.T) {
+	metrics, reg := newMetrics(t)
+
+	next := types.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp := new(http.Response)
+		resp.Body = io.NopCloser(strings.NewReader("internal error"))
+		resp.StatusCode = http.StatusInternalServerError
+
+		return resp, nil
+	})
+
+	transport := Middleware(Config{Metrics: metrics, Client: "test-client"})(next)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://api.example.com/resource", http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	require.InDelta(t, 1.0, testutil.ToFloat64(
+		metrics.RequestsTotal.WithLabelValues("test-client", "api.example.com", http.MethodPost, "500"),
+	), counterDeltaEpsilon)
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	var found bool
+
+	for _, mf := range families {
+		if mf.GetName() == "test_client_response_bytes" {
+			found = true
+
+			require.Len(t, mf.GetMetric(), 1)
+			require.Equal(t, uint64(1), mf.GetMetric()[0].GetHistogram().GetSampleCount())
+			require.InDelta(t, float64(len(body)), mf.GetMetric()[0].GetHistogram().GetSampleSum(), counterDeltaEpsilon)
+		}
+	}
+
+	require.True(t, found, "response size should be recorded")
+}
+
+func TestMetricsMiddleware_CountsEachAttempt(t *testing.T) {
+	metrics, _ := newMetrics(t)
+
+	attempts := 0
+	next := types.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("connection reset")
+		}
+
+		resp := new(http.Response)
+		resp.Body = http.NoBody
+		resp.StatusCode = http.StatusOK
+
+		return resp, nil
+	})
+
+	transport := Middleware(Config{Metrics: metrics, Client: "test-client"})(next)
+
+	// Naive retry on top of the metrics middleware.
+	retry := types.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			return transport.RoundTrip(req)
+		}
+
+		return resp, nil
+	})
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://example.com", http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := retry.RoundTrip(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	require.InDelta(t, 1.0, testutil.ToFloat64(
+		metrics.RequestsTotal.WithLabelValues("test-client", "example.com", http.MethodGet, "error"),
+	), counterDeltaEpsilon)
+	require.InDelta(t, 1.0, testutil.ToFloat64(
+		metrics.RequestsTotal.WithLabelValues("test-client", "example.com", http.MethodGet, "200"),
+	), counterDeltaEpsilon)
+}
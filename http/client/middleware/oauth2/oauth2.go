@@ -0,0 +1,226 @@
+// Package oauth2 authenticates outgoing requests with an OAuth2
+// client-credentials token that is cached and refreshed before it expires.
+package oauth2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/shortlink-org/go-sdk/http/client/internal/types"
+)
+
+const (
+	// DefaultRefreshBefore is how long before expiry a cached token is refreshed.
+	DefaultRefreshBefore = 30 * time.Second
+	// DefaultRefreshTimeout bounds a single token request.
+	DefaultRefreshTimeout = 10 * time.Second
+	// refreshRetryDelay spaces out background refreshes after a failed one.
+	refreshRetryDelay = 5 * time.Second
+	// maxTokenBodySize is the maximum size of a token endpoint response (1MB).
+	maxTokenBodySize = 1 << 20
+)
+
+var (
+	// ErrTokenRequest is returned when the token endpoint cannot issue a token.
+	ErrTokenRequest = errors.New("http_client/oauth2: token request failed")
+	// ErrEmptyToken is returned when the token endpoint response has no access_token.
+	ErrEmptyToken = errors.New("http_client/oauth2: empty access token")
+)
+
+type Config struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// HTTPClient calls the token endpoint (default: http.DefaultClient).
+	// It must not use this middleware.
+	HTTPClient *http.Client
+	// RefreshBefore refreshes the token this long before it expires (default: 30s),
+	// capped at half the token lifetime.
+	RefreshBefore time.Duration
+	// RefreshTimeout bounds each token request (default: 10s). The request is
+	// shared by concurrent callers, so it does not follow any caller's context.
+	RefreshTimeout time.Duration
+}
+
+// Middleware sets "Authorization: Bearer <token>" on every request. The token
+// is fetched with the client-credentials grant and cached. From RefreshBefore
+// its expiry it is refreshed in the background while requests keep using the
+// cached token; only once it has expired do requests wait for a new one.
+// Concurrent refreshes share one token request. When no valid token can be
+// fetched, the request fails with ErrTokenRequest.
+func Middleware(cfg Config) types.Middleware {
+	source := newTokenSource(cfg)
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return types.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			token, err := source.token(req.Context())
+			if err != nil {
+				return nil, err
+			}
+
+			// RoundTrippers must not modify the caller's request.
+			authorized := req.Clone(req.Context())
+			authorized.Header.Set("Authorization", "Bearer "+token)
+
+			return next.RoundTrip(authorized)
+		})
+	}
+}
+
+// tokenSource caches the access token and coalesces refreshes.
+type tokenSource struct {
+	cfg Config
+
+	mu          sync.RWMutex
+	accessToken string
+	refreshAt   time.Time
+	expiresAt   time.Time
+
+	group singleflight.Group
+}
+
+func newTokenSource(cfg Config) *tokenSource {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+
+	if cfg.RefreshBefore <= 0 {
+		cfg.RefreshBefore = DefaultRefreshBefore
+	}
+
+	if cfg.RefreshTimeout <= 0 {
+		cfg.RefreshTimeout = DefaultRefreshTimeout
+	}
+
+	return &tokenSource{cfg: cfg}
+}
+
+func (s *tokenSource) token(ctx context.Context) (string, error) {
+	s.mu.RLock()
+	accessToken, refreshAt, expiresAt := s.accessToken, s.refreshAt, s.expiresAt
+	s.mu.RUnlock()
+
+	now := time.Now()
+
+	// The shared fetch must outlive the caller that started it.
+	fetch := func() (any, error) {
+		return s.fetch(context.WithoutCancel(ctx))
+	}
+
+	if accessToken != "" && (expiresAt.IsZero() || now.Before(expiresAt)) {
+		if !refreshAt.IsZero() && !now.Before(refreshAt) {
+			// Still valid: refresh in the background and keep serving it.
+			s.group.DoChan("token", fetch)
+		}
+
+		return accessToken, nil
+	}
+
+	result := s.group.DoChan("token", fetch)
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return "", res.Err
+		}
+
+		token, _ := res.Val.(string)
+
+		return token, nil
+	}
+}
+
+// tokenResponse is the RFC 6749 section 5.1 access token response.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"` //nolint:tagliatelle // RFC 6749 uses snake_case keys.
+	TokenType   string `json:"token_type"`   //nolint:tagliatelle // RFC 6749 uses snake_case keys.
+	ExpiresIn   int64  `json:"expires_in"`   //nolint:tagliatelle // RFC 6749 uses snake_case keys.
+}
+
+// fetch refreshes the token. After a failure the next background refresh is
+// delayed, so requests served from a still valid token do not retry on every call.
+func (s *tokenSource) fetch(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.RefreshTimeout)
+	defer cancel()
+
+	token, err := s.refresh(ctx)
+	if err != nil {
+		s.mu.Lock()
+		s.refreshAt = time.Now().Add(refreshRetryDelay)
+		s.mu.Unlock()
+	}
+
+	return token, err
+}
+
+func (s *tokenSource) refresh(ctx context.Context) (string, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.cfg.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("%w: create request: %w", ErrTokenRequest, err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(s.cfg.ClientID), url.QueryEscape(s.cfg.ClientSecret))
+
+	start := time.Now()
+
+	resp, err := s.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrTokenRequest, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close() //nolint:errcheck // response body close
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: unexpected status %d", ErrTokenRequest, resp.StatusCode)
+	}
+
+	var body tokenResponse
+
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxTokenBodySize)).Decode(&body)
+	if err != nil {
+		return "", fmt.Errorf("%w: decode response: %w", ErrTokenRequest, err)
+	}
+
+	if body.AccessToken == "" {
+		return "", fmt.Errorf("%w: %w", ErrTokenRequest, ErrEmptyToken)
+	}
+
+	// A token without expires_in is kept until the process restarts. Short-lived
+	// tokens are refreshed at half their lifetime at the latest.
+	var refreshAt, expiresAt time.Time
+	if body.ExpiresIn > 0 {
+		lifetime := time.Duration(body.ExpiresIn) * time.Second
+		refreshAt = start.Add(lifetime - min(s.cfg.RefreshBefore, lifetime/2))
+		expiresAt = start.Add(lifetime)
+	}
+
+	s.mu.Lock()
+	s.accessToken = body.AccessToken
+	s.refreshAt = refreshAt
+	s.expiresAt = expiresAt
+	s.mu.Unlock()
+
+	return body.AccessToken, nil
+}
@@ -0,0 +1,215 @@
+package oauth2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTokenServer issues token-1, token-2, ... valid for expiresIn seconds.
+func newTokenServer(t *testing.T, expiresIn int64) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var issued atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "client" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		if r.FormValue("grant_type") != "client_credentials" || r.FormValue("scope") != "read write" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		n := issued.Add(1)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(tokenResponse{
+			AccessToken: fmt.Sprintf("token-%d", n),
+			TokenType:   "Bearer",
+			ExpiresIn:   expiresIn,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &issued
+}
+
+func newClient(t *testing.T, tokenURL string) (*http.Client, *httptest.Server) {
+	t.Helper()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	t.Cleanup(api.Close)
+
+	transport := Middleware(Config{
+		TokenURL:     tokenURL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Scopes:       []string{"read", "write"},
+	})(http.DefaultTransport)
+
+	return &http.Client{Transport: transport}, api
+}
+
+func authorization(t *testing.T, client *http.Client, url string) string {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	var body [64]byte
+	n, _ := resp.Body.Read(body[:])
+
+	require.Empty(t, req.Header.Get("Authorization"), "caller request must not be modified")
+
+	return string(body[:n])
+}
+
+func TestMiddleware_RefreshesShortLivedToken(t *testing.T) {
+	tokens, issued := newTokenServer(t, 1)
+	client, api := newClient(t, tokens.URL)
+
+	require.Equal(t, "Bearer token-1", authorization(t, client, api.URL))
+	require.Equal(t, "Bearer token-1", authorization(t, client, api.URL))
+	require.Equal(t, int32(1), issued.Load())
+
+	// A one-second token is refreshed in the background after half its lifetime,
+	// while the still valid token keeps being served.
+	time.Sleep(600 * time.Millisecond)
+
+	require.Equal(t, "Bearer token-1", authorization(t, client, api.URL))
+	require.Eventually(t, func() bool {
+		return authorization(t, client, api.URL) == "Bearer token-2"
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, int32(2), issued.Load())
+}
+
+func TestMiddleware_ServesCachedTokenDuringSlowRefresh(t *testing.T) {
+	release := make(chan struct{})
+
+	var issued atomic.Int32
+
+	tokens := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := issued.Add(1)
+		if n > 1 {
+			<-release
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(tokenResponse{AccessToken: fmt.Sprintf("token-%d", n), ExpiresIn: 1})
+	}))
+	t.Cleanup(tokens.Close)
+	// Runs before tokens.Close, which waits for the hanging refresh.
+	t.Cleanup(func() { close(release) })
+
+	client, api := newClient(t, tokens.URL)
+
+	require.Equal(t, "Bearer token-1", authorization(t, client, api.URL))
+
+	time.Sleep(600 * time.Millisecond)
+
+	// The refresh hangs, but token-1 has not expired yet.
+	for range 3 {
+		require.Equal(t, "Bearer token-1", authorization(t, client, api.URL))
+	}
+
+	require.Equal(t, int32(2), issued.Load(), "concurrent background refreshes must share one request")
+}
+
+func TestMiddleware_RefreshTimeout(t *testing.T) {
+	release := make(chan struct{})
+
+	tokens := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-release
+	}))
+	t.Cleanup(tokens.Close)
+	t.Cleanup(func() { close(release) })
+
+	api := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(api.Close)
+
+	transport := Middleware(Config{
+		TokenURL:       tokens.URL,
+		ClientID:       "client",
+		ClientSecret:   "secret",
+		RefreshTimeout: 50 * time.Millisecond,
+	})(http.DefaultTransport)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, api.URL, http.NoBody)
+	require.NoError(t, err)
+
+	start := time.Now()
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+
+	require.ErrorIs(t, err, ErrTokenRequest)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestMiddleware_CoalescesConcurrentRefreshes(t *testing.T) {
+	tokens, issued := newTokenServer(t, 3600)
+	client, api := newClient(t, tokens.URL)
+
+	var wg sync.WaitGroup
+
+	for range 20 {
+		wg.Go(func() {
+			require.Equal(t, "Bearer token-1", authorization(t, client, api.URL))
+		})
+	}
+
+	wg.Wait()
+
+	require.Equal(t, int32(1), issued.Load())
+}
+
+func TestMiddleware_RefreshFailureFailsRequest(t *testing.T) {
+	tokens := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(tokens.Close)
+
+	var called atomic.Bool
+
+	api := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		called.Store(true)
+	}))
+	t.Cleanup(api.Close)
+
+	client, _ := newClient(t, tokens.URL)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, api.URL, http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+
+	require.ErrorIs(t, err, ErrTokenRequest)
+	require.False(t, called.Load())
+}
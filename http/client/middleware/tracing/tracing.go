@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/shortlink-org/go-sdk/http/client/internal/types"
+)
+
+// Middleware starts a client span named "HTTP {method} {host}" for every outbound
+// request, nested under the caller's span from the request context, and injects the
+// global propagator headers. A nil tracer falls back to the global "http_client" tracer.
+func Middleware(tracer trace.Tracer) types.Middleware {
+	if tracer == nil {
+		tracer = otel.Tracer("http_client")
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return types.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ctx, span := tracer.Start(req.Context(), "HTTP "+req.Method+" "+req.URL.Host,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(requestAttributes(req)...),
+			)
+			defer span.End()
+
+			// RoundTrippers must not modify the caller's request.
+			req = req.Clone(ctx)
+			otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				span.SetAttributes(semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err)))
+
+				return resp, err
+			}
+
+			span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+
+			if resp.StatusCode >= http.StatusBadRequest {
+				span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+				span.SetAttributes(semconv.ErrorTypeKey.String(strconv.Itoa(resp.StatusCode)))
+			}
+
+			return resp, nil
+		})
+	}
+}
+
+func requestAttributes(req *http.Request) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLFull(req.URL.Redacted()),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+
+	if port, err := strconv.Atoi(req.URL.Port()); err == nil {
+		attrs = append(attrs, semconv.ServerPort(port))
+	}
+
+	return attrs
+}
@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/shortlink-org/go-sdk/http/client/internal/types"
+)
+
+func TestMiddleware_CreatesChildClientSpan(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := provider.Tracer("test")
+
+	var traceparent string
+
+	next := types.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		traceparent = req.Header.Get("traceparent")
+
+		resp := new(http.Response)
+		resp.Body = io.NopCloser(strings.NewReader("not found"))
+		resp.StatusCode = http.StatusNotFound
+
+		return resp, nil
+	})
+
+	ctx, parent := tracer.Start(context.Background(), "caller")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.example.com:8443/links?id=1", http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := Middleware(tracer)(next).RoundTrip(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	parent.End()
+
+	assert.Empty(t, req.Header.Get("traceparent"), "caller's request must not be modified")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	span := spans[0]
+	assert.Equal(t, "HTTP GET api.example.com:8443", span.Name())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Contains(t, traceparent, span.SpanContext().SpanID().String())
+
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+
+	assert.Equal(t, "GET", attrs["http.request.method"].AsString())
+	assert.Equal(t, "https://api.example.com:8443/links?id=1", attrs["url.full"].AsString())
+	assert.Equal(t, "api.example.com", attrs["server.address"].AsString())
+	assert.Equal(t, int64(8443), attrs["server.port"].AsInt64())
+	assert.Equal(t, int64(http.StatusNotFound), attrs["http.response.status_code"].AsInt64())
+}
+
+func TestMiddleware_RecordsTransportError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	next := types.RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, io.ErrUnexpectedEOF
+	})
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://example.com", http.NoBody)
+	require.NoError(t, err)
+
+	_, err = Middleware(provider.Tracer("test"))(next).RoundTrip(req) //nolint:bodyclose // no response on error
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Len(t, spans[0].Events(), 1)
+}
@@ -5,8 +5,15 @@ import (
 	"time"
 
 	"github.com/bhope/hedge"
+
+	"github.com/shortlink-org/go-sdk/http/client/internal/types"
+	"github.com/shortlink-org/go-sdk/http/client/middleware/dnscache"
 )
 
+// ErrDNSCacheTransport is returned by New when WithDNSCache is combined with a
+// base transport that is not an *http.Transport.
+var ErrDNSCacheTransport = types.ErrDNSCacheTransport
+
 type config struct {
 	clientName        string
 	rate              float64
@@ -18,6 +25,7 @@ type config struct {
 	base              http.RoundTripper
 	hedgeEnabled      bool
 	hedgeOpts         []hedge.Option
+	dnsCache          *dnscache.Cache
 }
 
 // Option configures an HTTP client during construction.
@@ -111,3 +119,15 @@ func WithHedge(opts ...hedge.Option) Option {
 		return nil
 	}
 }
+
+// WithDNSCache resolves host names through cache when the base transport
+// dials. The base transport must be an *http.Transport; it is cloned, and its
+// DialContext (or a default net.Dialer) dials the cached addresses.
+// Share one cache between clients to share entries; nil disables caching.
+func WithDNSCache(cache *dnscache.Cache) Option {
+	return func(c *config) error {
+		c.dnsCache = cache
+
+		return nil
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
-	"strings"
 
 	"github.com/shortlink-org/go-sdk/config"
 	"github.com/shortlink-org/go-sdk/logger"
@@ -18,6 +17,7 @@ func Middleware(loggerInstance logger.Logger, cfg *config.Config) func(http.Hand
 
 	// Configure trusted origins from environment variables
 	configureTrustedOrigins(antiCSRF, loggerInstance, cfg)
+	antiCSRF.SetDenyHandler(http.HandlerFunc(writeForbidden))
 
 	// Return a middleware function that wraps the handler with CSRF protection
 	return func(next http.Handler) http.Handler {
@@ -33,34 +33,35 @@ func configureTrustedOrigins(antiCSRF *http.CrossOriginProtection, loggerInstanc
 
 	// Get trusted origins from environment variable
 	envVarName := cfg.GetString("CSRF_TRUSTED_ORIGINS_ENV")
-	trustedOrigins := os.Getenv(envVarName)
+	trustedOrigins := config.SplitCSV(os.Getenv(envVarName))
 
 	// If not found in the direct env var, try viper config
-	if trustedOrigins == "" {
-		trustedOrigins = cfg.GetString("CSRF_TRUSTED_ORIGINS")
+	if len(trustedOrigins) == 0 {
+		trustedOrigins = cfg.GetStringSliceCSV("CSRF_TRUSTED_ORIGINS")
 	}
 
-	if trustedOrigins != "" {
-		origins := strings.SplitSeq(trustedOrigins, ",")
-		for origin := range origins {
-			trimmedOrigin := strings.TrimSpace(origin)
-			if trimmedOrigin != "" {
-				err := antiCSRF.AddTrustedOrigin(trimmedOrigin)
-				if err != nil {
-					loggerInstance.Error("CSRF trusted origin configuration error", slog.String("origin", trimmedOrigin), slog.Any("error", err))
-				} else {
-					loggerInstance.Info("CSRF trusted origin added", slog.String("origin", trimmedOrigin))
-				}
-			}
-		}
-	} else {
+	if len(trustedOrigins) == 0 {
 		loggerInstance.Info("No CSRF trusted origins configured. All cross-origin requests will be protected.")
+
+		return
+	}
+
+	for _, origin := range trustedOrigins {
+		err := antiCSRF.AddTrustedOrigin(origin)
+		if err != nil {
+			loggerInstance.Error("CSRF trusted origin configuration error", slog.String("origin", origin), slog.Any("error", err))
+		} else {
+			loggerInstance.Info("CSRF trusted origin added", slog.String("origin", origin))
+		}
 	}
 }
 
 // Config represents CSRF middleware configuration
 type Config struct {
 	TrustedOrigins []string
+	// OnForbidden writes the response for rejected cross-origin requests.
+	// Defaults to a JSON 403 with the "csrf_forbidden" error code.
+	OnForbidden http.Handler
 }
 
 // New creates a new CSRF middleware with custom configuration
@@ -77,7 +78,25 @@ func New(cfg Config) func(http.Handler) http.Handler {
 		}
 	}
 
+	onForbidden := cfg.OnForbidden
+	if onForbidden == nil {
+		onForbidden = http.HandlerFunc(writeForbidden)
+	}
+
+	antiCSRF.SetDenyHandler(onForbidden)
+
 	return func(next http.Handler) http.Handler {
 		return antiCSRF.Handler(next)
 	}
 }
+
+// writeForbidden writes a JSON 403 response.
+func writeForbidden(responseWriter http.ResponseWriter, _ *http.Request) {
+	responseWriter.Header().Set("Content-Type", "application/json")
+	responseWriter.WriteHeader(http.StatusForbidden)
+
+	_, writeErr := responseWriter.Write([]byte(`{"error":"csrf_forbidden","message":"cross-origin request rejected"}`))
+	if writeErr != nil {
+		return
+	}
+}
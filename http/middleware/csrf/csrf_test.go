@@ -199,6 +199,50 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewOnForbidden(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("next handler must not be called for an untrusted origin")
+	})
+
+	t.Run("custom_handler", func(t *testing.T) {
+		called := false
+
+		protectedHandler := New(Config{
+			TrustedOrigins: []string{"https://shortlink.best"},
+			OnForbidden: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+
+				w.WriteHeader(http.StatusForbidden)
+			}),
+		})(handler)
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/test", http.NoBody)
+		req.Header.Set("Origin", "https://malicious.com")
+
+		rr := httptest.NewRecorder()
+		protectedHandler.ServeHTTP(rr, req)
+
+		assert.True(t, called, "OnForbidden should be invoked")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("default_json_body", func(t *testing.T) {
+		protectedHandler := New(Config{
+			TrustedOrigins: []string{"https://shortlink.best"},
+		})(handler)
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/test", http.NoBody)
+		req.Header.Set("Origin", "https://malicious.com")
+
+		rr := httptest.NewRecorder()
+		protectedHandler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"error":"csrf_forbidden","message":"cross-origin request rejected"}`, rr.Body.String())
+	})
+}
+
 func TestConfigureTrustedOrigins(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,89 @@
+// Package drain_middleware rejects new requests while a server is draining,
+// so a pod can finish in-flight work before it shuts down.
+package drain_middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultRetryAfter is the Retry-After hint sent with 503 responses while draining.
+const DefaultRetryAfter = 5 * time.Second
+
+// ErrDraining is reported by Check once draining has begun.
+var ErrDraining = errors.New("http/drain: server is draining")
+
+// Option configures a Drainer.
+type Option func(*Drainer)
+
+// WithRetryAfter sets the Retry-After hint, rounded up to whole seconds.
+func WithRetryAfter(retryAfter time.Duration) Option {
+	return func(d *Drainer) {
+		d.retryAfter = retryAfterSeconds(retryAfter)
+	}
+}
+
+// Drainer switches a server into draining mode. It is safe for concurrent use.
+type Drainer struct {
+	draining   atomic.Bool
+	retryAfter string
+}
+
+// New creates a Drainer that serves requests until BeginDraining is called.
+func New(opts ...Option) *Drainer {
+	d := &Drainer{
+		retryAfter: retryAfterSeconds(DefaultRetryAfter),
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// BeginDraining makes the middleware answer new requests with 503 and the
+// readiness check fail. Requests already past the middleware are not affected.
+func (d *Drainer) BeginDraining() {
+	d.draining.Store(true)
+}
+
+// Draining reports whether BeginDraining has been called.
+func (d *Drainer) Draining() bool {
+	return d.draining.Load()
+}
+
+// Check is a readiness check that fails with ErrDraining once draining has begun.
+// It matches healthcheck.Check, e.g. monitoring.RegisterReadiness("drain", d.Check).
+func (d *Drainer) Check() error {
+	if d.Draining() {
+		return ErrDraining
+	}
+
+	return nil
+}
+
+// Middleware answers 503 Service Unavailable with a Retry-After header while
+// draining and closes the connection, so clients retry on another instance.
+func (d *Drainer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if d.Draining() {
+			w.Header().Set("Retry-After", d.retryAfter)
+			w.Header().Set("Connection", "close")
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func retryAfterSeconds(retryAfter time.Duration) string {
+	seconds := max(int64((retryAfter+time.Second-1)/time.Second), 1)
+
+	return strconv.FormatInt(seconds, 10)
+}
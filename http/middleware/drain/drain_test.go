@@ -0,0 +1,76 @@
+package drain_middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainer_RejectsNewRequestsWhileInFlightComplete(t *testing.T) {
+	drainer := New(WithRetryAfter(1500 * time.Millisecond))
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			started <- struct{}{}
+			<-release
+		}
+
+		_, _ = w.Write([]byte("done"))
+	})
+
+	srv := httptest.NewServer(drainer.Middleware(handler))
+	t.Cleanup(srv.Close)
+
+	require.NoError(t, drainer.Check())
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+
+	inFlight := make(chan result, 1)
+
+	go func() {
+		resp, err := http.Get(srv.URL + "/slow") //nolint:noctx // test request
+		if err != nil {
+			inFlight <- result{err: err}
+
+			return
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		inFlight <- result{status: resp.StatusCode, body: string(body), err: err}
+	}()
+
+	<-started
+	drainer.BeginDraining()
+
+	resp, err := http.Get(srv.URL + "/fast") //nolint:noctx // test request
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, "2", resp.Header.Get("Retry-After"))
+	require.ErrorIs(t, drainer.Check(), ErrDraining)
+
+	close(release)
+
+	res := <-inFlight
+	require.NoError(t, res.err)
+	require.Equal(t, http.StatusOK, res.status)
+	require.Equal(t, "done", res.body)
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	require.Equal(t, "1", retryAfterSeconds(0))
+	require.Equal(t, "1", retryAfterSeconds(time.Second))
+	require.Equal(t, "5", retryAfterSeconds(DefaultRetryAfter))
+}
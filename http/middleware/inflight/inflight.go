@@ -0,0 +1,35 @@
+package inflight_middleware
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewInflight returns a middleware that tracks the number of requests being served
+// in the http_server_inflight_requests gauge, registered with reg. The gauge is
+// decremented in a defer, so it stays accurate when a handler panics.
+func NewInflight(reg prometheus.Registerer) (func(next http.Handler) http.Handler, error) {
+	inflight := prometheus.NewGauge(prometheus.GaugeOpts{ //nolint:exhaustruct // Prometheus options intentionally use defaults
+		Name: "http_server_inflight_requests",
+		Help: "Number of HTTP requests currently being served.",
+	})
+
+	err := reg.Register(inflight)
+	if err != nil {
+		return nil, err
+	}
+
+	middleware := func(next http.Handler) http.Handler {
+		handlerFunc := func(w http.ResponseWriter, r *http.Request) {
+			inflight.Inc()
+			defer inflight.Dec()
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(handlerFunc)
+	}
+
+	return middleware, nil
+}
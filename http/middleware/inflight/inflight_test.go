@@ -0,0 +1,61 @@
+package inflight_middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewInflight(t *testing.T) {
+	const delta = 1e-9
+
+	reg := prometheus.NewRegistry()
+
+	middleware, err := NewInflight(reg)
+	require.NoError(t, err)
+
+	var during float64
+
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		during = gaugeValue(t, reg)
+
+		panic("boom")
+	}))
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/", http.NoBody)
+
+	require.Panics(t, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	})
+
+	require.InDelta(t, 1, during, delta)
+	require.InDelta(t, 0, gaugeValue(t, reg), delta)
+}
+
+func Test_NewInflightPerRegistry(t *testing.T) {
+	for range 2 {
+		_, err := NewInflight(prometheus.NewRegistry())
+		require.NoError(t, err)
+	}
+}
+
+func gaugeValue(t *testing.T, gatherer prometheus.Gatherer) float64 {
+	t.Helper()
+
+	families, err := gatherer.Gather()
+	require.NoError(t, err)
+
+	for _, mf := range families {
+		if mf.GetName() == "http_server_inflight_requests" {
+			return mf.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+
+	t.Fatal("http_server_inflight_requests is not registered")
+
+	return 0
+}
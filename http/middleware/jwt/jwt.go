@@ -2,6 +2,7 @@ package jwt_middleware
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -20,6 +21,9 @@ const (
 	tracerName = "github.com/shortlink-org/go-sdk/http/middleware/jwt"
 
 	authSchemeParts = 2 // "Bearer" + token from Authorization header
+
+	// DefaultClaimHeaderPrefix is the header prefix WithClaimHeaders uses when given an empty prefix.
+	DefaultClaimHeaderPrefix = "X-Jwt-Claim-"
 )
 
 // jwtMiddleware holds the middleware configuration.
@@ -28,6 +32,38 @@ type jwtMiddleware struct {
 	cfg        *config.Config
 	parser     *jwt.Parser
 	propagator propagation.TextMapPropagator
+	optional   bool
+	// claimHeaderPrefix switches the middleware to header mode when set.
+	claimHeaderPrefix string
+}
+
+// Option configures the JWT middleware.
+type Option func(*jwtMiddleware)
+
+// WithOptional lets requests with a missing or invalid token through without claims
+// instead of rejecting them. Use it for optional-auth routes that only enrich the
+// context when a user is signed in; combine with RequireAuth where authentication is mandatory.
+func WithOptional() Option {
+	return func(j *jwtMiddleware) {
+		j.optional = true
+	}
+}
+
+// WithClaimHeaders builds claims from headers forwarded by the mesh (Istio
+// outputClaimToHeaders) instead of parsing the Authorization token:
+// {prefix}Sub, {prefix}Email, {prefix}Name, {prefix}Identity-Id,
+// {prefix}Session-Id, {prefix}Iss, {prefix}Iat and {prefix}Exp.
+// An empty prefix means DefaultClaimHeaderPrefix. Requests without {prefix}Sub are rejected.
+//
+// Only use it behind a proxy that validates the token and strips these headers from client requests.
+func WithClaimHeaders(prefix string) Option {
+	return func(j *jwtMiddleware) {
+		if prefix == "" {
+			prefix = DefaultClaimHeaderPrefix
+		}
+
+		j.claimHeaderPrefix = prefix
+	}
 }
 
 // JWT creates a new JWT authentication middleware.
@@ -42,20 +78,40 @@ type jwtMiddleware struct {
 //
 // Trace propagation: This middleware extracts trace context from incoming headers
 // (traceparent, b3, uber-trace-id) to maintain distributed tracing across services.
-func JWT(cfg *config.Config) func(next http.Handler) http.Handler {
+func JWT(cfg *config.Config, opts ...Option) func(next http.Handler) http.Handler {
 	cfg.SetDefault("AUTH_LOGIN_URL", "/auth/login")
 
 	// Use composite propagator for W3C TraceContext and Baggage
 	prop := otel.GetTextMapPropagator()
 
-	return jwtMiddleware{
+	j := jwtMiddleware{
 		tracer: otel.Tracer(tracerName),
 		cfg:    cfg,
 		parser: jwt.NewParser(
 			jwt.WithoutClaimsValidation(), // Skip expiration validation (Oathkeeper handles it)
 		),
 		propagator: prop,
-	}.middleware
+	}
+
+	for _, opt := range opts {
+		opt(&j)
+	}
+
+	return j.middleware
+}
+
+// RequireAuth rejects requests whose context carries no session claims with 401.
+// Place it after JWT (typically in WithOptional mode) on routes that need an authenticated user.
+func RequireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(responseWriter http.ResponseWriter, req *http.Request) {
+		if _, err := session.GetClaims(req.Context()); err != nil {
+			writeUnauthorized(responseWriter)
+
+			return
+		}
+
+		next.ServeHTTP(responseWriter, req)
+	})
 }
 
 // oathkeeperClaims represents the JWT claims from Oathkeeper id_token mutator.
@@ -83,60 +139,29 @@ func (j jwtMiddleware) middleware(next http.Handler) http.Handler {
 		)
 		defer span.End()
 
-		// Extract token from Authorization header
-		tokenString := extractBearerToken(req)
-		if tokenString == "" {
-			span.SetStatus(codes.Error, "missing authorization header")
-			j.handleUnauthorized(responseWriter, req)
+		reject := func() {
+			if j.optional {
+				span.SetAttributes(attribute.Bool("auth.anonymous", true))
+				next.ServeHTTP(responseWriter, req.WithContext(ctx))
 
-			return
-		}
+				return
+			}
 
-		// Parse JWT without signature verification (we trust Oathkeeper)
-		token, _, err := j.parser.ParseUnverified(tokenString, &oathkeeperClaims{})
-		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
 			j.handleUnauthorized(responseWriter, req)
-
-			return
 		}
 
-		oathClaims, ok := token.Claims.(*oathkeeperClaims)
-		if !ok {
-			span.SetStatus(codes.Error, "invalid claims type")
-			j.handleUnauthorized(responseWriter, req)
-
-			return
+		claimsFrom := j.claimsFromToken
+		if j.claimHeaderPrefix != "" {
+			claimsFrom = j.claimsFromHeaders
 		}
 
-		// Validate subject is present
-		if oathClaims.Subject == "" {
-			span.SetStatus(codes.Error, "missing subject in token")
-			j.handleUnauthorized(responseWriter, req)
+		claims, ok := claimsFrom(req, span)
+		if !ok {
+			reject()
 
 			return
 		}
 
-		// Convert to session.Claims
-		claims := &session.Claims{
-			Subject:    oathClaims.Subject,
-			Email:      oathClaims.Email,
-			Name:       oathClaims.Name,
-			IdentityID: oathClaims.IdentityID,
-			SessionID:  oathClaims.SessionID,
-			Metadata:   oathClaims.Metadata,
-			Issuer:     oathClaims.Issuer,
-		}
-
-		if oathClaims.IssuedAt != nil {
-			claims.IssuedAt = oathClaims.IssuedAt.Unix()
-		}
-
-		if oathClaims.ExpiresAt != nil {
-			claims.ExpiresAt = oathClaims.ExpiresAt.Unix()
-		}
-
 		span.SetStatus(codes.Ok, "token validated")
 		span.SetAttributes(
 			attribute.String("user.id", claims.Subject),
@@ -156,6 +181,91 @@ func (j jwtMiddleware) middleware(next http.Handler) http.Handler {
 	})
 }
 
+// claimsFromToken parses the Oathkeeper JWT from the Authorization header.
+func (j jwtMiddleware) claimsFromToken(req *http.Request, span trace.Span) (*session.Claims, bool) {
+	// Extract token from Authorization header
+	tokenString := extractBearerToken(req)
+	if tokenString == "" {
+		span.SetStatus(codes.Error, "missing authorization header")
+
+		return nil, false
+	}
+
+	// Parse JWT without signature verification (we trust Oathkeeper)
+	token, _, err := j.parser.ParseUnverified(tokenString, &oathkeeperClaims{})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, false
+	}
+
+	oathClaims, ok := token.Claims.(*oathkeeperClaims)
+	if !ok {
+		span.SetStatus(codes.Error, "invalid claims type")
+
+		return nil, false
+	}
+
+	// Validate subject is present
+	if oathClaims.Subject == "" {
+		span.SetStatus(codes.Error, "missing subject in token")
+
+		return nil, false
+	}
+
+	// Convert to session.Claims
+	claims := &session.Claims{
+		Subject:    oathClaims.Subject,
+		Email:      oathClaims.Email,
+		Name:       oathClaims.Name,
+		IdentityID: oathClaims.IdentityID,
+		SessionID:  oathClaims.SessionID,
+		Metadata:   oathClaims.Metadata,
+		Issuer:     oathClaims.Issuer,
+	}
+
+	if oathClaims.IssuedAt != nil {
+		claims.IssuedAt = oathClaims.IssuedAt.Unix()
+	}
+
+	if oathClaims.ExpiresAt != nil {
+		claims.ExpiresAt = oathClaims.ExpiresAt.Unix()
+	}
+
+	return claims, true
+}
+
+// claimsFromHeaders builds claims from pre-validated claim headers.
+func (j jwtMiddleware) claimsFromHeaders(req *http.Request, span trace.Span) (*session.Claims, bool) {
+	header := func(name string) string {
+		return strings.TrimSpace(req.Header.Get(j.claimHeaderPrefix + name))
+	}
+
+	span.SetAttributes(attribute.String("auth.claims.source", "headers"))
+
+	claims := &session.Claims{
+		Subject:    header("Sub"),
+		Email:      header("Email"),
+		Name:       header("Name"),
+		IdentityID: header("Identity-Id"),
+		SessionID:  header("Session-Id"),
+		Issuer:     header("Iss"),
+	}
+
+	if claims.Subject == "" {
+		span.SetStatus(codes.Error, "missing subject claim header")
+
+		return nil, false
+	}
+
+	// Unparsable timestamps are left at zero; the proxy already validated them.
+	claims.IssuedAt, _ = strconv.ParseInt(header("Iat"), 10, 64)
+	claims.ExpiresAt, _ = strconv.ParseInt(header("Exp"), 10, 64)
+
+	return claims, true
+}
+
 // extractBearerToken extracts the JWT from the Authorization header.
 func extractBearerToken(r *http.Request) string {
 	auth := r.Header.Get("Authorization")
@@ -179,13 +289,7 @@ func (j jwtMiddleware) handleUnauthorized(responseWriter http.ResponseWriter, re
 
 	// API request - return JSON error
 	if strings.Contains(accept, "application/json") {
-		responseWriter.Header().Set("Content-Type", "application/json")
-		responseWriter.WriteHeader(http.StatusUnauthorized)
-
-		_, writeErr := responseWriter.Write([]byte(`{"error":"unauthorized","message":"authentication required"}`))
-		if writeErr != nil {
-			return
-		}
+		writeUnauthorized(responseWriter)
 
 		return
 	}
@@ -193,3 +297,14 @@ func (j jwtMiddleware) handleUnauthorized(responseWriter http.ResponseWriter, re
 	// Browser request - redirect to login
 	http.Redirect(responseWriter, req, j.cfg.GetString("AUTH_LOGIN_URL"), http.StatusFound)
 }
+
+// writeUnauthorized writes a JSON 401 response.
+func writeUnauthorized(responseWriter http.ResponseWriter) {
+	responseWriter.Header().Set("Content-Type", "application/json")
+	responseWriter.WriteHeader(http.StatusUnauthorized)
+
+	_, writeErr := responseWriter.Write([]byte(`{"error":"unauthorized","message":"authentication required"}`))
+	if writeErr != nil {
+		return
+	}
+}
@@ -152,6 +152,91 @@ func TestJWT_RedirectForBrowser(t *testing.T) {
 	assert.Equal(t, "/auth/login", rec.Header().Get("Location"))
 }
 
+func TestJWT_OptionalPassesAnonymous(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	called := false
+	handler := JWT(cfg, WithOptional())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+
+		_, err := session.GetClaims(r.Context())
+		assert.Error(t, err)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, authHeader := range []string{"", "Bearer invalid.token.here"} {
+		called = false
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/api/test", http.NoBody)
+		req.Header.Set("Accept", "application/json")
+
+		if authHeader != "" {
+			req.Header.Set("Authorization", authHeader)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.True(t, called, "handler should be called for %q", authHeader)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestJWT_OptionalSetsClaimsWhenPresent(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	tokenString := createTestToken(t, &oathkeeperClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "user-123"},
+	})
+
+	handler := JWT(cfg, WithOptional())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, err := session.GetUserID(r.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, "user-123", userID)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/api/test", http.NoBody)
+	req.Header.Set("Authorization", "Bearer "+tokenString)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRequireAuth(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	handler := JWT(cfg, WithOptional())(RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/api/test", http.NoBody)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	tokenString := createTestToken(t, &oathkeeperClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "user-123"},
+	})
+
+	req = httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/api/test", http.NoBody)
+	req.Header.Set("Authorization", "Bearer "+tokenString)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
 func TestExtractBearerToken(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -178,3 +263,79 @@ func TestExtractBearerToken(t *testing.T) {
 		})
 	}
 }
+
+func TestJWT_ClaimHeaders(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	handler := JWT(cfg, WithClaimHeaders(""))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionClaims, err := session.GetClaims(r.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, "user-123", sessionClaims.Subject)
+		assert.Equal(t, "test@example.com", sessionClaims.Email)
+		assert.Equal(t, "identity-456", sessionClaims.IdentityID)
+		assert.Equal(t, "https://shortlink.best", sessionClaims.Issuer)
+		assert.Equal(t, int64(1234567890), sessionClaims.ExpiresAt)
+
+		userID, err := session.GetUserID(r.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, "user-123", userID)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/api/test", http.NoBody)
+	req.Header.Set("X-Jwt-Claim-Sub", "user-123")
+	req.Header.Set("X-Jwt-Claim-Email", "test@example.com")
+	req.Header.Set("X-Jwt-Claim-Identity-Id", "identity-456")
+	req.Header.Set("X-Jwt-Claim-Iss", "https://shortlink.best")
+	req.Header.Set("X-Jwt-Claim-Exp", "1234567890")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestJWT_ClaimHeadersCustomPrefix(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	handler := JWT(cfg, WithClaimHeaders("X-Auth-"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, err := session.GetUserID(r.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, "user-123", userID)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/api/test", http.NoBody)
+	req.Header.Set("X-Auth-Sub", "user-123")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestJWT_ClaimHeadersMissingSubject(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	handler := JWT(cfg, WithClaimHeaders(""))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not be called")
+	}))
+
+	// A bearer token is ignored in header mode.
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/api/test", http.NoBody)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestToken(t, &oathkeeperClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "user-123"},
+	}))
+	req.Header.Set("X-Jwt-Claim-Email", "test@example.com")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
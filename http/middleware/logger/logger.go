@@ -2,27 +2,163 @@ package logger_middleware
 
 import (
 	"log/slog"
+	"net"
 	"net/http"
+	"net/netip"
 	"runtime/debug"
 	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
-	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 
 	"github.com/shortlink-org/go-sdk/logger"
+	"github.com/shortlink-org/go-sdk/logger/tracer"
 )
 
+const meterName = "github.com/shortlink-org/go-sdk/http/middleware/logger"
+
+// LevelHeader lowers the log level for a single request, e.g. "X-Log-Level: debug".
+const LevelHeader = "X-Log-Level"
+
+// Config configures the logger middleware.
+type Config struct {
+	Logger logger.Logger
+	// MeterProvider enables RED metrics (http_server_requests_total,
+	// http_server_request_duration_seconds) recorded alongside each log line.
+	// Metrics are disabled when nil.
+	MeterProvider metric.MeterProvider
+	// TrustLevelHeader reports whether the LevelHeader of a request may be honored.
+	// The header is ignored when nil, so clients cannot flood the logs by default.
+	TrustLevelHeader func(req *http.Request) bool
+	// PanicResponse writes the response after a handler panic, e.g. a JSON error for API
+	// clients; it should write a 500 status. The panic is logged with its stack either way.
+	// It is skipped when the handler already started the response, since the status can
+	// no longer change. Defaults to a plain-text 500.
+	PanicResponse func(rw http.ResponseWriter, req *http.Request, rec any)
+}
+
 type chilogger struct {
-	log logger.Logger
+	log           logger.Logger
+	trustLevel    func(req *http.Request) bool
+	panicResponse func(rw http.ResponseWriter, req *http.Request, rec any)
+
+	requests metric.Int64Counter
+	duration metric.Float64Histogram
 }
 
 func Logger(log logger.Logger) func(next http.Handler) http.Handler {
 	return chilogger{log: log}.middleware
 }
 
+// New builds the logger middleware from Config.
+func New(cfg Config) (func(next http.Handler) http.Handler, error) {
+	c := chilogger{log: cfg.Logger, trustLevel: cfg.TrustLevelHeader, panicResponse: cfg.PanicResponse}
+
+	if cfg.MeterProvider != nil {
+		meter := cfg.MeterProvider.Meter(meterName)
+
+		requests, err := meter.Int64Counter(
+			"http_server_requests_total",
+			metric.WithDescription("Total number of HTTP requests handled."),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		duration, err := meter.Float64Histogram(
+			"http_server_request_duration_seconds",
+			metric.WithDescription("HTTP request latencies in seconds."),
+			metric.WithUnit("s"),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		c.requests = requests
+		c.duration = duration
+	}
+
+	return c.middleware, nil
+}
+
+// TrustNetworks trusts the LevelHeader of requests whose peer address is in one of prefixes.
+// RemoteAddr is only the real peer when no proxy middleware rewrites it from client headers.
+func TrustNetworks(prefixes ...netip.Prefix) func(req *http.Request) bool {
+	return func(req *http.Request) bool {
+		host, _, err := net.SplitHostPort(req.RemoteAddr)
+		if err != nil {
+			host = req.RemoteAddr
+		}
+
+		addr, err := netip.ParseAddr(host)
+		if err != nil {
+			return false
+		}
+
+		addr = addr.Unmap()
+
+		for _, prefix := range prefixes {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// withRequestLevel applies a trusted LevelHeader to the request context.
+func (c chilogger) withRequestLevel(req *http.Request) *http.Request {
+	value := req.Header.Get(LevelHeader)
+	if value == "" || c.trustLevel == nil || !c.trustLevel(req) {
+		return req
+	}
+
+	var level slog.Level
+
+	err := level.UnmarshalText([]byte(value))
+	if err != nil {
+		return req
+	}
+
+	return req.WithContext(logger.WithLevel(req.Context(), level))
+}
+
+// writePanicResponse answers a panicked request unless the handler already sent the status;
+// appending an error body to a partial response would only corrupt it.
+func (c chilogger) writePanicResponse(rw middleware.WrapResponseWriter, req *http.Request, rec any) {
+	if rw.Status() != 0 {
+		return
+	}
+
+	if c.panicResponse != nil {
+		c.panicResponse(rw, req, rec)
+
+		return
+	}
+
+	http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+func (c chilogger) recordMetrics(req *http.Request, status int, latency time.Duration) {
+	if c.requests == nil {
+		return
+	}
+
+	attrs := metric.WithAttributes(
+		attribute.String("method", req.Method),
+		attribute.Int("status", status),
+	)
+
+	c.requests.Add(req.Context(), 1, attrs)
+	c.duration.Record(req.Context(), latency.Seconds(), attrs)
+}
+
 func (c chilogger) middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		start := time.Now()
+		req = c.withRequestLevel(req)
 
 		// Preserve original writer but wrap it to intercept status + bytes written
 		wrapped := middleware.NewWrapResponseWriter(rw, req.ProtoMajor)
@@ -42,11 +178,19 @@ func (c chilogger) middleware(next http.Handler) http.Handler {
 					slog.String("method", req.Method),
 					slog.String("path", req.URL.Path),
 				)
-				http.Error(wrapped, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				c.writePanicResponse(wrapped, req, rec)
+				c.recordMetrics(req, http.StatusInternalServerError, latency)
 
 				return
 			}
 
+			// A handler that never writes gets an implicit 200 from net/http.
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			c.recordMetrics(req, status, latency)
+
 			fields := []slog.Attr{
 				slog.Int("status", status),
 				slog.Int("bytes", bytes),
@@ -59,22 +203,7 @@ func (c chilogger) middleware(next http.Handler) http.Handler {
 				slog.String("referer", req.Referer()),
 			}
 
-			// Extract OTEL trace IDs if available
-			span := trace.SpanFromContext(req.Context())
-			if span != nil && span.IsRecording() {
-				spanCtx := span.SpanContext()
-				if spanCtx.HasTraceID() {
-					fields = append(fields,
-						slog.String("trace_id", spanCtx.TraceID().String()),
-					)
-				}
-
-				if spanCtx.HasSpanID() {
-					fields = append(fields,
-						slog.String("span_id", spanCtx.SpanID().String()),
-					)
-				}
-			}
+			fields = append(fields, tracer.CorrelationFields(req.Context())...)
 
 			// Log level depending on status
 			switch {
@@ -1,21 +1,29 @@
 package logger_middleware_test
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	tracenoop "go.opentelemetry.io/otel/trace/noop"
 
 	logger_middleware "github.com/shortlink-org/go-sdk/http/middleware/logger"
 	"github.com/shortlink-org/go-sdk/http/middleware/logger/mocks"
+	"github.com/shortlink-org/go-sdk/logger"
+	"github.com/shortlink-org/go-sdk/logger/tracer"
 )
 
 const (
@@ -97,6 +105,13 @@ func TestLoggerMiddleware_RequestCompletedByStatus(t *testing.T) {
 			},
 			wantCode: http.StatusOK,
 		},
+		{
+			name:       "info_implicit_200",
+			mockMethod: "InfoWithContext",
+			path:       "/empty",
+			setup:      func(*testing.T, http.ResponseWriter) {},
+			wantCode:   http.StatusOK,
+		},
 		{
 			name:       "warn_400",
 			mockMethod: "WarnWithContext",
@@ -200,6 +215,71 @@ func TestLoggerMiddleware_Panic(t *testing.T) {
 	mockLogger.AssertExpectations(t)
 }
 
+func TestLoggerMiddleware_PanicResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		wantCode int
+		wantBody string
+		wantHook bool
+	}{
+		{
+			name: "custom_body",
+			handler: func(http.ResponseWriter, *http.Request) {
+				panic("boom")
+			},
+			wantCode: http.StatusInternalServerError,
+			wantBody: `{"error":"internal","message":"boom"}`,
+			wantHook: true,
+		},
+		{
+			name: "response_already_started",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+
+				_, _ = w.Write([]byte("partial"))
+
+				panic("boom")
+			},
+			wantCode: http.StatusAccepted,
+			wantBody: "partial",
+			wantHook: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogger := mocks.NewMockLogger(t)
+			setupMockLoggerCall(mockLogger, "ErrorWithContext", "panic recovered").Return().Once()
+
+			hookCalled := false
+
+			mw, err := logger_middleware.New(logger_middleware.Config{
+				Logger: mockLogger,
+				PanicResponse: func(w http.ResponseWriter, _ *http.Request, rec any) {
+					hookCalled = true
+
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusInternalServerError)
+
+					_, werr := fmt.Fprintf(w, `{"error":"internal","message":%q}`, rec)
+					assert.NoError(t, werr)
+				},
+			})
+			require.NoError(t, err)
+
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/panic", http.NoBody)
+			rr := httptest.NewRecorder()
+
+			mw(tt.handler).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantCode, rr.Code)
+			assert.Equal(t, tt.wantBody, rr.Body.String())
+			assert.Equal(t, tt.wantHook, hookCalled)
+		})
+	}
+}
+
 // BytesWritten
 func TestLoggerMiddleware_BytesWritten(t *testing.T) {
 	mockLogger := mocks.NewMockLogger(t)
@@ -271,7 +351,7 @@ func TestLoggerMiddleware_QueryString(t *testing.T) {
 	mockLogger.AssertExpectations(t)
 }
 
-// Parent span must propagate trace_id + span_id
+// Parent span must propagate traceID + spanID
 func TestLoggerMiddleware_OtelTracePropagation(t *testing.T) {
 	tp := sdktrace.NewTracerProvider()
 
@@ -289,9 +369,9 @@ func TestLoggerMiddleware_OtelTracePropagation(t *testing.T) {
 
 	mw := logger_middleware.Logger(mockLogger)
 
-	tracer := otel.Tracer("test-tracer")
+	tr := otel.Tracer("test-tracer")
 
-	ctx, span := tracer.Start(context.Background(), "parent-span")
+	ctx, span := tr.Start(context.Background(), "parent-span")
 
 	defer span.End()
 
@@ -312,22 +392,22 @@ func TestLoggerMiddleware_OtelTracePropagation(t *testing.T) {
 	hasSpanID := false
 
 	for _, attr := range capturedAttrs {
-		if attr.Key == "trace_id" && attr.Value.String() == traceID {
+		if attr.Key == tracer.TraceIDKey && attr.Value.String() == traceID {
 			hasTraceID = true
 		}
 
-		if attr.Key == "span_id" && attr.Value.String() == spanID {
+		if attr.Key == tracer.SpanIDKey && attr.Value.String() == spanID {
 			hasSpanID = true
 		}
 	}
 
-	require.True(t, hasTraceID, "trace_id must be logged")
-	require.True(t, hasSpanID, "span_id must be logged")
+	require.True(t, hasTraceID, "traceID must be logged")
+	require.True(t, hasSpanID, "spanID must be logged")
 
 	mockLogger.AssertExpectations(t)
 }
 
-// No span in context → no trace_id / span_id fields
+// No span in context → no traceID / spanID fields
 func TestLoggerMiddleware_Otel_NoSpan(t *testing.T) {
 	mockLogger := mocks.NewMockLogger(t)
 
@@ -349,8 +429,8 @@ func TestLoggerMiddleware_Otel_NoSpan(t *testing.T) {
 	handler.ServeHTTP(rr, req)
 
 	for _, attr := range capturedAttrs {
-		require.NotEqual(t, "trace_id", attr.Key, "should not have trace_id")
-		require.NotEqual(t, "span_id", attr.Key, "should not have span_id")
+		require.NotEqual(t, tracer.TraceIDKey, attr.Key, "should not have traceID")
+		require.NotEqual(t, tracer.SpanIDKey, attr.Key, "should not have spanID")
 	}
 
 	mockLogger.AssertExpectations(t)
@@ -374,9 +454,9 @@ func TestLoggerMiddleware_Otel_ChildSpan(t *testing.T) {
 
 	mw := logger_middleware.Logger(mockLogger)
 
-	tracer := otel.Tracer("test-tracer")
+	tr := otel.Tracer("test-tracer")
 
-	ctx, parent := tracer.Start(context.Background(), "parent")
+	ctx, parent := tr.Start(context.Background(), "parent")
 
 	defer parent.End()
 
@@ -391,7 +471,7 @@ func TestLoggerMiddleware_Otel_ChildSpan(t *testing.T) {
 	rr := httptest.NewRecorder()
 
 	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		_, childSpan := tracer.Start(r.Context(), "child")
+		_, childSpan := tr.Start(r.Context(), "child")
 
 		defer childSpan.End()
 
@@ -416,23 +496,131 @@ func TestLoggerMiddleware_Otel_ChildSpan(t *testing.T) {
 	hasSpanID := false
 
 	for _, attr := range capturedAttrs {
-		if attr.Key == "trace_id" && attr.Value.String() == parentTraceID {
+		if attr.Key == tracer.TraceIDKey && attr.Value.String() == parentTraceID {
 			hasTraceID = true
 		}
 
-		if attr.Key == "span_id" && attr.Value.String() == parentSpanID {
+		if attr.Key == tracer.SpanIDKey && attr.Value.String() == parentSpanID {
 			hasSpanID = true
 		}
 	}
 
-	require.True(t, hasTraceID, "parent trace_id must be logged")
-	require.True(t, hasSpanID, "parent span_id must be logged")
+	require.True(t, hasTraceID, "parent traceID must be logged")
+	require.True(t, hasSpanID, "parent spanID must be logged")
 
 	childTraceID := childIDs.traceID
 	childSpanID := childIDs.spanID
 
-	require.Equal(t, parentTraceID, childTraceID, "child should have same trace_id as parent")
-	require.NotEqual(t, parentSpanID, childSpanID, "child should have different span_id than parent")
+	require.Equal(t, parentTraceID, childTraceID, "child should have same traceID as parent")
+	require.NotEqual(t, parentSpanID, childSpanID, "child should have different spanID than parent")
 
 	mockLogger.AssertExpectations(t)
 }
+
+func TestLoggerMiddleware_RecordsRequestMetrics(t *testing.T) {
+	mockLogger := mocks.NewMockLogger(t)
+	setupMockLoggerCall(mockLogger, "WarnWithContext", "request completed").Return().Maybe()
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	mw, err := logger_middleware.New(logger_middleware.Config{
+		Logger:        mockLogger,
+		MeterProvider: provider,
+	})
+	require.NoError(t, err)
+
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	for range 2 {
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/missing", http.NoBody)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	var (
+		requests  *metricdata.Sum[int64]
+		durations *metricdata.Histogram[float64]
+	)
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				if m.Name == "http_server_requests_total" {
+					requests = &data
+				}
+			case metricdata.Histogram[float64]:
+				if m.Name == "http_server_request_duration_seconds" {
+					durations = &data
+				}
+			}
+		}
+	}
+
+	require.NotNil(t, requests, "requests counter not recorded")
+	require.Len(t, requests.DataPoints, 1)
+	assert.Equal(t, int64(2), requests.DataPoints[0].Value)
+
+	status, ok := requests.DataPoints[0].Attributes.Value("status")
+	require.True(t, ok)
+	assert.Equal(t, int64(http.StatusNotFound), status.AsInt64())
+
+	method, ok := requests.DataPoints[0].Attributes.Value("method")
+	require.True(t, ok)
+	assert.Equal(t, http.MethodGet, method.AsString())
+
+	require.NotNil(t, durations, "duration histogram not recorded")
+	require.Len(t, durations.DataPoints, 1)
+	assert.Equal(t, uint64(2), durations.DataPoints[0].Count)
+}
+
+func TestLoggerMiddleware_LevelHeader(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		level      string
+		wantDebug  bool
+	}{
+		{name: "no_header", remoteAddr: "10.0.0.1:1234", wantDebug: false},
+		{name: "trusted_debug", remoteAddr: "10.0.0.1:1234", level: "debug", wantDebug: true},
+		{name: "untrusted_debug", remoteAddr: "203.0.113.7:1234", level: "debug", wantDebug: false},
+		{name: "invalid_level", remoteAddr: "10.0.0.1:1234", level: "verbose", wantDebug: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+
+			log, err := logger.New(logger.Configuration{Level: logger.INFO_LEVEL, Writer: &buffer})
+			require.NoError(t, err)
+
+			mw, err := logger_middleware.New(logger_middleware.Config{
+				Logger:           log,
+				TrustLevelHeader: logger_middleware.TrustNetworks(netip.MustParsePrefix("10.0.0.0/8")),
+			})
+			require.NoError(t, err)
+
+			handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				log.DebugWithContext(r.Context(), "debug line")
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/", http.NoBody)
+			req.RemoteAddr = tt.remoteAddr
+
+			if tt.level != "" {
+				req.Header.Set(logger_middleware.LevelHeader, tt.level)
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Contains(t, buffer.String(), "request completed")
+			assert.Equal(t, tt.wantDebug, strings.Contains(buffer.String(), "debug line"))
+		})
+	}
+}
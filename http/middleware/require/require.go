@@ -0,0 +1,130 @@
+// Package require_middleware rejects requests that lack required headers or
+// accept none of the media types a handler can produce.
+package require_middleware
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Header is a request header that must be present.
+type Header struct {
+	Name string
+	// Allowed restricts the header to these values; empty allows any non-empty value.
+	Allowed []string
+}
+
+// Config configures the require middleware.
+type Config struct {
+	// Headers must be present on every request, otherwise the request is rejected with 400.
+	Headers []Header
+	// Accept lists the media types the handler produces, e.g. "application/json".
+	// Requests whose Accept header allows none of them are rejected with 406;
+	// a missing Accept header accepts anything. Empty skips negotiation.
+	Accept []string
+}
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// New builds the require middleware from Config.
+func New(cfg Config) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(responseWriter http.ResponseWriter, req *http.Request) {
+			for _, header := range cfg.Headers {
+				value := strings.TrimSpace(req.Header.Get(header.Name))
+
+				if value == "" {
+					writeError(responseWriter, http.StatusBadRequest, "missing_header",
+						"header "+header.Name+" is required")
+
+					return
+				}
+
+				if len(header.Allowed) > 0 && !slices.Contains(header.Allowed, value) {
+					writeError(responseWriter, http.StatusBadRequest, "invalid_header",
+						"header "+header.Name+" must be one of: "+strings.Join(header.Allowed, ", "))
+
+					return
+				}
+			}
+
+			if len(cfg.Accept) > 0 && !acceptable(req.Header.Values("Accept"), cfg.Accept) {
+				writeError(responseWriter, http.StatusNotAcceptable, "not_acceptable",
+					"supported media types: "+strings.Join(cfg.Accept, ", "))
+
+				return
+			}
+
+			next.ServeHTTP(responseWriter, req)
+		})
+	}
+}
+
+// acceptable reports whether any media range of the Accept headers matches one of offered.
+// Ranges with q=0 are excluded; unparsable ranges are ignored.
+func acceptable(accept, offered []string) bool {
+	if len(accept) == 0 {
+		return true
+	}
+
+	for _, header := range accept {
+		for part := range strings.SplitSeq(header, ",") {
+			mediaRange, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil {
+				continue
+			}
+
+			if q, ok := params["q"]; ok {
+				weight, err := strconv.ParseFloat(q, 64)
+				if err != nil || weight <= 0 {
+					continue
+				}
+			}
+
+			for _, mediaType := range offered {
+				if matches(mediaRange, mediaType) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// matches reports whether mediaType falls into mediaRange, e.g. "*/*" or "application/*".
+func matches(mediaRange, mediaType string) bool {
+	mediaType = strings.ToLower(mediaType)
+
+	if mediaRange == "*/*" || mediaRange == mediaType {
+		return true
+	}
+
+	prefix, ok := strings.CutSuffix(mediaRange, "/*")
+
+	return ok && strings.HasPrefix(mediaType, prefix+"/")
+}
+
+func writeError(responseWriter http.ResponseWriter, status int, code, message string) {
+	body, err := json.Marshal(errorResponse{Error: code, Message: message})
+	if err != nil {
+		http.Error(responseWriter, message, status)
+
+		return
+	}
+
+	responseWriter.Header().Set("Content-Type", "application/json")
+	responseWriter.WriteHeader(status)
+
+	_, writeErr := responseWriter.Write(body)
+	if writeErr != nil {
+		return
+	}
+}
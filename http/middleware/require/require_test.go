@@ -0,0 +1,101 @@
+package require_middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	require_middleware "github.com/shortlink-org/go-sdk/http/middleware/require"
+)
+
+func newHandler() http.Handler {
+	mw := require_middleware.New(require_middleware.Config{
+		Headers: []require_middleware.Header{
+			{Name: "X-Api-Version", Allowed: []string{"1", "2"}},
+			{Name: "X-Tenant"},
+		},
+		Accept: []string{"application/json"},
+	})
+
+	return mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestRequire(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "valid",
+			headers:  map[string]string{"X-Api-Version": "2", "X-Tenant": "acme", "Accept": "application/json"},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "no_accept_header",
+			headers:  map[string]string{"X-Api-Version": "1", "X-Tenant": "acme"},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "accept_wildcard",
+			headers:  map[string]string{"X-Api-Version": "1", "X-Tenant": "acme", "Accept": "text/html;q=0.9, */*;q=0.1"},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "accept_subtype_wildcard",
+			headers:  map[string]string{"X-Api-Version": "1", "X-Tenant": "acme", "Accept": "Application/*"},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "missing_header",
+			headers:  map[string]string{"X-Api-Version": "1", "Accept": "application/json"},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"error":"missing_header","message":"header X-Tenant is required"}`,
+		},
+		{
+			name:     "invalid_value",
+			headers:  map[string]string{"X-Api-Version": "3", "X-Tenant": "acme"},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"error":"invalid_header","message":"header X-Api-Version must be one of: 1, 2"}`,
+		},
+		{
+			name:     "unacceptable_accept",
+			headers:  map[string]string{"X-Api-Version": "1", "X-Tenant": "acme", "Accept": "text/html, application/xml"},
+			wantCode: http.StatusNotAcceptable,
+			wantBody: `{"error":"not_acceptable","message":"supported media types: application/json"}`,
+		},
+		{
+			name:     "accept_excluded_by_q0",
+			headers:  map[string]string{"X-Api-Version": "1", "X-Tenant": "acme", "Accept": "application/json;q=0"},
+			wantCode: http.StatusNotAcceptable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/", http.NoBody)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			rr := httptest.NewRecorder()
+			newHandler().ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantCode, rr.Code)
+
+			if tt.wantCode != http.StatusOK {
+				assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+			}
+
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rr.Body.String())
+			}
+		})
+	}
+}
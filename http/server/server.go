@@ -2,9 +2,11 @@ package httpserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/shortlink-org/go-sdk/config"
 )
@@ -30,3 +32,93 @@ func New(ctx context.Context, handler http.Handler, serverConfig Config, cfg *co
 
 	return server
 }
+
+// Drainer is switched into draining mode when Shutdown begins, e.g. *drain_middleware.Drainer.
+type Drainer interface {
+	BeginDraining()
+}
+
+// Option configures a Server created by Start.
+type Option func(*Server)
+
+// WithDrain calls drainer.BeginDraining when Shutdown begins and keeps serving for
+// delay before the listener is closed, so load balancers observe the failing
+// readiness check while new requests are answered with 503.
+func WithDrain(drainer Drainer, delay time.Duration) Option {
+	return func(s *Server) {
+		s.drainer = drainer
+		s.drainDelay = delay
+	}
+}
+
+// Server is an HTTP server started by Start that can be drained with Shutdown.
+type Server struct {
+	server   *http.Server
+	listener net.Listener
+	done     chan struct{}
+	err      error
+
+	drainer    Drainer
+	drainDelay time.Duration
+}
+
+// Start binds the listener synchronously, so port conflicts surface as errors,
+// and serves requests in the background until Shutdown is called.
+func Start(ctx context.Context, handler http.Handler, serverConfig Config, cfg *config.Config, opts ...Option) (*Server, error) {
+	server := New(ctx, handler, serverConfig, cfg)
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", server.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("http server listen on %s: %w", server.Addr, err)
+	}
+
+	srv := &Server{
+		server:   server,
+		listener: listener,
+		done:     make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(srv)
+	}
+
+	go func() {
+		defer close(srv.done)
+
+		if errServe := server.Serve(listener); !errors.Is(errServe, http.ErrServerClosed) {
+			srv.err = errServe
+		}
+	}()
+
+	return srv, nil
+}
+
+// Addr returns the address the server is listening on.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Shutdown stops accepting connections and waits for in-flight requests to
+// complete or ctx to expire, then returns any error reported by Serve.
+// With WithDrain, the server drains for the configured delay first.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.drainer != nil {
+		s.drainer.BeginDraining()
+
+		timer := time.NewTimer(s.drainDelay)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+	}
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	<-s.done
+
+	return s.err
+}
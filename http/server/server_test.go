@@ -0,0 +1,100 @@
+package httpserver
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/config"
+)
+
+func TestServerShutdownDrainsInFlightRequest(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+
+		_, _ = w.Write([]byte("done"))
+	})
+
+	srv, err := Start(context.Background(), handler, Config{Port: 0, Timeout: 5 * time.Second}, cfg)
+	require.NoError(t, err)
+
+	type result struct {
+		body string
+		err  error
+	}
+
+	resCh := make(chan result, 1)
+
+	go func() {
+		resp, errGet := http.Get("http://" + srv.Addr()) //nolint:noctx // test request
+		if errGet != nil {
+			resCh <- result{err: errGet}
+
+			return
+		}
+		defer resp.Body.Close()
+
+		body, errRead := io.ReadAll(resp.Body)
+		resCh <- result{body: string(body), err: errRead}
+	}()
+
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	require.NoError(t, srv.Shutdown(ctx))
+
+	res := <-resCh
+	require.NoError(t, res.err)
+	require.Equal(t, "done", res.body)
+}
+
+type recordingDrainer struct {
+	draining chan struct{}
+}
+
+func (d *recordingDrainer) BeginDraining() {
+	close(d.draining)
+}
+
+func TestServerShutdownBeginsDrainingBeforeClosing(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	drainer := &recordingDrainer{draining: make(chan struct{})}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	srv, err := Start(context.Background(), handler, Config{Port: 0, Timeout: 5 * time.Second}, cfg,
+		WithDrain(drainer, 200*time.Millisecond))
+	require.NoError(t, err)
+
+	shutdownErr := make(chan error, 1)
+
+	go func() {
+		shutdownErr <- srv.Shutdown(context.Background())
+	}()
+
+	<-drainer.draining
+
+	// The listener stays open during the drain delay.
+	resp, err := http.Get("http://" + srv.Addr()) //nolint:noctx // test request
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.NoError(t, <-shutdownErr)
+}
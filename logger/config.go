@@ -22,13 +22,23 @@ const (
 
 // Configuration - options for logger.
 type Configuration struct {
-	Writer     io.Writer
+	Writer io.Writer
+	// Writers are additional destinations: each record goes to Writer and to
+	// every entry, one record at a time. See MultiWriter.
+	Writers    []io.Writer
 	TimeFormat string
 	Level      int
+	// UTC formats timestamps in UTC instead of the local time zone.
+	UTC bool
+	// Encoder replaces the slog JSON encoding, e.g. with segmentio/encoding/json.Marshal.
+	// The keys stay the same; nil uses slog.JSONHandler.
+	Encoder Encoder
+	// Sampling drops repeated high-volume lines; nil disables sampling.
+	Sampling *SamplingConfig
 }
 
 func (c *Configuration) Validate() error {
-	if c.Writer == nil {
+	if c.Writer == nil && len(c.Writers) == 0 {
 		c.Writer = os.Stdout
 	}
 
@@ -43,6 +53,15 @@ func (c *Configuration) Validate() error {
 	return nil
 }
 
+// output returns the writer records are written to.
+func (c *Configuration) output() io.Writer {
+	if len(c.Writers) == 0 {
+		return c.Writer
+	}
+
+	return MultiWriter(append([]io.Writer{c.Writer}, c.Writers...)...)
+}
+
 // Default returns a default configuration.
 func Default() Configuration {
 	return Configuration{
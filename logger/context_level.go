@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+type contextLevelKey struct{}
+
+// WithLevel returns a context whose records are written down to level, even when the
+// configured level is higher. It only adds verbosity: records the configured level
+// already writes are never dropped.
+func WithLevel(ctx context.Context, level slog.Level) context.Context {
+	return context.WithValue(ctx, contextLevelKey{}, level)
+}
+
+// LevelFromContext returns the level set by WithLevel.
+func LevelFromContext(ctx context.Context) (slog.Level, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+
+	level, ok := ctx.Value(contextLevelKey{}).(slog.Level)
+
+	return level, ok
+}
+
+// contextLevelHandler enables records allowed by the context level on top of next's own level.
+type contextLevelHandler struct {
+	next slog.Handler
+}
+
+func (h *contextLevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.next.Enabled(ctx, level) {
+		return true
+	}
+
+	override, ok := LevelFromContext(ctx)
+
+	return ok && level >= override
+}
+
+//nolint:gocritic // hugeParam: slog.Handler interface passes Record by value.
+func (h *contextLevelHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.next.Handle(ctx, record)
+}
+
+func (h *contextLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextLevelHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h *contextLevelHandler) WithGroup(name string) slog.Handler {
+	return &contextLevelHandler{next: h.next.WithGroup(name)}
+}
@@ -13,10 +13,25 @@ import (
 func NewDefault(_ context.Context, cfg *config.Config) (*SlogLogger, func(), error) {
 	cfg.SetDefault("LOG_LEVEL", INFO_LEVEL)
 	cfg.SetDefault("LOG_TIME_FORMAT", time.RFC3339Nano)
+	cfg.SetDefault("LOG_TIME_UTC", false)
+
+	cfg.SetDefault("LOG_SAMPLING_ENABLED", false)
+	cfg.SetDefault("LOG_SAMPLING_WINDOW", "1s")
+	cfg.SetDefault("LOG_SAMPLING_FIRST", 100)
+	cfg.SetDefault("LOG_SAMPLING_THEREAFTER", 100)
 
 	conf := Configuration{
 		Level:      cfg.GetInt("LOG_LEVEL"),
 		TimeFormat: cfg.GetString("LOG_TIME_FORMAT"),
+		UTC:        cfg.GetBool("LOG_TIME_UTC"),
+	}
+
+	if cfg.GetBool("LOG_SAMPLING_ENABLED") {
+		conf.Sampling = &SamplingConfig{
+			Window:     cfg.GetDuration("LOG_SAMPLING_WINDOW"),
+			First:      cfg.GetInt("LOG_SAMPLING_FIRST"),
+			Thereafter: cfg.GetInt("LOG_SAMPLING_THEREAFTER"),
+		}
 	}
 
 	log, err := New(conf)
@@ -0,0 +1,205 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// Encoder marshals a log record to JSON. json.Marshal from encoding/json or from
+// github.com/segmentio/encoding/json can be used directly.
+type Encoder func(v any) ([]byte, error)
+
+// encoderHandler writes records as JSON objects produced by an Encoder. It emits the
+// same keys as slog.JSONHandler (time, level, source, msg and the attributes), but
+// the key order is up to the encoder.
+type encoderHandler struct {
+	opts    slog.HandlerOptions
+	encoder Encoder
+
+	mu     *sync.Mutex
+	writer io.Writer
+
+	// attrs were added by WithAttrs, each under the groups open at that time.
+	attrs  []groupedAttr
+	groups []string
+}
+
+type groupedAttr struct {
+	groups []string
+	attr   slog.Attr
+}
+
+func newEncoderHandler(w io.Writer, encoder Encoder, opts slog.HandlerOptions) *encoderHandler {
+	return &encoderHandler{
+		opts:    opts,
+		encoder: encoder,
+		mu:      &sync.Mutex{},
+		writer:  w,
+	}
+}
+
+func (h *encoderHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+
+	return level >= minLevel
+}
+
+func (h *encoderHandler) Handle(_ context.Context, record slog.Record) error {
+	doc := make(map[string]any, 4+record.NumAttrs()+len(h.attrs))
+
+	if !record.Time.IsZero() {
+		h.put(doc, nil, slog.Time(slog.TimeKey, record.Time))
+	}
+
+	h.put(doc, nil, slog.Any(slog.LevelKey, record.Level))
+
+	if h.opts.AddSource && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		h.put(doc, nil, slog.Any(slog.SourceKey, &slog.Source{
+			Function: frame.Function,
+			File:     frame.File,
+			Line:     frame.Line,
+		}))
+	}
+
+	h.put(doc, nil, slog.String(slog.MessageKey, record.Message))
+
+	for _, attr := range h.attrs {
+		h.put(doc, attr.groups, attr.attr)
+	}
+
+	record.Attrs(func(attr slog.Attr) bool {
+		h.put(doc, h.groups, attr)
+
+		return true
+	})
+
+	line, err := h.encoder(doc)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, err = h.writer.Write(append(line, '\n'))
+
+	return err
+}
+
+func (h *encoderHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	clone := *h
+	clone.attrs = slices.Clip(h.attrs)
+
+	for _, attr := range attrs {
+		clone.attrs = append(clone.attrs, groupedAttr{groups: h.groups, attr: attr})
+	}
+
+	return &clone
+}
+
+func (h *encoderHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	clone := *h
+	clone.groups = append(slices.Clip(h.groups), name)
+
+	return &clone
+}
+
+// put stores attr in doc under groups, applying ReplaceAttr the way slog.JSONHandler does.
+func (h *encoderHandler) put(doc map[string]any, groups []string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Value.Kind() == slog.KindGroup {
+		members := attr.Value.Group()
+		if len(members) == 0 {
+			return
+		}
+
+		// Inline groups (empty key) merge their members into the enclosing object.
+		nested := groups
+		if attr.Key != "" {
+			nested = append(slices.Clip(groups), attr.Key)
+		}
+
+		for _, member := range members {
+			h.put(doc, nested, member)
+		}
+
+		return
+	}
+
+	if h.opts.ReplaceAttr != nil {
+		attr = h.opts.ReplaceAttr(groups, attr)
+		attr.Value = attr.Value.Resolve()
+	}
+
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	target := doc
+	for _, group := range groups {
+		child, ok := target[group].(map[string]any)
+		if !ok {
+			child = make(map[string]any)
+			target[group] = child
+		}
+
+		target = child
+	}
+
+	target[attr.Key] = encodeValue(attr.Value)
+}
+
+// encodeValue converts v to the value slog.JSONHandler would write for it.
+func encodeValue(v slog.Value) any {
+	switch v.Kind() {
+	case slog.KindString:
+		return v.String()
+	case slog.KindInt64:
+		return v.Int64()
+	case slog.KindUint64:
+		return v.Uint64()
+	case slog.KindFloat64:
+		return v.Float64()
+	case slog.KindBool:
+		return v.Bool()
+	case slog.KindDuration:
+		return int64(v.Duration())
+	case slog.KindTime:
+		return v.Time()
+	case slog.KindGroup:
+		group := make(map[string]any, len(v.Group()))
+		for _, attr := range v.Group() {
+			group[attr.Key] = encodeValue(attr.Value.Resolve())
+		}
+
+		return group
+	default:
+		switch value := v.Any().(type) {
+		case *slog.Source:
+			return map[string]any{"function": value.Function, "file": value.File, "line": value.Line}
+		case slog.Level:
+			return value.String()
+		case error:
+			return value.Error()
+		default:
+			return value
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/logger"
+)
+
+// logSample writes the same record from the same call site, so source matches too.
+func logSample(log *logger.SlogLogger) {
+	log.WarnWithContext(context.Background(), "request failed",
+		slog.String("method", "GET"),
+		slog.Int("status", 502),
+		slog.Duration("elapsed", 1500*time.Millisecond),
+		slog.Any("err", errors.New("upstream timeout")),
+		slog.Group("http", slog.String("path", "/links"), slog.Bool("retry", true)),
+	)
+}
+
+func TestEncoderMatchesJSONHandler(t *testing.T) {
+	decode := func(encoder logger.Encoder) map[string]any {
+		var buffer bytes.Buffer
+
+		log, err := logger.New(logger.Configuration{
+			Level:      logger.INFO_LEVEL,
+			Writer:     &buffer,
+			TimeFormat: "2006",
+			Encoder:    encoder,
+		})
+		require.NoError(t, err, "Error init a logger")
+
+		logSample(log)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &response), "Error unmarshalling")
+
+		return response
+	}
+
+	standard := decode(nil)
+	encoded := decode(json.Marshal)
+
+	assert.Equal(t, standard, encoded)
+	assert.Equal(t, "WARN", encoded["level"])
+	assert.Equal(t, "request failed", encoded["msg"])
+	assert.Contains(t, encoded, "time")
+	assert.Contains(t, encoded, "source")
+}
+
+type benchItem struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	Tags  []string `json:"tags"`
+	Price float64  `json:"price"`
+}
+
+// benchPayload is a struct attribute, where the encoder does most of the work.
+var benchPayload = func() []benchItem {
+	items := make([]benchItem, 20)
+	for i := range items {
+		items[i] = benchItem{ID: i, Name: "item", Tags: []string{"a", "b", "c"}, Price: 9.99}
+	}
+
+	return items
+}()
+
+func BenchmarkInfoEncoder(b *testing.B) {
+	encoders := map[string]logger.Encoder{
+		"slog":      nil,
+		"segmentio": json.Marshal,
+	}
+
+	for name, encoder := range encoders {
+		b.Run(name, func(b *testing.B) {
+			log, err := logger.New(logger.Configuration{
+				Level:      logger.INFO_LEVEL,
+				Writer:     io.Discard,
+				TimeFormat: time.RFC3339,
+				Encoder:    encoder,
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ResetTimer()
+
+			for i := range b.N {
+				log.Info("Benchmark message",
+					slog.Int("iteration", i),
+					slog.String("request_id", "bench-123"),
+					slog.Any("payload", benchPayload),
+				)
+			}
+		})
+	}
+}
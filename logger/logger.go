@@ -19,17 +19,35 @@ func New(cfg Configuration) (*SlogLogger, error) {
 	}
 
 	// JSON handler with source and formatted timestamp (from record, not time.Now)
-	handler := slog.NewJSONHandler(cfg.Writer, &slog.HandlerOptions{
+	opts := slog.HandlerOptions{
 		Level:     convertLevel(cfg.Level),
 		AddSource: true,
 		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
 			if attr.Key == slog.TimeKey && attr.Value.Kind() == slog.KindTime {
-				return slog.String(slog.TimeKey, attr.Value.Time().Format(cfg.TimeFormat))
+				ts := attr.Value.Time()
+				if cfg.UTC {
+					ts = ts.UTC()
+				}
+
+				return slog.String(slog.TimeKey, ts.Format(cfg.TimeFormat))
 			}
 
 			return attr
 		},
-	})
+	}
+
+	output := cfg.output()
+
+	var handler slog.Handler = slog.NewJSONHandler(output, &opts)
+	if cfg.Encoder != nil {
+		handler = newEncoderHandler(output, cfg.Encoder, opts)
+	}
+
+	if cfg.Sampling != nil {
+		handler = newSamplingHandler(handler, *cfg.Sampling)
+	}
+
+	handler = &contextLevelHandler{next: handler}
 
 	return &SlogLogger{logger: slog.New(handler)}, nil
 }
@@ -39,6 +57,12 @@ func (log *SlogLogger) Close() error {
 	return nil
 }
 
+// Enabled reports whether records at level would be written, so callers can
+// skip building expensive fields for disabled levels.
+func (log *SlogLogger) Enabled(ctx context.Context, level slog.Level) bool {
+	return log.logger.Enabled(ctx, level)
+}
+
 // convertLevel converts our int level to slog.Level.
 func convertLevel(level int) slog.Level {
 	switch level {
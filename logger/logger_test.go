@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -75,6 +76,31 @@ func TestOutputInfoWithContextSlog(t *testing.T) {
 	}
 }
 
+func TestOutputUTC(t *testing.T) {
+	var buffer bytes.Buffer
+
+	log, err := logger.New(logger.Configuration{
+		Level:      logger.INFO_LEVEL,
+		Writer:     &buffer,
+		TimeFormat: time.RFC3339,
+		UTC:        true,
+	})
+	require.NoError(t, err, "Error init a logger")
+
+	log.Info("Hello World")
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &response), "Error unmarshalling")
+
+	ts, ok := response["time"].(string)
+	require.True(t, ok, "time should be a string")
+	assert.True(t, strings.HasSuffix(ts, "Z"), "expected UTC timestamp, got %s", ts)
+
+	parsed, err := time.Parse(time.RFC3339, ts)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), parsed, time.Minute)
+}
+
 func BenchmarkOutputSlog(bench *testing.B) {
 	var buffer bytes.Buffer
 
@@ -177,6 +203,52 @@ func TestConfigValidation(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestWriters(t *testing.T) {
+	var first, second bytes.Buffer
+
+	log, err := logger.New(logger.Configuration{
+		Level:   logger.INFO_LEVEL,
+		Writer:  &first,
+		Writers: []io.Writer{&second},
+	})
+	require.NoError(t, err)
+
+	log.Info("Tee record", slog.String("target", "both"))
+
+	require.Contains(t, first.String(), `"msg":"Tee record"`)
+	assert.Equal(t, first.String(), second.String())
+}
+
+func TestMultiWriter_Concurrent(t *testing.T) {
+	var first, second bytes.Buffer
+
+	shared := logger.MultiWriter(&first, &second)
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		log, err := logger.New(logger.Configuration{Level: logger.INFO_LEVEL, Writer: shared})
+		require.NoError(t, err)
+
+		wg.Go(func() {
+			for range 50 {
+				log.Info("Concurrent record")
+			}
+		})
+	}
+
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSpace(first.String()), "\n")
+	require.Len(t, lines, 200)
+
+	for _, line := range lines {
+		require.True(t, json.Valid([]byte(line)), line)
+	}
+
+	assert.Equal(t, first.String(), second.String())
+}
+
 func TestError(t *testing.T) {
 	var buffer bytes.Buffer
 
@@ -314,3 +386,64 @@ func TestDebugWithContext(t *testing.T) {
 	require.Contains(t, buffer.String(), `"data_size":1024`)
 	require.Contains(t, buffer.String(), `"traceID"`)
 }
+
+func TestSamplingDropsRepeatedInfoLines(t *testing.T) {
+	var buffer bytes.Buffer
+
+	log, err := logger.New(logger.Configuration{
+		Level:  logger.INFO_LEVEL,
+		Writer: &buffer,
+		Sampling: &logger.SamplingConfig{
+			Window:     time.Minute,
+			First:      10,
+			Thereafter: 100,
+		},
+	})
+	require.NoError(t, err)
+
+	for range 1000 {
+		log.Info("cache miss")
+	}
+
+	log.Warn("cache degraded")
+	log.Warn("cache degraded")
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+
+	var info, warn int
+
+	for _, line := range lines {
+		switch {
+		case strings.Contains(line, `"msg":"cache miss"`):
+			info++
+		case strings.Contains(line, `"msg":"cache degraded"`):
+			warn++
+		}
+	}
+
+	// 10 initial lines, then every 100th of the remaining 990.
+	assert.Equal(t, 19, info)
+	assert.Equal(t, 2, warn, "WARN must not be sampled by default")
+}
+
+func TestWithLevel(t *testing.T) {
+	var buffer bytes.Buffer
+
+	log, err := logger.New(logger.Configuration{Level: logger.INFO_LEVEL, Writer: &buffer})
+	require.NoError(t, err)
+
+	ctx := logger.WithLevel(context.Background(), slog.LevelDebug)
+
+	log.DebugWithContext(context.Background(), "hidden")
+	assert.Empty(t, buffer.String())
+	assert.False(t, log.Enabled(context.Background(), slog.LevelDebug))
+
+	log.DebugWithContext(ctx, "shown")
+	assert.Contains(t, buffer.String(), `"msg":"shown"`)
+	assert.True(t, log.Enabled(ctx, slog.LevelDebug))
+
+	// The context level only adds verbosity.
+	buffer.Reset()
+	log.InfoWithContext(logger.WithLevel(context.Background(), slog.LevelError), "still shown")
+	assert.Contains(t, buffer.String(), `"msg":"still shown"`)
+}
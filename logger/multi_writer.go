@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// multiWriter duplicates each write to all writers. Unlike io.MultiWriter it keeps
+// writing after a failing destination and serializes writes, so loggers sharing it
+// never interleave records.
+type multiWriter struct {
+	mu      sync.Mutex
+	writers []io.Writer
+}
+
+// MultiWriter returns a writer that writes every record to all writers, e.g. stdout
+// and a file, or a buffer in tests. Nil writers are skipped.
+func MultiWriter(writers ...io.Writer) io.Writer {
+	mw := &multiWriter{writers: make([]io.Writer, 0, len(writers))}
+
+	for _, w := range writers {
+		if w != nil {
+			mw.writers = append(mw.writers, w)
+		}
+	}
+
+	return mw
+}
+
+func (mw *multiWriter) Write(p []byte) (int, error) {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	var errs []error
+
+	for _, w := range mw.writers {
+		n, err := w.Write(p)
+		if err == nil && n != len(p) {
+			err = io.ErrShortWrite
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return len(p), errors.Join(errs...)
+}
@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// SamplingConfig limits repeated log lines: within each Window, the first First
+// records with the same level and message are written, then every Thereafter-th.
+// Records above MaxLevel are never sampled; the zero value samples INFO and DEBUG only.
+type SamplingConfig struct {
+	Window     time.Duration
+	First      int
+	Thereafter int
+	MaxLevel   slog.Level
+}
+
+type samplingKey struct {
+	level slog.Level
+	msg   string
+}
+
+// sampler holds counters shared by a handler and all handlers derived from it.
+type sampler struct {
+	cfg SamplingConfig
+
+	mu          sync.Mutex
+	windowStart time.Time
+	counts      map[samplingKey]int
+}
+
+func (s *sampler) allow(level slog.Level, msg string, now time.Time) bool {
+	if level > s.cfg.MaxLevel {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Resetting all counters per window also bounds memory for high-cardinality messages.
+	if now.Sub(s.windowStart) >= s.cfg.Window {
+		s.windowStart = now
+		clear(s.counts)
+	}
+
+	key := samplingKey{level: level, msg: msg}
+	s.counts[key]++
+	n := s.counts[key]
+
+	if n <= s.cfg.First {
+		return true
+	}
+
+	return s.cfg.Thereafter > 0 && (n-s.cfg.First)%s.cfg.Thereafter == 0
+}
+
+// samplingHandler drops records rejected by the sampler before they reach next.
+type samplingHandler struct {
+	next    slog.Handler
+	sampler *sampler
+}
+
+func newSamplingHandler(next slog.Handler, cfg SamplingConfig) *samplingHandler {
+	if cfg.Window <= 0 {
+		cfg.Window = time.Second
+	}
+
+	return &samplingHandler{
+		next: next,
+		sampler: &sampler{
+			cfg:    cfg,
+			counts: make(map[samplingKey]int),
+		},
+	}
+}
+
+func (h *samplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+//nolint:gocritic // hugeParam: slog.Handler interface passes Record by value.
+func (h *samplingHandler) Handle(ctx context.Context, record slog.Record) error {
+	if !h.sampler.allow(record.Level, record.Message, time.Now()) {
+		return nil
+	}
+
+	return h.next.Handle(ctx, record)
+}
+
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{next: h.next.WithAttrs(attrs), sampler: h.sampler}
+}
+
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{next: h.next.WithGroup(name), sampler: h.sampler}
+}
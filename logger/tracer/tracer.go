@@ -16,13 +16,20 @@ import (
 
 const callersSkip = 3
 
+// Log keys of the correlation ids added by NewTraceFromContext and CorrelationFields.
+const (
+	TraceIDKey = "traceID"
+	SpanIDKey  = "spanID"
+)
+
 // ErrLogField is a sentinel used to wrap error messages extracted from log fields.
 var ErrLogField = errors.New("log field error")
 
 // NewTraceFromContext
 // - If an active span exists: add an Event ("log.<LEVEL>") with attributes.
 // - If there is no active span: create a short span only for WARN/ERROR.
-// - Always return fields augmented with traceID/spanID when a span exists.
+// - Always return fields augmented with traceID/spanID when a span exists, unless
+//   fields already carry them (e.g. from CorrelationFields).
 func NewTraceFromContext(
 	ctx context.Context,
 	level string, // "INFO"|"WARN"|"ERROR"|...
@@ -44,12 +51,7 @@ func NewTraceFromContext(
 		span.AddEvent("log."+levelUpper, trace.WithAttributes(attrs...))
 		annotateByLevel(span, levelUpper, msg, capturedErr)
 
-		out := append(append([]slog.Attr{}, fields...),
-			slog.String("traceID", span.SpanContext().TraceID().String()),
-			slog.String("spanID", span.SpanContext().SpanID().String()),
-		)
-
-		return out, nil
+		return withCorrelationFields(fields, span.SpanContext()), nil
 	}
 
 	// 3) No active span — create only for important levels
@@ -65,12 +67,44 @@ func NewTraceFromContext(
 	span.SetAttributes(attrs...)
 	annotateByLevel(span, levelUpper, msg, capturedErr)
 
-	out := append(append([]slog.Attr{}, fields...),
-		slog.String("traceID", span.SpanContext().TraceID().String()),
-		slog.String("spanID", span.SpanContext().SpanID().String()),
-	)
+	return withCorrelationFields(fields, span.SpanContext()), nil
+}
+
+// CorrelationFields returns the traceID and spanID of the span in ctx, or nil when
+// ctx carries no valid span context. Unlike NewTraceFromContext it never records
+// events or starts spans.
+func CorrelationFields(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return nil
+	}
 
-	return out, nil
+	return correlationFields(spanCtx)
+}
+
+// withCorrelationFields returns a copy of fields with the ids of spanCtx appended,
+// keeping fields as is when a middleware already added them.
+func withCorrelationFields(fields []slog.Attr, spanCtx trace.SpanContext) []slog.Attr {
+	out := append([]slog.Attr{}, fields...)
+
+	for _, field := range fields {
+		if field.Key == TraceIDKey {
+			return out
+		}
+	}
+
+	return append(out, correlationFields(spanCtx)...)
+}
+
+func correlationFields(spanCtx trace.SpanContext) []slog.Attr {
+	return []slog.Attr{
+		slog.String(TraceIDKey, spanCtx.TraceID().String()),
+		slog.String(SpanIDKey, spanCtx.SpanID().String()),
+	}
 }
 
 func isSmallLevel(levelUpper string) bool {
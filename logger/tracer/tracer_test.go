@@ -367,3 +367,47 @@ func Test_NewTraceFromContext_Normalization_IsErrorAndStringErr_OnActiveSpan(t *
 		assert.Equal(t, "oops", exceptionMessage)
 	}
 }
+
+func Test_CorrelationFields_ActiveSpan(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	t.Cleanup(func() {
+		err := tp.Shutdown(context.Background())
+		require.NoError(t, err)
+	})
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "root")
+
+	fields := tracer.CorrelationFields(ctx)
+
+	require.Equal(t, []slog.Attr{
+		slog.String(tracer.TraceIDKey, span.SpanContext().TraceID().String()),
+		slog.String(tracer.SpanIDKey, span.SpanContext().SpanID().String()),
+	}, fields)
+
+	span.End()
+
+	spans := rec.Ended()
+	require.Len(t, spans, 1, "must not start spans")
+	assert.Empty(t, spans[0].Events(), "must not record events")
+}
+
+func Test_CorrelationFields_NoSpan(t *testing.T) {
+	assert.Empty(t, tracer.CorrelationFields(context.Background()))
+}
+
+func Test_NewTraceFromContext_KeepsCorrelationFields(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	t.Cleanup(func() {
+		err := tp.Shutdown(context.Background())
+		require.NoError(t, err)
+	})
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "root")
+	defer span.End()
+
+	fields, err := tracer.NewTraceFromContext(ctx, "INFO", "msg", nil, tracer.CorrelationFields(ctx)...)
+	require.NoError(t, err)
+
+	assert.Equal(t, tracer.CorrelationFields(ctx), fields, "must not add the ids twice")
+}
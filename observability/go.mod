@@ -71,4 +71,7 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
-replace github.com/shortlink-org/go-sdk/config => ../config
+replace (
+	github.com/shortlink-org/go-sdk/config => ../config
+	github.com/shortlink-org/go-sdk/http => ../http
+)
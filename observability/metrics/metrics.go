@@ -27,9 +27,11 @@ type Monitoring struct {
 	Prometheus *prometheus.Registry
 	Metrics    *api.MeterProvider
 	cfg        *config.Config
+	health     healthcheck.Handler
 }
 
-// New - Monitoring endpoints
+// New - Monitoring endpoints.
+// It returns an error when the monitoring port cannot be bound.
 func New(ctx context.Context, log logger.Logger, tracer trace.TracerProvider, cfg *config.Config) (*Monitoring, func(), error) {
 	var err error
 
@@ -47,25 +49,33 @@ func New(ctx context.Context, log logger.Logger, tracer trace.TracerProvider, cf
 		return nil, nil, err
 	}
 
-	go func() {
-		// Create a new HTTP server for Prometheus metrics
-		serverConfig := http_server.Config{
-			Port:    9090,             //nolint:mnd // port for Prometheus metrics
-			Timeout: 30 * time.Second, //nolint:mnd // timeout for Prometheus metrics
-		}
-		server := http_server.New(ctx, monitoring.Handler, serverConfig, cfg)
+	// Create a new HTTP server for Prometheus metrics
+	cfg.SetDefault("METRICS_PORT", 9090) //nolint:mnd // port for Prometheus metrics
 
-		errListenAndServe := server.ListenAndServe()
-		if errListenAndServe != nil {
-			log.Error(errListenAndServe.Error())
-		}
-	}()
+	serverConfig := http_server.Config{
+		Port:    cfg.GetInt("METRICS_PORT"),
+		Timeout: 30 * time.Second, //nolint:mnd // timeout for Prometheus metrics
+	}
+
+	server, err := http_server.Start(ctx, monitoring.Handler, serverConfig, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	log.Info("Run monitoring",
-		slog.String("addr", "0.0.0.0:9090"),
+		slog.String("addr", server.Addr()),
 	)
 
 	return monitoring, func() {
+		// Let in-flight scrapes finish before tearing down the meter provider.
+		drainCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), serverConfig.Timeout)
+		defer cancel()
+
+		errDrain := server.Shutdown(drainCtx)
+		if errDrain != nil {
+			log.ErrorWithContext(ctx, errDrain.Error())
+		}
+
 		errShutdown := monitoring.Metrics.Shutdown(ctx)
 		if errShutdown != nil {
 			log.ErrorWithContext(ctx, errShutdown.Error())
@@ -111,11 +121,15 @@ func (m *Monitoring) SetMetrics(ctx context.Context) (*api.MeterProvider, error)
 
 // SetHandler - Create a "common" handler for metrics
 func (m *Monitoring) SetHandler() (*http.ServeMux, error) {
+	m.cfg.SetDefault("METRICS_PATH", "/metrics")
+	m.cfg.SetDefault("HEALTH_LIVE_PATH", "/live")
+	m.cfg.SetDefault("HEALTH_READY_PATH", "/ready")
+
 	// Create a "common" listener
 	handler := http.NewServeMux()
 
-	// Expose prometheus metrics on /metrics
-	handler.Handle("/metrics", promhttp.HandlerFor(
+	// Expose prometheus metrics on METRICS_PATH (default /metrics)
+	handler.Handle(m.cfg.GetString("METRICS_PATH"), promhttp.HandlerFor(
 		m.Prometheus,
 		promhttp.HandlerOpts{
 			// Opt into OpenMetrics to support exemplars.
@@ -125,17 +139,55 @@ func (m *Monitoring) SetHandler() (*http.ServeMux, error) {
 		},
 	))
 
+	m.ensureHealth()
+
+	// Expose a liveness check on HEALTH_LIVE_PATH (default /live)
+	handler.HandleFunc(m.cfg.GetString("HEALTH_LIVE_PATH"), m.health.LiveEndpoint)
+
+	// Expose a readiness check on HEALTH_READY_PATH (default /ready)
+	handler.HandleFunc(m.cfg.GetString("HEALTH_READY_PATH"), m.readyEndpoint)
+
+	return handler, nil
+}
+
+// RegisterReadiness adds a named check to the readiness endpoint. The endpoint
+// returns 503 while any check fails, with every check's status in the JSON body.
+// Registering a name again replaces its check. Call it after New.
+func (m *Monitoring) RegisterReadiness(name string, check healthcheck.Check) {
+	m.ensureHealth()
+	m.health.AddReadinessCheck(name, check)
+}
+
+// ensureHealth creates the health handler once, so checks registered before
+// SetHandler are kept.
+func (m *Monitoring) ensureHealth() {
+	if m.health != nil {
+		return
+	}
+
+	if m.Prometheus == nil {
+		m.health = healthcheck.NewHandler()
+
+		return
+	}
+
 	// Create a metrics-exposing Handler for the Prometheus registry
 	// The health check related metrics will be prefixed with the provided namespace
-	health := healthcheck.NewMetricsHandler(m.Prometheus, "common")
+	m.health = healthcheck.NewMetricsHandler(m.Prometheus, "common")
+}
 
-	// Expose a liveness check on /live
-	handler.HandleFunc("/live", health.LiveEndpoint)
+// readyEndpoint always reports per-check details; probes only look at the
+// status code. An explicit ?full= query is passed through unchanged.
+func (m *Monitoring) readyEndpoint(w http.ResponseWriter, r *http.Request) {
+	if !r.URL.Query().Has("full") {
+		r = r.Clone(r.Context())
 
-	// Expose a readiness check on /ready
-	handler.HandleFunc("/ready", health.ReadyEndpoint)
+		query := r.URL.Query()
+		query.Set("full", "1")
+		r.URL.RawQuery = query.Encode()
+	}
 
-	return handler, nil
+	m.health.ReadyEndpoint(w, r)
 }
 
 // SetPrometheus - Create a new Prometheus registry
@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/shortlink-org/go-sdk/config"
+	"github.com/shortlink-org/go-sdk/logger"
+)
+
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	listener, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("reserve port: %v", err)
+	}
+
+	port := listener.Addr().(*net.TCPAddr).Port //nolint:forcetypeassert // tcp listener
+
+	if err := listener.Close(); err != nil {
+		t.Fatalf("release port: %v", err)
+	}
+
+	return port
+}
+
+func get(t *testing.T, url string) int {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, http.NoBody)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+
+	_ = resp.Body.Close()
+
+	return resp.StatusCode
+}
+
+func TestNew_CustomPortAndPaths(t *testing.T) {
+	port := freePort(t)
+
+	t.Setenv("METRICS_PORT", strconv.Itoa(port))
+	t.Setenv("METRICS_PATH", "/internal/metrics")
+	t.Setenv("HEALTH_LIVE_PATH", "/healthz")
+	t.Setenv("HEALTH_READY_PATH", "/readyz")
+
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatalf("config.New() returned error: %v", err)
+	}
+
+	t.Cleanup(cfg.Reset)
+
+	log, err := logger.New(logger.Configuration{Writer: io.Discard})
+	if err != nil {
+		t.Fatalf("logger.New() returned error: %v", err)
+	}
+
+	_, cleanup, err := New(context.Background(), log, noop.NewTracerProvider(), cfg)
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	t.Cleanup(cleanup)
+
+	base := "http://127.0.0.1:" + strconv.Itoa(port)
+
+	for path, want := range map[string]int{
+		"/internal/metrics": http.StatusOK,
+		"/healthz":          http.StatusOK,
+		"/readyz":           http.StatusOK,
+		"/metrics":          http.StatusNotFound,
+	} {
+		if got := get(t, base+path); got != want {
+			t.Errorf("GET %s = %d, want %d", path, got, want)
+		}
+	}
+}
+
+func TestRegisterReadiness_AggregatesChecks(t *testing.T) {
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatalf("config.New() returned error: %v", err)
+	}
+
+	t.Cleanup(cfg.Reset)
+
+	monitoring := &Monitoring{cfg: cfg, Prometheus: prometheus.NewRegistry()}
+
+	handler, err := monitoring.SetHandler()
+	if err != nil {
+		t.Fatalf("SetHandler() returned error: %v", err)
+	}
+
+	monitoring.RegisterReadiness("postgres", func() error { return nil })
+	monitoring.RegisterReadiness("kafka", func() error { return errors.New("broker unreachable") })
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/ready", http.NoBody))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("GET /ready = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+
+	var checks map[string]string
+	if err := json.NewDecoder(rec.Body).Decode(&checks); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+
+	want := map[string]string{"postgres": "OK", "kafka": "broker unreachable"}
+	if !maps.Equal(checks, want) {
+		t.Errorf("checks = %v, want %v", checks, want)
+	}
+}
+
+func TestRegisterReadiness_BeforeSetHandler(t *testing.T) {
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatalf("config.New() returned error: %v", err)
+	}
+
+	t.Cleanup(cfg.Reset)
+
+	monitoring := &Monitoring{cfg: cfg, Prometheus: prometheus.NewRegistry()}
+	monitoring.RegisterReadiness("kafka", func() error { return errors.New("broker unreachable") })
+
+	handler, err := monitoring.SetHandler()
+	if err != nil {
+		t.Fatalf("SetHandler() returned error: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/ready", http.NoBody))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("GET /ready = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+}
@@ -1,25 +1,18 @@
 package specification
 
-import (
-	"errors"
-)
-
 // AndSpecification is a composite specification that represents the logical AND of two other specifications.
 type AndSpecification[T any] struct {
 	Specs []Specification[T]
 }
 
 func (a *AndSpecification[T]) IsSatisfiedBy(item *T) error {
-	var errs error
+	var errs errBuffer
 
 	for _, spec := range a.Specs {
-		err := spec.IsSatisfiedBy(item)
-		if err != nil {
-			errs = errors.Join(errs, err)
-		}
+		errs.add(spec.IsSatisfiedBy(item))
 	}
 
-	return errs
+	return errs.join()
 }
 
 func NewAndSpecification[T any](specs ...Specification[T]) *AndSpecification[T] {
@@ -0,0 +1,106 @@
+package specification
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrSpecPanicked is returned to callers that waited on an evaluation whose inner
+// specification panicked. The panic itself propagates to the caller that ran it.
+var ErrSpecPanicked = errors.New("specification: cached specification panicked")
+
+// CachedSpecification memoizes the result of a pure specification per entity key for a TTL.
+//
+// Invalidation is purely TTL-based: a change to the entity or to the data the inner spec
+// reads is not observed until the cached result expires, so pick a TTL no longer than the
+// staleness the rule can tolerate (typically the lifetime of a request).
+type CachedSpecification[T any] struct {
+	spec  Specification[T]
+	keyFn func(*T) string
+	ttl   time.Duration
+
+	mu        sync.Mutex
+	entries   map[string]*cacheEntry
+	lastSweep time.Time
+}
+
+type cacheEntry struct {
+	ready     chan struct{}
+	err       error
+	expiresAt time.Time
+}
+
+// Cached wraps spec so repeated evaluations of the same entity, as identified by keyFn,
+// reuse the first result until ttl elapses. Concurrent evaluations of a key share one
+// inner call.
+func Cached[T any](spec Specification[T], keyFn func(*T) string, ttl time.Duration) *CachedSpecification[T] {
+	return &CachedSpecification[T]{
+		spec:    spec,
+		keyFn:   keyFn,
+		ttl:     ttl,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+func (c *CachedSpecification[T]) IsSatisfiedBy(item *T) error {
+	key := c.keyFn(item)
+	now := time.Now()
+
+	c.mu.Lock()
+
+	entry, ok := c.entries[key]
+	if ok && (entry.expiresAt.IsZero() || now.Before(entry.expiresAt)) {
+		c.mu.Unlock()
+		<-entry.ready
+
+		return entry.err
+	}
+
+	c.sweep(now)
+
+	entry = &cacheEntry{ready: make(chan struct{}), err: ErrSpecPanicked}
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	c.evaluate(key, entry, item)
+
+	return entry.err
+}
+
+// evaluate runs the inner spec for entry and releases its waiters. If the inner spec
+// panics, waiters get ErrSpecPanicked and the entry is dropped so the next call retries.
+func (c *CachedSpecification[T]) evaluate(key string, entry *cacheEntry, item *T) {
+	completed := false
+
+	defer func() {
+		c.mu.Lock()
+		if completed {
+			entry.expiresAt = time.Now().Add(c.ttl)
+		} else if c.entries[key] == entry {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+
+		close(entry.ready)
+	}()
+
+	entry.err = c.spec.IsSatisfiedBy(item)
+	completed = true
+}
+
+// sweep drops expired entries at most once per TTL so the cache doesn't grow with every key seen.
+// Callers must hold c.mu.
+func (c *CachedSpecification[T]) sweep(now time.Time) {
+	if now.Sub(c.lastSweep) < c.ttl {
+		return
+	}
+
+	c.lastSweep = now
+
+	for key, entry := range c.entries {
+		if !entry.expiresAt.IsZero() && !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package specification_test
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/specification"
+)
+
+type countingSpec struct {
+	calls atomic.Int64
+	inner specification.Specification[TestUser]
+}
+
+func (c *countingSpec) IsSatisfiedBy(user *TestUser) error {
+	c.calls.Add(1)
+	time.Sleep(time.Millisecond)
+
+	return c.inner.IsSatisfiedBy(user)
+}
+
+func userKey(user *TestUser) string {
+	return strconv.Itoa(user.ID)
+}
+
+func TestCached_InnerSpecRunsOnceWithinTTL(t *testing.T) {
+	inner := &countingSpec{inner: &UserAgeMinSpec{MinAge: 18}}
+	spec := specification.Cached[TestUser](inner, userKey, time.Minute)
+
+	adult := &TestUser{ID: 1, Age: 30}
+	minor := &TestUser{ID: 2, Age: 10}
+
+	var wg sync.WaitGroup
+
+	for range 50 {
+		wg.Go(func() {
+			assert.NoError(t, spec.IsSatisfiedBy(adult))
+			assert.Error(t, spec.IsSatisfiedBy(minor))
+		})
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int64(2), inner.calls.Load())
+}
+
+func TestCached_ReevaluatesAfterTTL(t *testing.T) {
+	inner := &countingSpec{inner: &UserAgeMinSpec{MinAge: 18}}
+	spec := specification.Cached[TestUser](inner, userKey, 10*time.Millisecond)
+
+	user := &TestUser{ID: 1, Age: 30}
+	require.NoError(t, spec.IsSatisfiedBy(user))
+
+	// Stale result is served until the TTL expires.
+	user.Age = 10
+	require.NoError(t, spec.IsSatisfiedBy(user))
+	assert.Equal(t, int64(1), inner.calls.Load())
+
+	time.Sleep(20 * time.Millisecond)
+
+	require.Error(t, spec.IsSatisfiedBy(user))
+	assert.Equal(t, int64(2), inner.calls.Load())
+}
+
+type panickingSpec struct {
+	calls   atomic.Int64
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *panickingSpec) IsSatisfiedBy(*TestUser) error {
+	if p.calls.Add(1) == 1 {
+		close(p.started)
+		<-p.release
+		panic("boom")
+	}
+
+	return nil
+}
+
+func TestCached_PanicReleasesWaiters(t *testing.T) {
+	inner := &panickingSpec{started: make(chan struct{}), release: make(chan struct{})}
+	spec := specification.Cached[TestUser](inner, userKey, time.Minute)
+
+	user := &TestUser{ID: 1}
+
+	go func() {
+		defer func() { _ = recover() }()
+
+		_ = spec.IsSatisfiedBy(user)
+	}()
+
+	<-inner.started
+
+	waiter := make(chan error, 1)
+
+	go func() { waiter <- spec.IsSatisfiedBy(user) }()
+
+	// Give the waiter time to block on the in-flight evaluation.
+	time.Sleep(10 * time.Millisecond)
+	close(inner.release)
+
+	select {
+	case err := <-waiter:
+		if err != nil {
+			require.ErrorIs(t, err, specification.ErrSpecPanicked)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter blocked after the inner spec panicked")
+	}
+
+	// The panicked entry was dropped, so the next call evaluates again.
+	require.NoError(t, spec.IsSatisfiedBy(user))
+	assert.GreaterOrEqual(t, inner.calls.Load(), int64(2))
+}
@@ -0,0 +1,83 @@
+package specification
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrEmptyCollection is returned by AnySpecification when the sub-collection has no elements.
+var ErrEmptyCollection = errors.New("specification: empty collection")
+
+// AllSpecification applies Inner to every element of the sub-collection returned by Getter.
+// An empty sub-collection satisfies it.
+type AllSpecification[T, E any] struct {
+	Getter func(*T) []*E
+	Inner  Specification[E]
+}
+
+// IsSatisfiedBy joins the error of every failing element, annotated with its index.
+// Nil elements fail with ErrNilElement.
+func (a *AllSpecification[T, E]) IsSatisfiedBy(item *T) error {
+	var errs error
+
+	for i, elem := range a.Getter(item) {
+		if err := satisfiedAt(a.Inner, elem, i); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// All passes when every element returned by getter satisfies inner,
+// e.g. "all orders of the user are paid".
+func All[T, E any](getter func(*T) []*E, inner Specification[E]) *AllSpecification[T, E] {
+	return &AllSpecification[T, E]{Getter: getter, Inner: inner}
+}
+
+// AnySpecification passes when at least one element of the sub-collection returned by Getter satisfies Inner.
+// An empty sub-collection fails with ErrEmptyCollection.
+type AnySpecification[T, E any] struct {
+	Getter func(*T) []*E
+	Inner  Specification[E]
+}
+
+// IsSatisfiedBy returns nil on the first passing element, otherwise the joined
+// errors of all elements, annotated with their index.
+func (a *AnySpecification[T, E]) IsSatisfiedBy(item *T) error {
+	elems := a.Getter(item)
+	if len(elems) == 0 {
+		return ErrEmptyCollection
+	}
+
+	var errs error
+
+	for i, elem := range elems {
+		err := satisfiedAt(a.Inner, elem, i)
+		if err == nil {
+			return nil
+		}
+
+		errs = errors.Join(errs, err)
+	}
+
+	return errs
+}
+
+// Any passes when at least one element returned by getter satisfies inner,
+// e.g. "the user has a paid order".
+func Any[T, E any](getter func(*T) []*E, inner Specification[E]) *AnySpecification[T, E] {
+	return &AnySpecification[T, E]{Getter: getter, Inner: inner}
+}
+
+func satisfiedAt[E any](spec Specification[E], elem *E, index int) error {
+	if elem == nil {
+		return fmt.Errorf("%w at index %d", ErrNilElement, index)
+	}
+
+	if err := spec.IsSatisfiedBy(elem); err != nil {
+		return fmt.Errorf("element %d: %w", index, err)
+	}
+
+	return nil
+}
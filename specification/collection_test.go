@@ -0,0 +1,113 @@
+package specification_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/specification"
+)
+
+type TestOrder struct {
+	ID   int
+	Paid bool
+}
+
+type TestCustomer struct {
+	Name   string
+	Orders []*TestOrder
+}
+
+var errOrderNotPaid = errors.New("order is not paid")
+
+type OrderPaidSpec struct{}
+
+func (o *OrderPaidSpec) IsSatisfiedBy(order *TestOrder) error {
+	if !order.Paid {
+		return fmt.Errorf("order %d: %w", order.ID, errOrderNotPaid)
+	}
+
+	return nil
+}
+
+func customerOrders(c *TestCustomer) []*TestOrder {
+	return c.Orders
+}
+
+func TestAll_AggregatesFailingElements(t *testing.T) {
+	// Arrange
+	spec := specification.All[TestCustomer, TestOrder](customerOrders, &OrderPaidSpec{})
+	customer := &TestCustomer{Orders: []*TestOrder{
+		{ID: 1, Paid: true},
+		{ID: 2, Paid: false},
+		{ID: 3, Paid: true},
+	}}
+
+	// Act
+	err := spec.IsSatisfiedBy(customer)
+
+	// Assert
+	require.ErrorIs(t, err, errOrderNotPaid)
+	assert.Contains(t, err.Error(), "element 1: order 2")
+	assert.NotContains(t, err.Error(), "order 1:")
+	assert.NotContains(t, err.Error(), "order 3:")
+
+	customer.Orders[1].Paid = true
+	require.NoError(t, spec.IsSatisfiedBy(customer))
+}
+
+func TestAll_EmptyAndNilElements(t *testing.T) {
+	// Arrange
+	spec := specification.All[TestCustomer, TestOrder](customerOrders, &OrderPaidSpec{})
+
+	// Assert
+	require.NoError(t, spec.IsSatisfiedBy(&TestCustomer{}))
+
+	err := spec.IsSatisfiedBy(&TestCustomer{Orders: []*TestOrder{{ID: 1, Paid: true}, nil}})
+	require.ErrorIs(t, err, specification.ErrNilElement)
+	assert.Contains(t, err.Error(), "index 1")
+}
+
+func TestAny_PassesWhenOneElementPasses(t *testing.T) {
+	// Arrange
+	spec := specification.Any[TestCustomer, TestOrder](customerOrders, &OrderPaidSpec{})
+
+	testCases := []struct {
+		name     string
+		orders   []*TestOrder
+		expected error
+	}{
+		{name: "One paid", orders: []*TestOrder{{ID: 1}, {ID: 2, Paid: true}}},
+		{name: "None paid", orders: []*TestOrder{{ID: 1}, {ID: 2}}, expected: errOrderNotPaid},
+		{name: "No orders", expected: specification.ErrEmptyCollection},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			err := spec.IsSatisfiedBy(&TestCustomer{Orders: testCase.orders})
+
+			// Assert
+			if testCase.expected == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestAll_ComposesWithAllOf(t *testing.T) {
+	// Arrange
+	spec := specification.AllOf[TestCustomer](
+		specification.All[TestCustomer, TestOrder](customerOrders, &OrderPaidSpec{}),
+		specification.Any[TestCustomer, TestOrder](customerOrders, &OrderPaidSpec{}),
+	)
+
+	// Assert
+	require.NoError(t, spec.IsSatisfiedBy(&TestCustomer{Orders: []*TestOrder{{ID: 1, Paid: true}}}))
+	require.ErrorIs(t, spec.IsSatisfiedBy(&TestCustomer{}), specification.ErrEmptyCollection)
+}
@@ -0,0 +1,17 @@
+package specification
+
+// AllOf is a readable alias for NewAndSpecification: it passes only when every spec passes.
+func AllOf[T any](specs ...Specification[T]) *AndSpecification[T] {
+	return NewAndSpecification(specs...)
+}
+
+// AnyOf is a readable alias for NewOrSpecification: it passes when at least one spec passes.
+func AnyOf[T any](specs ...Specification[T]) *OrSpecification[T] {
+	return NewOrSpecification(specs...)
+}
+
+// NoneOf passes only when every spec fails. It is NOT(OR(specs...)), so the
+// returned NotSpecification wraps an *OrSpecification.
+func NoneOf[T any](specs ...Specification[T]) *NotSpecification[T] {
+	return NewNotSpecification[T](NewOrSpecification(specs...))
+}
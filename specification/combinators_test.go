@@ -0,0 +1,71 @@
+package specification_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/specification"
+)
+
+func TestAllOf_ReturnsAndSpecification(t *testing.T) {
+	// Arrange
+	spec := specification.AllOf[TestUser](&UserActiveSpec{}, &UserAgeMinSpec{MinAge: 18})
+
+	// Assert
+	assert.Len(t, spec.Specs, 2)
+	require.NoError(t, spec.IsSatisfiedBy(&TestUser{Age: 25, IsActive: true}))
+	require.Error(t, spec.IsSatisfiedBy(&TestUser{Age: 17, IsActive: true}))
+}
+
+func TestAnyOf_ReturnsOrSpecification(t *testing.T) {
+	// Arrange
+	spec := specification.AnyOf[TestUser](&UserActiveSpec{}, &UserAgeMinSpec{MinAge: 18})
+
+	// Assert
+	assert.Len(t, spec.Specs, 2)
+	assert.False(t, spec.FirstErrorOnly)
+	require.NoError(t, spec.IsSatisfiedBy(&TestUser{Age: 17, IsActive: true}))
+	require.Error(t, spec.IsSatisfiedBy(&TestUser{Age: 17, IsActive: false}))
+}
+
+func TestNoneOf_PassesOnlyWhenEveryInnerSpecFails(t *testing.T) {
+	// Arrange
+	spec := specification.NoneOf[TestUser](&UserActiveSpec{}, &UserAgeMinSpec{MinAge: 18})
+
+	testCases := []struct {
+		name     string
+		user     *TestUser
+		expected bool
+	}{
+		{name: "Active adult", user: &TestUser{Age: 25, IsActive: true}, expected: false},
+		{name: "Inactive adult", user: &TestUser{Age: 30, IsActive: false}, expected: false},
+		{name: "Active minor", user: &TestUser{Age: 17, IsActive: true}, expected: false},
+		{name: "Inactive minor", user: &TestUser{Age: 16, IsActive: false}, expected: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			err := spec.IsSatisfiedBy(testCase.user)
+
+			// Assert
+			if testCase.expected {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, specification.ErrNotSatisfied)
+			}
+		})
+	}
+}
+
+func TestNoneOf_WrapsOrSpecification(t *testing.T) {
+	// Arrange
+	spec := specification.NoneOf[TestUser](&UserActiveSpec{})
+
+	// Assert
+	orSpec, ok := spec.Spec.(*specification.OrSpecification[TestUser])
+	require.True(t, ok)
+	assert.Len(t, orSpec.Specs, 1)
+}
@@ -0,0 +1,167 @@
+package dsl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrConditionNotSatisfied is returned when a field does not match its condition.
+	ErrConditionNotSatisfied = errors.New("specification/dsl: condition not satisfied")
+	// ErrFieldNotFound is returned when the entity has no value for a condition field.
+	ErrFieldNotFound = errors.New("specification/dsl: field not found")
+)
+
+// operator checks a condition value once at parse time and matches entity values at evaluation time.
+type operator struct {
+	validate func(value any) error
+	match    func(actual, value any) bool
+}
+
+var operators = map[string]operator{
+	"==": {validate: scalar, match: equal},
+	"!=": {validate: scalar, match: func(actual, value any) bool { return !equal(actual, value) }},
+	">":  {validate: ordered, match: compareWith(func(c int) bool { return c > 0 })},
+	">=": {validate: ordered, match: compareWith(func(c int) bool { return c >= 0 })},
+	"<":  {validate: ordered, match: compareWith(func(c int) bool { return c < 0 })},
+	"<=": {validate: ordered, match: compareWith(func(c int) bool { return c <= 0 })},
+	"in": {validate: list, match: in},
+}
+
+// condition is a leaf node: field op value.
+type condition[T any] struct {
+	field   string
+	op      string
+	value   any
+	match   func(actual, value any) bool
+	resolve Resolver[T]
+}
+
+func (c *condition[T]) IsSatisfiedBy(item *T) error {
+	actual, ok := c.resolve(item, c.field)
+	if !ok {
+		return ErrFieldNotFound
+	}
+
+	if !c.match(actual, c.value) {
+		return fmt.Errorf("%w: %v %s %v", ErrConditionNotSatisfied, actual, c.op, c.value)
+	}
+
+	return nil
+}
+
+func scalar(value any) error {
+	switch value.(type) {
+	case nil, bool, float64, string:
+		return nil
+	default:
+		return errors.New("expected a string, number, boolean or null")
+	}
+}
+
+func ordered(value any) error {
+	switch value.(type) {
+	case float64, string:
+		return nil
+	default:
+		return errors.New("expected a string or number")
+	}
+}
+
+func list(value any) error {
+	items, ok := value.([]any)
+	if !ok {
+		return errors.New("expected an array")
+	}
+
+	for _, item := range items {
+		err := scalar(item)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func equal(actual, value any) bool {
+	if number, ok := value.(float64); ok {
+		n, ok := toFloat(actual)
+
+		return ok && n == number
+	}
+
+	return actual == value
+}
+
+func compareWith(accept func(int) bool) func(actual, value any) bool {
+	return func(actual, value any) bool {
+		switch v := value.(type) {
+		case float64:
+			n, ok := toFloat(actual)
+			if !ok {
+				return false
+			}
+
+			switch {
+			case n < v:
+				return accept(-1)
+			case n > v:
+				return accept(1)
+			default:
+				return accept(0)
+			}
+		case string:
+			s, ok := actual.(string)
+
+			return ok && accept(strings.Compare(s, v))
+		default:
+			return false
+		}
+	}
+}
+
+func in(actual, value any) bool {
+	for _, item := range value.([]any) { //nolint:forcetypeassert // checked by list at parse time
+		if equal(actual, item) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toFloat normalises Go numeric kinds, so entities may hold ints while JSON values are float64.
+func toFloat(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() { //nolint:exhaustive // only numeric kinds convert
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// lookup walks dotted paths through nested maps.
+func lookup(item map[string]any, field string) (any, bool) {
+	head, rest, nested := strings.Cut(field, ".")
+
+	value, ok := item[head]
+	if !ok || !nested {
+		return value, ok
+	}
+
+	child, ok := value.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	return lookup(child, rest)
+}
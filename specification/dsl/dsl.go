@@ -0,0 +1,161 @@
+// Package dsl builds specifications from JSON documents, so rules such as
+// eligibility checks can be changed without a redeploy.
+//
+// A document is a tree of nodes. Every node has exactly one of the keys:
+//
+//	{"and": [node, ...]}
+//	{"or": [node, ...]}
+//	{"not": node}
+//	{"field": "age", "op": ">=", "value": 18}
+package dsl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/shortlink-org/go-sdk/specification"
+)
+
+var (
+	// ErrInvalidDocument is returned when the document is not valid JSON or a node has an unexpected shape.
+	ErrInvalidDocument = errors.New("specification/dsl: invalid document")
+	// ErrUnknownOperator is returned when a condition uses an unsupported operator.
+	ErrUnknownOperator = errors.New("specification/dsl: unknown operator")
+	// ErrInvalidValue is returned when a condition value does not fit its operator.
+	ErrInvalidValue = errors.New("specification/dsl: invalid value")
+)
+
+// Resolver returns the value of field on item and whether the field exists.
+type Resolver[T any] func(item *T, field string) (any, bool)
+
+// node is the wire form of one document node.
+type node struct {
+	And   []json.RawMessage `json:"and"`
+	Or    []json.RawMessage `json:"or"`
+	Not   json.RawMessage   `json:"not"`
+	Field string            `json:"field"`
+	Op    string            `json:"op"`
+	Value any               `json:"value"`
+}
+
+// Parse builds a specification over map entities. Fields may address nested
+// maps with dots, e.g. "address.city".
+func Parse(data []byte) (specification.Specification[map[string]any], error) {
+	return ParseWith(data, MapResolver)
+}
+
+// ParseWith builds a specification over T, reading condition fields through resolve.
+// Every condition is wrapped in a FieldSpecification, so specification.Explain
+// attributes failures to their fields.
+func ParseWith[T any](data []byte, resolve Resolver[T]) (specification.Specification[T], error) {
+	p := parser[T]{resolve: resolve}
+
+	return p.parse(data, "$")
+}
+
+// MapResolver resolves dotted field paths in nested map[string]any values.
+func MapResolver(item *map[string]any, field string) (any, bool) {
+	if item == nil {
+		return nil, false
+	}
+
+	return lookup(*item, field)
+}
+
+type parser[T any] struct {
+	resolve Resolver[T]
+}
+
+func (p parser[T]) parse(data []byte, path string) (specification.Specification[T], error) {
+	var n node
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(&n)
+	if err != nil {
+		return nil, fmt.Errorf("%w at %s: %w", ErrInvalidDocument, path, err)
+	}
+
+	kinds := 0
+
+	for _, set := range []bool{n.And != nil, n.Or != nil, n.Not != nil, n.Field != ""} {
+		if set {
+			kinds++
+		}
+	}
+
+	if kinds != 1 {
+		return nil, fmt.Errorf("%w at %s: node must have exactly one of and, or, not, field", ErrInvalidDocument, path)
+	}
+
+	switch {
+	case n.And != nil:
+		specs, err := p.parseList(n.And, path+".and")
+		if err != nil {
+			return nil, err
+		}
+
+		return specification.NewAndSpecification(specs...), nil
+	case n.Or != nil:
+		specs, err := p.parseList(n.Or, path+".or")
+		if err != nil {
+			return nil, err
+		}
+
+		return specification.NewOrSpecification(specs...), nil
+	case n.Not != nil:
+		inner, err := p.parse(n.Not, path+".not")
+		if err != nil {
+			return nil, err
+		}
+
+		return specification.NewNotSpecificationVerbose(inner), nil
+	default:
+		return p.parseCondition(n, path)
+	}
+}
+
+func (p parser[T]) parseList(raw []json.RawMessage, path string) ([]specification.Specification[T], error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("%w at %s: list must not be empty", ErrInvalidDocument, path)
+	}
+
+	specs := make([]specification.Specification[T], 0, len(raw))
+
+	for i, item := range raw {
+		spec, err := p.parse(item, path+"["+strconv.Itoa(i)+"]")
+		if err != nil {
+			return nil, err
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
+
+func (p parser[T]) parseCondition(n node, path string) (specification.Specification[T], error) {
+	op, ok := operators[n.Op]
+	if !ok {
+		return nil, fmt.Errorf("%w %q at %s", ErrUnknownOperator, n.Op, path)
+	}
+
+	err := op.validate(n.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%w for %q at %s: %w", ErrInvalidValue, n.Op, path, err)
+	}
+
+	cond := &condition[T]{
+		field:   n.Field,
+		op:      n.Op,
+		value:   n.Value,
+		match:   op.match,
+		resolve: p.resolve,
+	}
+
+	return specification.NewFieldSpecification[T](n.Field, cond), nil
+}
@@ -0,0 +1,161 @@
+package dsl_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/specification"
+	"github.com/shortlink-org/go-sdk/specification/dsl"
+)
+
+const eligibility = `{
+	"and": [
+		{"field": "age", "op": ">=", "value": 18},
+		{"not": {"field": "active", "op": "==", "value": false}},
+		{"or": [
+			{"field": "address.country", "op": "in", "value": ["DE", "FR"]},
+			{"field": "vip", "op": "==", "value": true}
+		]}
+	]
+}`
+
+func TestParse_NestedDocument(t *testing.T) {
+	// Arrange
+	spec, err := dsl.Parse([]byte(eligibility))
+	require.NoError(t, err)
+
+	eligible := map[string]any{
+		"age":     30,
+		"active":  true,
+		"address": map[string]any{"country": "DE"},
+	}
+	vip := map[string]any{
+		"age":     18,
+		"active":  true,
+		"vip":     true,
+		"address": map[string]any{"country": "US"},
+	}
+	minor := map[string]any{
+		"age":     16,
+		"active":  true,
+		"address": map[string]any{"country": "FR"},
+	}
+	inactive := map[string]any{
+		"age":     40,
+		"active":  false,
+		"address": map[string]any{"country": "FR"},
+	}
+
+	// Act & Assert
+	require.NoError(t, spec.IsSatisfiedBy(&eligible))
+	require.NoError(t, spec.IsSatisfiedBy(&vip))
+
+	err = spec.IsSatisfiedBy(&minor)
+	require.ErrorIs(t, err, dsl.ErrConditionNotSatisfied)
+	assert.Contains(t, specification.Explain(err), "age")
+
+	err = spec.IsSatisfiedBy(&inactive)
+	require.ErrorIs(t, err, specification.ErrNotSatisfied)
+}
+
+func TestParse_MissingField(t *testing.T) {
+	// Arrange
+	spec, err := dsl.Parse([]byte(`{"field": "age", "op": ">", "value": 18}`))
+	require.NoError(t, err)
+
+	item := map[string]any{}
+
+	// Act
+	err = spec.IsSatisfiedBy(&item)
+
+	// Assert
+	require.ErrorIs(t, err, dsl.ErrFieldNotFound)
+	assert.Equal(t, map[string]string{"age": dsl.ErrFieldNotFound.Error()}, specification.Explain(err))
+}
+
+func TestParse_InvalidOperator(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{name: "unknown", doc: `{"field": "age", "op": "~=", "value": 18}`},
+		{name: "missing", doc: `{"field": "age", "value": 18}`},
+		{name: "nested", doc: `{"and": [{"field": "age", "op": ">=", "value": 18}, {"not": {"field": "x", "op": "like", "value": "a%"}}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			spec, err := dsl.Parse([]byte(tt.doc))
+
+			// Assert
+			require.ErrorIs(t, err, dsl.ErrUnknownOperator)
+			assert.Nil(t, spec)
+		})
+	}
+}
+
+func TestParse_InvalidOperatorReportsPath(t *testing.T) {
+	// Act
+	_, err := dsl.Parse([]byte(`{"or": [{"field": "a", "op": "==", "value": 1}, {"field": "b", "op": "like", "value": 1}]}`))
+
+	// Assert
+	require.ErrorIs(t, err, dsl.ErrUnknownOperator)
+	assert.Contains(t, err.Error(), `"like" at $.or[1]`)
+}
+
+func TestParse_InvalidDocument(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want error
+	}{
+		{name: "not json", doc: `{"and": [`, want: dsl.ErrInvalidDocument},
+		{name: "empty node", doc: `{}`, want: dsl.ErrInvalidDocument},
+		{name: "two kinds", doc: `{"and": [{"field": "a", "op": "==", "value": 1}], "field": "b"}`, want: dsl.ErrInvalidDocument},
+		{name: "unknown key", doc: `{"field": "a", "op": "==", "value": 1, "typo": 1}`, want: dsl.ErrInvalidDocument},
+		{name: "empty and", doc: `{"and": []}`, want: dsl.ErrInvalidDocument},
+		{name: "ordered bool", doc: `{"field": "a", "op": ">", "value": true}`, want: dsl.ErrInvalidValue},
+		{name: "in scalar", doc: `{"field": "a", "op": "in", "value": "DE"}`, want: dsl.ErrInvalidValue},
+		{name: "equal object", doc: `{"field": "a", "op": "==", "value": {"b": 1}}`, want: dsl.ErrInvalidValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := dsl.Parse([]byte(tt.doc))
+
+			// Assert
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestParseWith_TypedEntity(t *testing.T) {
+	// Arrange
+	type user struct {
+		Name string
+		Age  int
+	}
+
+	resolve := func(u *user, field string) (any, bool) {
+		switch field {
+		case "name":
+			return u.Name, true
+		case "age":
+			return u.Age, true
+		default:
+			return nil, false
+		}
+	}
+
+	spec, err := dsl.ParseWith([]byte(`{"and": [{"field": "age", "op": "<", "value": 65}, {"field": "name", "op": "!=", "value": ""}]}`), resolve)
+	require.NoError(t, err)
+
+	// Act & Assert
+	require.NoError(t, spec.IsSatisfiedBy(&user{Name: "alice", Age: 30}))
+	require.ErrorIs(t, spec.IsSatisfiedBy(&user{Name: "", Age: 30}), dsl.ErrConditionNotSatisfied)
+	require.ErrorIs(t, spec.IsSatisfiedBy(&user{Name: "bob", Age: 70}), dsl.ErrConditionNotSatisfied)
+}
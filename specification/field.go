@@ -0,0 +1,79 @@
+package specification
+
+import (
+	"strings"
+)
+
+// FieldError attributes a specification failure to a field, e.g. for per-field 422 responses.
+type FieldError struct {
+	// Field is the path of the invalid field, e.g. "email" or "address.city".
+	Field string
+	// Err is the failure reported by the field's specification.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldSpecification validates a single field of T: it wraps the failure of Spec in a FieldError.
+// And and Or join the errors of their children, so the field attribution survives composition
+// and Explain can recover it from the combined error.
+type FieldSpecification[T any] struct {
+	Field string
+	Spec  Specification[T]
+}
+
+func (f *FieldSpecification[T]) IsSatisfiedBy(item *T) error {
+	err := f.Spec.IsSatisfiedBy(item)
+	if err == nil {
+		return nil
+	}
+
+	return &FieldError{Field: f.Field, Err: err}
+}
+
+// ToSQL delegates to the inner spec; the field only affects error reporting.
+func (f *FieldSpecification[T]) ToSQL() (string, []any, error) {
+	return ToSQL(f.Spec)
+}
+
+func NewFieldSpecification[T any](field string, spec Specification[T]) *FieldSpecification[T] {
+	return &FieldSpecification[T]{Field: field, Spec: spec}
+}
+
+// Explain maps each field to the reason it failed, walking the error tree built by And/Or.
+// Several failures of the same field are joined with "; ". Errors without a FieldError
+// are ignored, so the map is empty (not nil) when err carries no field attribution.
+func Explain(err error) map[string]string {
+	reasons := make(map[string][]string)
+	collectFieldErrors(err, reasons)
+
+	explained := make(map[string]string, len(reasons))
+	for field, list := range reasons {
+		explained[field] = strings.Join(list, "; ")
+	}
+
+	return explained
+}
+
+func collectFieldErrors(err error, reasons map[string][]string) {
+	if err == nil {
+		return
+	}
+
+	switch wrapped := err.(type) { //nolint:errorlint // walking the tree level by level
+	case *FieldError:
+		reasons[wrapped.Field] = append(reasons[wrapped.Field], wrapped.Err.Error())
+	case interface{ Unwrap() []error }:
+		for _, child := range wrapped.Unwrap() {
+			collectFieldErrors(child, reasons)
+		}
+	case interface{ Unwrap() error }:
+		collectFieldErrors(wrapped.Unwrap(), reasons)
+	}
+}
@@ -0,0 +1,45 @@
+package specification_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/specification"
+)
+
+func TestExplain_AndOfFieldSpecifications(t *testing.T) {
+	// Arrange
+	spec := specification.NewAndSpecification[TestUser](
+		specification.NewFieldSpecification[TestUser]("age", &UserAgeMinSpec{MinAge: 18}),
+		specification.NewFieldSpecification[TestUser]("is_active", &UserActiveSpec{}),
+		specification.NewFieldSpecification[TestUser]("email", &AlwaysPassSpec[TestUser]{}),
+	)
+	user := &TestUser{Age: 16, IsActive: false}
+
+	// Act
+	err := spec.IsSatisfiedBy(user)
+	explained := specification.Explain(err)
+
+	// Assert
+	require.Error(t, err)
+	assert.Equal(t, map[string]string{
+		"age":       "user age 16 is below minimum 18",
+		"is_active": "user is not active",
+	}, explained)
+
+	var fieldErr *specification.FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "age", fieldErr.Field)
+}
+
+func TestExplain_WithoutFieldErrors(t *testing.T) {
+	// Act
+	explained := specification.Explain(errors.New("boom"))
+
+	// Assert
+	assert.NotNil(t, explained)
+	assert.Empty(t, explained)
+}
@@ -2,8 +2,12 @@ package specification
 
 import (
 	"errors"
+	"fmt"
 )
 
+// ErrNilElement is collected by FilterSkipNil for every nil element in the list.
+var ErrNilElement = errors.New("specification: nil element")
+
 // Filter returns a new slice containing only the elements that satisfy the given specification.
 func Filter[T any](list []*T, spec Specification[T]) ([]*T, error) {
 	var errs error
@@ -23,3 +27,31 @@ func Filter[T any](list []*T, spec Specification[T]) ([]*T, error) {
 
 	return result, errs
 }
+
+// FilterSkipNil is like Filter but treats nil elements as failures instead of
+// passing them to the specification, which may panic on nil. Each nil element
+// adds ErrNilElement with its index to the returned error.
+func FilterSkipNil[T any](list []*T, spec Specification[T]) ([]*T, error) {
+	var errs error
+
+	result := make([]*T, 0, len(list))
+
+	for i, item := range list {
+		if item == nil {
+			errs = errors.Join(errs, fmt.Errorf("%w at index %d", ErrNilElement, i))
+
+			continue
+		}
+
+		err := spec.IsSatisfiedBy(item)
+		if err != nil {
+			errs = errors.Join(errs, err)
+
+			continue
+		}
+
+		result = append(result, item)
+	}
+
+	return result, errs
+}
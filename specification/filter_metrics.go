@@ -0,0 +1,86 @@
+package specification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// FilterMetrics holds the instruments recorded by FilterWithMetrics. Create it
+// once with NewFilterMetrics and share it between calls.
+type FilterMetrics struct {
+	evaluated metric.Int64Counter
+	passed    metric.Int64Counter
+	duration  metric.Float64Histogram
+}
+
+// NewFilterMetrics registers the filter instruments on meter. A nil or noop
+// meter returns nil, which makes FilterWithMetrics a plain Filter.
+func NewFilterMetrics(meter metric.Meter) *FilterMetrics {
+	if meter == nil {
+		return nil
+	}
+
+	if _, ok := meter.(noop.Meter); ok {
+		return nil
+	}
+
+	fallback := noop.Meter{}
+
+	evaluated, err := meter.Int64Counter(
+		"specification_filter_items_evaluated_total",
+		metric.WithDescription("Total number of items evaluated by specification filters"),
+	)
+	if err != nil {
+		evaluated, _ = fallback.Int64Counter("")
+	}
+
+	passed, err := meter.Int64Counter(
+		"specification_filter_items_passed_total",
+		metric.WithDescription("Total number of items that satisfied the specification"),
+	)
+	if err != nil {
+		passed, _ = fallback.Int64Counter("")
+	}
+
+	duration, err := meter.Float64Histogram(
+		"specification_filter_duration_seconds",
+		metric.WithDescription("Duration of specification filter evaluation"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		duration, _ = fallback.Float64Histogram("")
+	}
+
+	return &FilterMetrics{
+		evaluated: evaluated,
+		passed:    passed,
+		duration:  duration,
+	}
+}
+
+// FilterWithMetrics behaves like Filter and records how many items were
+// evaluated, how many passed and how long the evaluation took.
+// Nil metrics disable instrumentation, so there is no overhead.
+func FilterWithMetrics[T any](list []*T, spec Specification[T], metrics *FilterMetrics) ([]*T, error) {
+	if metrics == nil {
+		return Filter(list, spec)
+	}
+
+	start := time.Now()
+	result, err := Filter(list, spec)
+	elapsed := time.Since(start)
+
+	attrs := metric.WithAttributes(attribute.String("specification", fmt.Sprintf("%T", spec)))
+	ctx := context.Background()
+
+	metrics.evaluated.Add(ctx, int64(len(list)), attrs)
+	metrics.passed.Add(ctx, int64(len(result)), attrs)
+	metrics.duration.Record(ctx, elapsed.Seconds(), attrs)
+
+	return result, err
+}
@@ -0,0 +1,95 @@
+package specification_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/shortlink-org/go-sdk/specification"
+)
+
+func TestFilterWithMetrics_RecordsPassAndFailCounts(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	users := createTestUsers()
+	spec := &UserAgeMinSpec{MinAge: 18}
+
+	result, err := specification.FilterWithMetrics(users, spec, specification.NewFilterMetrics(provider.Meter("test")))
+	require.Error(t, err)
+	require.Len(t, result, 6)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	sums := map[string]int64{}
+
+	var durationCount uint64
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					sums[m.Name] += dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					durationCount += dp.Count
+				}
+			}
+		}
+	}
+
+	require.Equal(t, int64(len(users)), sums["specification_filter_items_evaluated_total"])
+	require.Equal(t, int64(6), sums["specification_filter_items_passed_total"])
+	require.Equal(t, uint64(1), durationCount)
+}
+
+func TestFilterWithMetrics_NilMeter(t *testing.T) {
+	users := createTestUsers()
+
+	result, err := specification.FilterWithMetrics(users, &AlwaysPassSpec[TestUser]{}, nil)
+	require.NoError(t, err)
+	require.Len(t, result, len(users))
+}
+
+// countingMeter counts how many counters are created through it.
+type countingMeter struct {
+	metric.Meter
+
+	counters atomic.Int64
+}
+
+func (m *countingMeter) Int64Counter(name string, options ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	m.counters.Add(1)
+
+	return m.Meter.Int64Counter(name, options...)
+}
+
+func TestFilterWithMetrics_ReusesInstruments(t *testing.T) {
+	// Arrange
+	meter := &countingMeter{Meter: noop.Meter{}}
+	metrics := specification.NewFilterMetrics(meter)
+	users := createTestUsers()
+
+	// Act
+	for range 3 {
+		_, err := specification.FilterWithMetrics(users, &AlwaysPassSpec[TestUser]{}, metrics)
+		require.NoError(t, err)
+	}
+
+	// Assert
+	require.Equal(t, int64(2), meter.counters.Load())
+}
+
+func TestNewFilterMetrics_NoopMeter(t *testing.T) {
+	require.Nil(t, specification.NewFilterMetrics(nil))
+	require.Nil(t, specification.NewFilterMetrics(noop.Meter{}))
+}
@@ -209,6 +209,29 @@ func (suite *FilterTestSuite) TestFilter_SliceWithNilElements() {
 	})
 }
 
+func (suite *FilterTestSuite) TestFilterSkipNil_InterleavedNils() {
+	// Arrange - Alice (active), nil, Charlie (inactive), nil, Bob (active)
+	usersWithNil := []*TestUser{
+		suite.users[0],
+		nil,
+		suite.users[2],
+		nil,
+		suite.users[1],
+	}
+	spec := &UserActiveSpec{}
+
+	// Act
+	result, err := specification.FilterSkipNil(usersWithNil, spec)
+
+	// Assert
+	suite.Require().Error(err)
+	suite.Require().ErrorIs(err, specification.ErrNilElement)
+	suite.Contains(err.Error(), "nil element at index 1")
+	suite.Contains(err.Error(), "nil element at index 3")
+	suite.Contains(err.Error(), "user is not active")
+	suite.Equal([]*TestUser{suite.users[0], suite.users[1]}, result)
+}
+
 // Standalone tests for additional coverage.
 func TestFilter_BasicFunctionality(t *testing.T) {
 	// Arrange
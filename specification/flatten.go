@@ -0,0 +1,56 @@
+package specification
+
+// Flatten collapses nested And/Or nodes of the same kind into a single wide node,
+// e.g. And(And(a, b), c) becomes And(a, b, c), so evaluation skips the extra layers.
+//
+// The result is satisfied by the same items and fails with the same errors: joined
+// errors print and match (errors.Is/As) the same whether they were joined in one or
+// several steps. An Or is only merged into its parent when both have the same
+// FirstErrorOnly setting and is not empty. Not and FieldSpecification nodes are kept, with their inner
+// specs flattened. spec itself is not modified.
+func Flatten[T any](spec Specification[T]) Specification[T] {
+	switch node := spec.(type) {
+	case *AndSpecification[T]:
+		return &AndSpecification[T]{Specs: flattenAnd(node.Specs, nil)}
+	case *OrSpecification[T]:
+		return &OrSpecification[T]{
+			Specs:          flattenOr(node.Specs, node.FirstErrorOnly, nil),
+			FirstErrorOnly: node.FirstErrorOnly,
+		}
+	case *NotSpecification[T]:
+		return &NotSpecification[T]{Spec: Flatten(node.Spec), Verbose: node.Verbose}
+	case *FieldSpecification[T]:
+		return &FieldSpecification[T]{Field: node.Field, Spec: Flatten(node.Spec)}
+	default:
+		return spec
+	}
+}
+
+func flattenAnd[T any](specs, flat []Specification[T]) []Specification[T] {
+	for _, spec := range specs {
+		if child, ok := spec.(*AndSpecification[T]); ok {
+			flat = flattenAnd(child.Specs, flat)
+
+			continue
+		}
+
+		flat = append(flat, Flatten(spec))
+	}
+
+	return flat
+}
+
+func flattenOr[T any](specs []Specification[T], firstErrorOnly bool, flat []Specification[T]) []Specification[T] {
+	for _, spec := range specs {
+		// An empty Or is always satisfied, so it cannot be merged away.
+		if child, ok := spec.(*OrSpecification[T]); ok && len(child.Specs) > 0 && child.FirstErrorOnly == firstErrorOnly {
+			flat = flattenOr(child.Specs, firstErrorOnly, flat)
+
+			continue
+		}
+
+		flat = append(flat, Flatten(spec))
+	}
+
+	return flat
+}
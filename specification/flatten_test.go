@@ -0,0 +1,88 @@
+package specification_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/specification"
+)
+
+func nestedUserSpec() specification.Specification[TestUser] {
+	return specification.NewAndSpecification[TestUser](
+		specification.NewAndSpecification[TestUser](
+			&UserAgeMinSpec{MinAge: 18},
+			specification.NewAndSpecification[TestUser](&UserAgeMaxSpec{MaxAge: 65}),
+		),
+		specification.NewOrSpecification[TestUser](
+			specification.NewOrSpecification[TestUser](&UserActiveSpec{}, &UserEmailValidSpec{}),
+			specification.NewNotSpecification[TestUser](
+				specification.NewAndSpecification[TestUser](
+					specification.NewAndSpecification[TestUser](&AlwaysPassSpec[TestUser]{}),
+				),
+			),
+		),
+	)
+}
+
+func TestFlatten_SameResults(t *testing.T) {
+	// Arrange
+	nested := nestedUserSpec()
+	users := append(createTestUsers(), &TestUser{ID: 9, Name: "Old", Age: 80}, &TestUser{ID: 10, Name: "Kid", Age: 10})
+
+	// Act
+	flat := specification.Flatten(nested)
+
+	// Assert
+	for _, user := range users {
+		want := nested.IsSatisfiedBy(user)
+		got := flat.IsSatisfiedBy(user)
+
+		if want == nil {
+			require.NoError(t, got, "user %d", user.ID)
+
+			continue
+		}
+
+		require.Error(t, got, "user %d", user.ID)
+		assert.Equal(t, want.Error(), got.Error(), "user %d", user.ID)
+	}
+}
+
+func TestFlatten_CollapsesSameKindNodes(t *testing.T) {
+	// Act
+	flat := specification.Flatten(nestedUserSpec())
+
+	// Assert
+	and, ok := flat.(*specification.AndSpecification[TestUser])
+	require.True(t, ok)
+	require.Len(t, and.Specs, 3)
+
+	or, ok := and.Specs[2].(*specification.OrSpecification[TestUser])
+	require.True(t, ok)
+	require.Len(t, or.Specs, 3)
+	assert.False(t, or.FirstErrorOnly)
+
+	not, ok := or.Specs[2].(*specification.NotSpecification[TestUser])
+	require.True(t, ok)
+
+	inner, ok := not.Spec.(*specification.AndSpecification[TestUser])
+	require.True(t, ok)
+	assert.Len(t, inner.Specs, 1)
+}
+
+func TestFlatten_KeepsEmptyOr(t *testing.T) {
+	// Arrange
+	nested := specification.NewOrSpecification[TestUser](
+		specification.NewOrSpecification[TestUser](),
+		&AlwaysFailSpec[TestUser]{},
+	)
+
+	// Act
+	flat := specification.Flatten[TestUser](nested)
+
+	// Assert
+	require.NoError(t, nested.IsSatisfiedBy(&TestUser{}))
+	require.NoError(t, flat.IsSatisfiedBy(&TestUser{}))
+}
@@ -0,0 +1,60 @@
+package specification
+
+import (
+	"errors"
+	"sync"
+)
+
+// errBufferPool holds scratch slices for aggregating failures. errors.Join copies
+// its arguments, so a buffer goes back to the pool as soon as the join returns.
+var errBufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]error, 0, 8) //nolint:mnd // typical composite width
+
+		return &buf
+	},
+}
+
+// errBuffer collects failures and joins them once, instead of nesting an
+// errors.Join per failure. The zero value is ready to use.
+type errBuffer struct {
+	buf *[]error
+}
+
+// add records err; nil errors are ignored.
+func (e *errBuffer) add(err error) {
+	if err == nil {
+		return
+	}
+
+	if e.buf == nil {
+		e.buf, _ = errBufferPool.Get().(*[]error)
+	}
+
+	*e.buf = append(*e.buf, err)
+}
+
+// join returns the collected errors as one errors.Join error (nil when none)
+// and releases the buffer.
+func (e *errBuffer) join() error {
+	if e.buf == nil {
+		return nil
+	}
+
+	err := errors.Join(*e.buf...)
+	e.release()
+
+	return err
+}
+
+// release returns the buffer to the pool without building an error.
+func (e *errBuffer) release() {
+	if e.buf == nil {
+		return
+	}
+
+	clear(*e.buf)
+	*e.buf = (*e.buf)[:0]
+	errBufferPool.Put(e.buf)
+	e.buf = nil
+}
@@ -0,0 +1,78 @@
+package specification_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/specification"
+)
+
+// sentinelSpec fails with a fixed error so callers can match it with errors.Is.
+type sentinelSpec struct {
+	err error
+}
+
+func (s *sentinelSpec) IsSatisfiedBy(*TestUser) error {
+	return s.err
+}
+
+func TestErrorAggregation_NoMessageLost(t *testing.T) {
+	// Arrange
+	const width = 50
+
+	user := &TestUser{ID: 1, Name: "Alice", Age: 25}
+	sentinels := make([]error, width)
+	specs := make([]specification.Specification[TestUser], width)
+	messages := make([]string, width)
+
+	for i := range width {
+		sentinels[i] = fmt.Errorf("fail%d", i)
+		specs[i] = &sentinelSpec{err: sentinels[i]}
+		messages[i] = sentinels[i].Error()
+	}
+
+	tests := []struct {
+		name string
+		spec specification.Specification[TestUser]
+	}{
+		{name: "or", spec: specification.NewOrSpecification[TestUser](specs...)},
+		{name: "and", spec: specification.NewAndSpecification[TestUser](specs...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Repeated evaluation reuses pooled buffers; no run may see another's errors.
+			for range 3 {
+				// Act
+				err := tt.spec.IsSatisfiedBy(user)
+
+				// Assert
+				require.Error(t, err)
+				assert.Equal(t, strings.Join(messages, "\n"), err.Error())
+
+				for _, sentinel := range sentinels {
+					require.ErrorIs(t, err, sentinel)
+				}
+			}
+		})
+	}
+}
+
+func TestErrorAggregation_SingleFailureIsJoined(t *testing.T) {
+	// Arrange
+	errFail := errors.New("fail")
+	andSpec := specification.NewAndSpecification[TestUser](&AlwaysPassSpec[TestUser]{}, &sentinelSpec{err: errFail})
+
+	// Act
+	err := andSpec.IsSatisfiedBy(&TestUser{})
+
+	// Assert
+	require.ErrorIs(t, err, errFail)
+	assert.NotSame(t, errFail, err)
+	assert.Equal(t, "fail", err.Error())
+}
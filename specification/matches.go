@@ -0,0 +1,69 @@
+package specification
+
+// matcher is implemented by the composites that can answer yes/no without
+// building the error IsSatisfiedBy would return.
+type matcher[T any] interface {
+	matches(item *T) bool
+}
+
+// Matches reports whether item satisfies spec, i.e. spec.IsSatisfiedBy(item) == nil,
+// for callers that do not need the reason. And, Or, Not and Field nodes short-circuit
+// and skip error aggregation; leaf specs are still evaluated through IsSatisfiedBy,
+// which stays the source of truth. And stops at the first failing spec, so leaves
+// after it are not evaluated.
+func Matches[T any](spec Specification[T], item *T) bool {
+	if m, ok := spec.(matcher[T]); ok {
+		return m.matches(item)
+	}
+
+	return spec.IsSatisfiedBy(item) == nil
+}
+
+func (a *AndSpecification[T]) matches(item *T) bool {
+	for _, spec := range a.Specs {
+		if !Matches(spec, item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (o *OrSpecification[T]) matches(item *T) bool {
+	_, ok := o.matchIndex(item)
+
+	return ok
+}
+
+// matchIndex is satisfiedIndex without errors: the index of the first spec that
+// matches, or -1. An empty Or matches, like IsSatisfiedBy.
+func (o *OrSpecification[T]) matchIndex(item *T) (int, bool) {
+	if len(o.Specs) == 0 {
+		return -1, true
+	}
+
+	for i, spec := range o.Specs {
+		if Matches(spec, item) {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
+
+func (o *InstrumentedOrSpecification[T]) matches(item *T) bool {
+	index, ok := o.matchIndex(item)
+	if index >= 0 {
+		o.record(index)
+	}
+
+	return ok
+}
+
+func (n *NotSpecification[T]) matches(item *T) bool {
+	return !Matches(n.Spec, item)
+}
+
+func (f *FieldSpecification[T]) matches(item *T) bool {
+	return Matches(f.Spec, item)
+}
@@ -0,0 +1,72 @@
+package specification_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shortlink-org/go-sdk/specification"
+)
+
+func TestMatches_AgreesWithIsSatisfiedBy(t *testing.T) {
+	// Arrange
+	adult := &UserAgeMinSpec{MinAge: 18}
+	active := &UserActiveSpec{}
+	email := &UserEmailValidSpec{}
+
+	specs := map[string]specification.Specification[TestUser]{
+		"leaf":      adult,
+		"and":       specification.NewAndSpecification[TestUser](adult, active),
+		"or":        specification.NewOrSpecification[TestUser](adult, email),
+		"or first":  &specification.OrSpecification[TestUser]{Specs: []specification.Specification[TestUser]{adult, email}},
+		"not":       specification.NewNotSpecificationVerbose[TestUser](active),
+		"field":     specification.NewFieldSpecification[TestUser]("age", adult),
+		"none of":   specification.NoneOf[TestUser](adult, active),
+		"empty and": specification.NewAndSpecification[TestUser](),
+		"empty or":  specification.NewOrSpecification[TestUser](),
+		"nested": specification.NewOrSpecification[TestUser](
+			specification.NewAndSpecification[TestUser](active, &UserAgeMaxSpec{MaxAge: 30}),
+			specification.NewNotSpecification[TestUser](email),
+		),
+		"cached":       specification.Cached[TestUser](specification.NewAndSpecification[TestUser](adult, email), func(u *TestUser) string { return u.Name }, time.Minute),
+		"instrumented": specification.NewInstrumentedOrSpecification[TestUser](nil, "test", active, adult),
+	}
+
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			for _, user := range createTestUsers() {
+				// Act
+				want := spec.IsSatisfiedBy(user) == nil
+				got := specification.Matches(spec, user)
+
+				// Assert
+				assert.Equal(t, want, got, "user %d", user.ID)
+			}
+		})
+	}
+}
+
+func TestMatches_ShortCircuits(t *testing.T) {
+	// Arrange
+	user := &TestUser{ID: 1}
+	failing := &countingSpec{inner: &AlwaysFailSpec[TestUser]{}}
+	skippedByAnd := &countingSpec{inner: &AlwaysPassSpec[TestUser]{}}
+	passing := &countingSpec{inner: &AlwaysPassSpec[TestUser]{}}
+	skippedByOr := &countingSpec{inner: &AlwaysPassSpec[TestUser]{}}
+
+	andSpec := specification.NewAndSpecification[TestUser](failing, skippedByAnd)
+	orSpec := specification.NewOrSpecification[TestUser](passing, skippedByOr)
+
+	// Act
+	andMatched := specification.Matches[TestUser](andSpec, user)
+	orMatched := specification.Matches[TestUser](orSpec, user)
+
+	// Assert
+	assert.False(t, andMatched)
+	assert.True(t, orMatched)
+	assert.EqualValues(t, 1, failing.calls.Load())
+	assert.EqualValues(t, 0, skippedByAnd.calls.Load())
+	assert.EqualValues(t, 1, passing.calls.Load())
+	assert.EqualValues(t, 0, skippedByOr.calls.Load())
+}
@@ -2,19 +2,42 @@ package specification
 
 import (
 	"errors"
+	"reflect"
+	"strings"
 )
 
 var ErrNotSatisfied = errors.New("specification not satisfied")
 
+// NotSatisfiedError describes a NOT specification that failed because its inner spec was satisfied.
+// It matches ErrNotSatisfied via errors.Is.
+type NotSatisfiedError struct {
+	// Spec is the type name of the inner specification.
+	Spec string
+}
+
+func (e *NotSatisfiedError) Error() string {
+	return "NOT(" + e.Spec + ") failed: inner satisfied"
+}
+
+func (e *NotSatisfiedError) Unwrap() error {
+	return ErrNotSatisfied
+}
+
 // NotSpecification is a composite specification that represents the logical NOT of another specification.
 type NotSpecification[T any] struct {
 	Spec Specification[T]
+	// Verbose reports which inner spec was satisfied via NotSatisfiedError instead of the bare ErrNotSatisfied.
+	Verbose bool
 }
 
 func (n *NotSpecification[T]) IsSatisfiedBy(item *T) error {
 	// If inner spec PASSES → NOT should FAIL
 	err := n.Spec.IsSatisfiedBy(item)
 	if err == nil {
+		if n.Verbose {
+			return &NotSatisfiedError{Spec: specName(n.Spec)}
+		}
+
 		return ErrNotSatisfied
 	}
 
@@ -25,3 +48,32 @@ func (n *NotSpecification[T]) IsSatisfiedBy(item *T) error {
 func NewNotSpecification[T any](spec Specification[T]) *NotSpecification[T] {
 	return &NotSpecification[T]{Spec: spec}
 }
+
+// NewNotSpecificationVerbose is like NewNotSpecification but its error names the satisfied inner spec,
+// e.g. "NOT(UserActiveSpec) failed: inner satisfied".
+func NewNotSpecificationVerbose[T any](spec Specification[T]) *NotSpecification[T] {
+	return &NotSpecification[T]{Spec: spec, Verbose: true}
+}
+
+func specName(spec any) string {
+	typ := reflect.TypeOf(spec)
+	if typ == nil {
+		return "<nil>"
+	}
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	name := typ.Name()
+	if name == "" {
+		return typ.String()
+	}
+
+	// Drop the package path from generic type arguments, e.g. AndSpecification[pkg.User].
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		return name[:i]
+	}
+
+	return name
+}
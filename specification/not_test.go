@@ -411,3 +411,45 @@ func TestNotSpecification_DiscardsPreviousError(t *testing.T) {
 	require.ErrorIs(t, err, specification.ErrNotSatisfied)
 	assert.NotContains(t, err.Error(), "age") // Should not contain inner spec's success message
 }
+
+func TestNotSpecificationVerbose_DescribesSatisfiedInnerSpec(t *testing.T) {
+	// Arrange
+	user := &TestUser{ID: 1, Name: "Alice", Age: 25, IsActive: true}
+	notSpec := specification.NewNotSpecificationVerbose[TestUser](&UserActiveSpec{})
+
+	// Act
+	err := notSpec.IsSatisfiedBy(user)
+
+	// Assert
+	require.Error(t, err)
+	require.ErrorIs(t, err, specification.ErrNotSatisfied)
+	require.Equal(t, "NOT(UserActiveSpec) failed: inner satisfied", err.Error())
+
+	var notErr *specification.NotSatisfiedError
+	require.ErrorAs(t, err, &notErr)
+	assert.Equal(t, "UserActiveSpec", notErr.Spec)
+}
+
+func TestNotSpecificationVerbose_GenericInnerSpec(t *testing.T) {
+	// Arrange
+	user := &TestUser{ID: 1, Name: "Alice", Age: 25, IsActive: true}
+	notSpec := specification.NewNotSpecificationVerbose[TestUser](
+		specification.NewAndSpecification[TestUser](&UserActiveSpec{}),
+	)
+
+	// Act
+	err := notSpec.IsSatisfiedBy(user)
+
+	// Assert
+	require.ErrorIs(t, err, specification.ErrNotSatisfied)
+	require.Equal(t, "NOT(AndSpecification) failed: inner satisfied", err.Error())
+}
+
+func TestNotSpecificationVerbose_InnerSpecFails(t *testing.T) {
+	// Arrange
+	user := &TestUser{ID: 3, Name: "Charlie", Age: 30, IsActive: false}
+	notSpec := specification.NewNotSpecificationVerbose[TestUser](&UserActiveSpec{})
+
+	// Act & Assert
+	require.NoError(t, notSpec.IsSatisfiedBy(user))
+}
@@ -1,31 +1,56 @@
 package specification
 
-import (
-	"errors"
-)
-
 // OrSpecification is a composite specification that represents the logical OR of two other specifications.
 type OrSpecification[T any] struct {
 	Specs []Specification[T]
+	// FirstErrorOnly returns only the first error when all specs fail, skipping
+	// the errors.Join allocations on hot paths. By default the errors of every
+	// failed spec are joined.
+	FirstErrorOnly bool
 }
 
 func (o *OrSpecification[T]) IsSatisfiedBy(item *T) error {
-	var errs error
+	_, err := o.satisfiedIndex(item)
+
+	return err
+}
+
+// satisfiedIndex evaluates the specs in order and returns the index of the first
+// one that passes, or -1 when none does (or there are no specs).
+func (o *OrSpecification[T]) satisfiedIndex(item *T) (int, error) {
+	if o.FirstErrorOnly {
+		var first error
+
+		for i, spec := range o.Specs {
+			err := spec.IsSatisfiedBy(item)
+			if err == nil {
+				return i, nil
+			}
+
+			if first == nil {
+				first = err
+			}
+		}
+
+		return -1, first
+	}
 
-	for _, spec := range o.Specs {
+	var errs errBuffer
+
+	for i, spec := range o.Specs {
 		err := spec.IsSatisfiedBy(item)
 		if err == nil {
-			return nil
+			errs.release()
+
+			return i, nil
 		}
 
-		errs = errors.Join(errs, err)
+		errs.add(err)
 	}
 
-	return errs
+	return -1, errs.join()
 }
 
 func NewOrSpecification[T any](specs ...Specification[T]) *OrSpecification[T] {
-	return &OrSpecification[T]{
-		Specs: specs,
-	}
+	return &OrSpecification[T]{Specs: specs}
 }
@@ -0,0 +1,66 @@
+package specification
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// InstrumentedOrSpecification is an OrSpecification that records which spec
+// satisfied each item. A high average index means expensive or rarely passing
+// specs run first; reorder the cheap, likely ones to the front.
+type InstrumentedOrSpecification[T any] struct {
+	*OrSpecification[T]
+
+	satisfied metric.Int64Histogram
+	attrs     metric.RecordOption
+}
+
+// NewInstrumentedOrSpecification returns an OR of specs that records the index
+// of the satisfying spec in the "specification_or_satisfied_index" histogram,
+// labeled with name. Items that satisfy no spec are not recorded.
+// A nil meter returns a plain OrSpecification, so there is no overhead.
+func NewInstrumentedOrSpecification[T any](meter metric.Meter, name string, specs ...Specification[T]) Specification[T] {
+	or := NewOrSpecification(specs...)
+	if meter == nil {
+		return or
+	}
+
+	// One bucket per position: (-inf, 0], (0, 1], ...
+	bounds := make([]float64, len(specs))
+	for i := range bounds {
+		bounds[i] = float64(i)
+	}
+
+	// Fall back to a noop instrument when registration fails, so a
+	// misconfigured meter never breaks evaluation.
+	satisfied, err := meter.Int64Histogram(
+		"specification_or_satisfied_index",
+		metric.WithDescription("Zero-based index of the spec that satisfied an OR specification"),
+		metric.WithExplicitBucketBoundaries(bounds...),
+	)
+	if err != nil {
+		satisfied, _ = noop.Meter{}.Int64Histogram("")
+	}
+
+	return &InstrumentedOrSpecification[T]{
+		OrSpecification: or,
+		satisfied:       satisfied,
+		attrs:           metric.WithAttributes(attribute.String("specification", name)),
+	}
+}
+
+func (o *InstrumentedOrSpecification[T]) IsSatisfiedBy(item *T) error {
+	index, err := o.satisfiedIndex(item)
+	if index >= 0 {
+		o.record(index)
+	}
+
+	return err
+}
+
+func (o *InstrumentedOrSpecification[T]) record(index int) {
+	o.satisfied.Record(context.Background(), int64(index), o.attrs)
+}
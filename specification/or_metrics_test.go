@@ -0,0 +1,78 @@
+package specification_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/shortlink-org/go-sdk/specification"
+)
+
+func TestInstrumentedOr_RecordsSatisfiedIndex(t *testing.T) {
+	// Arrange
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	user := &TestUser{ID: 1, Name: "Test", Age: 20, IsActive: true}
+
+	spec := specification.NewInstrumentedOrSpecification[TestUser](
+		provider.Meter("test"),
+		"user_access",
+		&AlwaysFailSpec[TestUser]{Reason: "first"},
+		&AlwaysFailSpec[TestUser]{Reason: "second"},
+		&AlwaysPassSpec[TestUser]{},
+		&AlwaysFailSpec[TestUser]{Reason: "never evaluated"},
+	)
+	allFail := specification.NewInstrumentedOrSpecification[TestUser](
+		provider.Meter("test"),
+		"all_fail",
+		&AlwaysFailSpec[TestUser]{},
+	)
+
+	// Act
+	err := spec.IsSatisfiedBy(user)
+	failErr := allFail.IsSatisfiedBy(user)
+
+	// Assert
+	require.NoError(t, err)
+	require.Error(t, failErr)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	var points []metricdata.HistogramDataPoint[int64]
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "specification_or_satisfied_index" {
+				continue
+			}
+
+			data, ok := m.Data.(metricdata.Histogram[int64])
+			require.True(t, ok)
+
+			points = append(points, data.DataPoints...)
+		}
+	}
+
+	// Only the satisfied OR records; the all-fail one has no data point.
+	require.Len(t, points, 1)
+
+	point := points[0]
+	name, _ := point.Attributes.Value("specification")
+	require.Equal(t, "user_access", name.AsString())
+	require.Equal(t, uint64(1), point.Count)
+	require.Equal(t, int64(2), point.Sum)
+}
+
+func TestInstrumentedOr_NilMeter(t *testing.T) {
+	// Arrange & Act
+	spec := specification.NewInstrumentedOrSpecification[TestUser](nil, "plain", &AlwaysPassSpec[TestUser]{})
+
+	// Assert
+	_, ok := spec.(*specification.OrSpecification[TestUser])
+	require.True(t, ok, "nil meter should return a plain OrSpecification")
+	require.NoError(t, spec.IsSatisfiedBy(&TestUser{}))
+}
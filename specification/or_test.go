@@ -400,3 +400,39 @@ func TestOrSpecification_ComplexLogic(t *testing.T) {
 		})
 	}
 }
+
+func TestOrSpecification_FirstErrorOnly(t *testing.T) {
+	// Arrange
+	user := &TestUser{ID: 6, Name: "Frank", Age: 16, Email: "frank.invalid", IsActive: false}
+	orSpec := specification.NewOrSpecification[TestUser](
+		&UserAgeMinSpec{MinAge: 18}, // Fail: too young
+		&UserActiveSpec{},           // Fail: not active
+	)
+	orSpec.FirstErrorOnly = true
+
+	// Act
+	err := orSpec.IsSatisfiedBy(user)
+
+	// Assert
+	require.Error(t, err)
+	assert.Equal(t, "user age 16 is below minimum 18", err.Error())
+}
+
+func TestOrSpecification_LiteralCollectsAllErrors(t *testing.T) {
+	// Arrange
+	user := &TestUser{ID: 6, Name: "Frank", Age: 16, Email: "frank.invalid", IsActive: false}
+	orSpec := &specification.OrSpecification[TestUser]{
+		Specs: []specification.Specification[TestUser]{
+			&UserAgeMinSpec{MinAge: 18}, // Fail: too young
+			&UserActiveSpec{},           // Fail: not active
+		},
+	}
+
+	// Act
+	err := orSpec.IsSatisfiedBy(user)
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "user age 16 is below minimum 18")
+	assert.Contains(t, err.Error(), "user is not active")
+}
@@ -0,0 +1,124 @@
+package specification
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNotTranslatable is returned by ToSQL when a specification in the tree cannot be expressed in SQL.
+// A single such leaf fails the whole translation: a partial WHERE clause would match the wrong rows.
+var ErrNotTranslatable = errors.New("specification: not translatable to SQL")
+
+// SQLSpecification is a Specification that can also be expressed as a SQL WHERE clause,
+// so the same rule filters in memory and in the database.
+//
+// ToSQL returns a boolean expression with positional placeholders numbered from $1
+// (e.g. "age >= $1") and the argument for each placeholder. Composites renumber
+// the placeholders of their children, so leaves never need to know their position.
+type SQLSpecification[T any] interface {
+	Specification[T]
+	ToSQL() (string, []any, error)
+}
+
+// ToSQL translates spec into a WHERE clause and its arguments.
+// It returns ErrNotTranslatable when spec or any spec below it does not implement SQLSpecification.
+func ToSQL[T any](spec Specification[T]) (string, []any, error) {
+	sqlSpec, ok := spec.(SQLSpecification[T])
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %s", ErrNotTranslatable, specName(spec))
+	}
+
+	return sqlSpec.ToSQL()
+}
+
+// ToSQL joins the clauses of all specs with AND. An empty AND is always satisfied and yields TRUE.
+func (a *AndSpecification[T]) ToSQL() (string, []any, error) {
+	return joinSQL(a.Specs, "AND", "TRUE")
+}
+
+// ToSQL joins the clauses of all specs with OR. An empty OR is always satisfied and yields TRUE.
+func (o *OrSpecification[T]) ToSQL() (string, []any, error) {
+	return joinSQL(o.Specs, "OR", "TRUE")
+}
+
+// ToSQL wraps the clause of the inner spec in NOT (...).
+func (n *NotSpecification[T]) ToSQL() (string, []any, error) {
+	clause, args, err := ToSQL(n.Spec)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "NOT (" + clause + ")", args, nil
+}
+
+func joinSQL[T any](specs []Specification[T], operator, empty string) (string, []any, error) {
+	if len(specs) == 0 {
+		return empty, nil, nil
+	}
+
+	clauses := make([]string, 0, len(specs))
+
+	var args []any
+
+	for _, spec := range specs {
+		clause, specArgs, err := ToSQL(spec)
+		if err != nil {
+			return "", nil, err
+		}
+
+		clauses = append(clauses, "("+renumberPlaceholders(clause, len(args))+")")
+		args = append(args, specArgs...)
+	}
+
+	return strings.Join(clauses, " "+operator+" "), args, nil
+}
+
+// renumberPlaceholders shifts every $N placeholder in clause by offset,
+// leaving quoted literals and identifiers untouched.
+func renumberPlaceholders(clause string, offset int) string {
+	if offset == 0 {
+		return clause
+	}
+
+	var (
+		out   strings.Builder
+		quote byte
+	)
+
+	out.Grow(len(clause))
+
+	for i := 0; i < len(clause); i++ {
+		c := clause[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '$':
+			end := i + 1
+			for end < len(clause) && clause[end] >= '0' && clause[end] <= '9' {
+				end++
+			}
+
+			if end > i+1 {
+				n, err := strconv.Atoi(clause[i+1 : end])
+				if err == nil {
+					out.WriteString("$" + strconv.Itoa(n+offset))
+
+					i = end - 1
+
+					continue
+				}
+			}
+		}
+
+		out.WriteByte(c)
+	}
+
+	return out.String()
+}
@@ -0,0 +1,114 @@
+package specification_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/specification"
+)
+
+// UserAgeAtLeastSQLSpec is UserAgeMinSpec with a SQL translation.
+type UserAgeAtLeastSQLSpec struct {
+	UserAgeMinSpec
+}
+
+func (u *UserAgeAtLeastSQLSpec) ToSQL() (string, []any, error) {
+	return "age >= $1", []any{u.MinAge}, nil
+}
+
+// UserNameSQLSpec matches users by exact name in memory and in SQL.
+type UserNameSQLSpec struct {
+	Name string
+}
+
+func (u *UserNameSQLSpec) IsSatisfiedBy(user *TestUser) error {
+	if user.Name != u.Name {
+		return specification.ErrNotSatisfied
+	}
+
+	return nil
+}
+
+func (u *UserNameSQLSpec) ToSQL() (string, []any, error) {
+	return "name = $1", []any{u.Name}, nil
+}
+
+func ageAtLeast(minAge int) *UserAgeAtLeastSQLSpec {
+	return &UserAgeAtLeastSQLSpec{UserAgeMinSpec{MinAge: minAge}}
+}
+
+func TestToSQL_Not(t *testing.T) {
+	// Arrange
+	spec := specification.NewNotSpecification[TestUser](ageAtLeast(18))
+
+	// Act
+	clause, args, err := specification.ToSQL[TestUser](spec)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "NOT (age >= $1)", clause)
+	assert.Equal(t, []any{18}, args)
+}
+
+func TestToSQL_MixedTreeRenumbersPlaceholders(t *testing.T) {
+	// Arrange - adults named alice, or anyone except bob
+	spec := specification.AllOf[TestUser](
+		ageAtLeast(18),
+		specification.AnyOf[TestUser](
+			&UserNameSQLSpec{Name: "alice"},
+			specification.NewNotSpecification[TestUser](&UserNameSQLSpec{Name: "bob"}),
+		),
+	)
+
+	// Act
+	clause, args, err := specification.ToSQL[TestUser](spec)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "(age >= $1) AND ((name = $2) OR (NOT (name = $3)))", clause)
+	assert.Equal(t, []any{18, "alice", "bob"}, args)
+
+	// The same tree still filters in memory.
+	require.NoError(t, spec.IsSatisfiedBy(&TestUser{Name: "carol", Age: 30}))
+	require.Error(t, spec.IsSatisfiedBy(&TestUser{Name: "bob", Age: 30}))
+}
+
+func TestToSQL_NonTranslatableLeafFailsWholeTree(t *testing.T) {
+	// Arrange - UserActiveSpec has no SQL translation
+	spec := specification.NewOrSpecification[TestUser](
+		ageAtLeast(18),
+		specification.NewNotSpecification[TestUser](&UserActiveSpec{}),
+	)
+
+	// Act
+	clause, args, err := specification.ToSQL[TestUser](spec)
+
+	// Assert
+	require.ErrorIs(t, err, specification.ErrNotTranslatable)
+	assert.Contains(t, err.Error(), "UserActiveSpec")
+	assert.Empty(t, clause)
+	assert.Nil(t, args)
+}
+
+type quotedSQLSpec struct {
+	AlwaysPassSpec[TestUser]
+}
+
+func (quotedSQLSpec) ToSQL() (string, []any, error) {
+	return `note <> 'costs $1' AND "col$1" = $1`, []any{"x"}, nil
+}
+
+func TestToSQL_RenumberSkipsQuotedText(t *testing.T) {
+	// Arrange
+	spec := specification.NewAndSpecification[TestUser](ageAtLeast(21), &quotedSQLSpec{})
+
+	// Act
+	clause, args, err := specification.ToSQL[TestUser](spec)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, `(age >= $1) AND (note <> 'costs $1' AND "col$1" = $2)`, clause)
+	assert.Equal(t, []any{21, "x"}, args)
+}
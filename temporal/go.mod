@@ -11,6 +11,7 @@ require (
 	go.opentelemetry.io/otel/trace v1.43.0
 	go.temporal.io/sdk v1.42.0
 	go.temporal.io/sdk/contrib/opentelemetry v0.7.0
+	google.golang.org/grpc v1.80.0
 )
 
 require (
@@ -74,14 +75,15 @@ require (
 	golang.org/x/time v0.12.0 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20260401024825-9d38bb4040a9 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20260406210006-6f92a3bedf2d // indirect
-	google.golang.org/grpc v1.80.0 // indirect
 	google.golang.org/protobuf v1.36.11 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 replace (
+	github.com/shortlink-org/go-sdk/auth => ../auth
 	github.com/shortlink-org/go-sdk/config => ../config
 	github.com/shortlink-org/go-sdk/grpc => ../grpc
+	github.com/shortlink-org/go-sdk/http => ../http
 	github.com/shortlink-org/go-sdk/logger => ../logger
 	github.com/shortlink-org/go-sdk/observability => ../observability
 )
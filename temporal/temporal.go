@@ -16,6 +16,7 @@ import (
 	"go.temporal.io/sdk/contrib/opentelemetry"
 	"go.temporal.io/sdk/interceptor"
 	"go.temporal.io/sdk/log"
+	"google.golang.org/grpc"
 
 	"github.com/shortlink-org/go-sdk/config"
 	sdkgrpc "github.com/shortlink-org/go-sdk/grpc"
@@ -50,6 +51,73 @@ func New(
 	tracer trace.TracerProvider,
 	monitor *metrics.Monitoring,
 ) (client.Client, error) {
+	return NewWithOptions(l, cfg, tracer, monitor)
+}
+
+// Option customizes the Temporal client built by NewWithOptions.
+type Option func(*clientConfig)
+
+type clientConfig struct {
+	dialOptions  []grpc.DialOption
+	interceptors []interceptor.ClientInterceptor
+}
+
+// WithDialOptions appends gRPC dial options for the connection to the Temporal frontend,
+// e.g. per-RPC credentials from a machine token source or a custom retry policy.
+// They are applied after the go-sdk/grpc options, so they take precedence.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(c *clientConfig) {
+		c.dialOptions = append(c.dialOptions, opts...)
+	}
+}
+
+// WithInterceptors appends Temporal client interceptors after the tracing interceptor.
+func WithInterceptors(interceptors ...interceptor.ClientInterceptor) Option {
+	return func(c *clientConfig) {
+		c.interceptors = append(c.interceptors, interceptors...)
+	}
+}
+
+// NewWithOptions is like New but accepts extra dial options and client interceptors.
+func NewWithOptions(
+	l logger.Logger,
+	cfg *config.Config,
+	tracer trace.TracerProvider,
+	monitor *metrics.Monitoring,
+	opts ...Option,
+) (client.Client, error) {
+	clientOpts, err := newClientOptions(l, cfg, tracer, monitor, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create client
+	c, err := client.Dial(clientOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporal client: %w", err)
+	}
+
+	l.Info("Temporal client created",
+		slog.String("host", clientOpts.HostPort),
+		slog.String("namespace", clientOpts.Namespace),
+	)
+
+	return c, nil
+}
+
+// newClientOptions builds the Temporal client options from config and opts.
+func newClientOptions(
+	l logger.Logger,
+	cfg *config.Config,
+	tracer trace.TracerProvider,
+	monitor *metrics.Monitoring,
+	opts ...Option,
+) (client.Options, error) {
+	var custom clientConfig
+	for _, opt := range opts {
+		opt(&custom)
+	}
+
 	// Set defaults
 	cfg.SetDefault("TEMPORAL_HOST", "temporal-frontend.temporal.svc.cluster.local:7233")
 	cfg.SetDefault("TEMPORAL_NAMESPACE", "default")
@@ -81,31 +149,32 @@ func New(
 	// Get configured gRPC client options
 	grpcClient, err := sdkgrpc.SetClientConfig(cfg, grpcOpts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to configure gRPC client: %w", err)
+		return client.Options{}, fmt.Errorf("failed to configure gRPC client: %w", err)
 	}
 
 	// Build Temporal interceptors
 	// Reference: https://docs.temporal.io/develop/go/observability#tracing-and-context-propagation
-	interceptors := make([]interceptor.ClientInterceptor, 0)
+	interceptors := make([]interceptor.ClientInterceptor, 0, 1+len(custom.interceptors))
 
 	// OpenTelemetry tracing interceptor for Temporal workflows
 	tracingInterceptor, err := opentelemetry.NewTracingInterceptor(opentelemetry.TracerOptions{
 		Tracer: otel.Tracer("temporal-sdk-go"),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create tracing interceptor: %w", err)
+		return client.Options{}, fmt.Errorf("failed to create tracing interceptor: %w", err)
 	}
 
 	interceptors = append(interceptors, tracingInterceptor)
+	interceptors = append(interceptors, custom.interceptors...)
 
 	// Build client options
-	opts := client.Options{
+	clientOpts := client.Options{
 		HostPort:     host,
 		Namespace:    namespace,
 		Logger:       newLogAdapter(l),
 		Interceptors: interceptors,
 		ConnectionOptions: client.ConnectionOptions{
-			DialOptions: grpcClient.GetOptions(),
+			DialOptions: append(grpcClient.GetOptions(), custom.dialOptions...),
 		},
 	}
 
@@ -113,7 +182,7 @@ func New(
 	// Reference: https://docs.temporal.io/develop/go/observability#how-to-emit-metrics
 	if monitor != nil && monitor.Metrics != nil {
 		meter := monitor.Metrics.Meter("temporal-sdk-go")
-		opts.MetricsHandler = opentelemetry.NewMetricsHandler(opentelemetry.MetricsHandlerOptions{
+		clientOpts.MetricsHandler = opentelemetry.NewMetricsHandler(opentelemetry.MetricsHandlerOptions{
 			Meter: meter,
 			OnError: func(err error) {
 				l.Error("Temporal metrics error", slog.String("error", err.Error()))
@@ -122,21 +191,10 @@ func New(
 	}
 
 	if identity != "" {
-		opts.Identity = identity
+		clientOpts.Identity = identity
 	}
 
-	// Create client
-	c, err := client.Dial(opts)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create temporal client: %w", err)
-	}
-
-	l.Info("Temporal client created",
-		slog.String("host", host),
-		slog.String("namespace", namespace),
-	)
-
-	return c, nil
+	return clientOpts, nil
 }
 
 // CheckHealth verifies the connection to Temporal server.
@@ -0,0 +1,56 @@
+package temporal
+
+import (
+	"io"
+	"testing"
+
+	"go.temporal.io/sdk/interceptor"
+	"google.golang.org/grpc"
+
+	"github.com/shortlink-org/go-sdk/config"
+	"github.com/shortlink-org/go-sdk/logger"
+)
+
+type testInterceptor struct {
+	interceptor.ClientInterceptorBase
+}
+
+func TestNewClientOptions_WithOptions(t *testing.T) {
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatalf("config.New() error = %v", err)
+	}
+
+	log, err := logger.New(logger.Configuration{Writer: io.Discard})
+	if err != nil {
+		t.Fatalf("logger.New() error = %v", err)
+	}
+
+	dialOpt := grpc.WithUserAgent("temporal-test")
+	custom := &testInterceptor{}
+
+	opts, err := newClientOptions(log, cfg, nil, nil, WithDialOptions(dialOpt), WithInterceptors(custom))
+	if err != nil {
+		t.Fatalf("newClientOptions() error = %v", err)
+	}
+
+	found := false
+
+	for _, opt := range opts.ConnectionOptions.DialOptions {
+		if opt == dialOpt {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Errorf("dial option was not passed to the client options")
+	}
+
+	if n := len(opts.Interceptors); n != 2 {
+		t.Fatalf("len(Interceptors) = %d, want 2", n)
+	}
+
+	if opts.Interceptors[1] != custom {
+		t.Errorf("custom interceptor must follow the tracing interceptor")
+	}
+}
@@ -0,0 +1,102 @@
+//go:build integration
+
+package specquery_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+
+	"github.com/shortlink-org/go-sdk/specification"
+	"github.com/shortlink-org/go-sdk/uow"
+	"github.com/shortlink-org/go-sdk/uow/specquery"
+)
+
+func setupPostgres(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	ctx := context.Background()
+
+	container, err := postgres.Run(ctx,
+		"postgres:18-alpine",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		postgres.BasicWaitStrategies(),
+	)
+	testcontainers.CleanupContainer(t, container)
+	require.NoError(t, err, "postgres container: ensure Docker is running")
+
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	pool, err := pgxpool.New(ctx, connStr)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	_, err = pool.Exec(ctx, `
+		CREATE TABLE users (
+			id BIGSERIAL PRIMARY KEY,
+			name TEXT NOT NULL,
+			age INT NOT NULL,
+			active BOOLEAN NOT NULL
+		);
+		INSERT INTO users (name, age, active) VALUES
+			('alice', 30, true),
+			('bob', 17, true),
+			('carol', 45, false),
+			('root', 99, false);
+	`)
+	require.NoError(t, err)
+
+	return pool
+}
+
+func TestFind_Integration(t *testing.T) {
+	pool := setupPostgres(t)
+	ctx := context.Background()
+
+	// adults that are active or named root
+	spec := specification.AllOf[user](
+		ageAtLeast{min: 18},
+		specification.AnyOf[user](active{}, nameIs{name: "root"}),
+	)
+
+	t.Run("pool", func(t *testing.T) {
+		users, err := specquery.Find(ctx, pool, "SELECT id, name, age, active FROM users", spec, pgx.RowToStructByName[user])
+		require.NoError(t, err)
+
+		names := make([]string, 0, len(users))
+		for _, u := range users {
+			names = append(names, u.Name)
+
+			// the database agrees with the in-memory evaluation
+			require.NoError(t, spec.IsSatisfiedBy(&u))
+		}
+
+		assert.ElementsMatch(t, []string{"alice", "root"}, names)
+	})
+
+	t.Run("uow transaction", func(t *testing.T) {
+		tx, err := pool.Begin(ctx)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = tx.Rollback(ctx) })
+
+		_, err = tx.Exec(ctx, "INSERT INTO users (name, age, active) VALUES ('dave', 21, true)")
+		require.NoError(t, err)
+
+		// the uncommitted row is only visible inside the transaction carried by ctx
+		users, err := specquery.Find(uow.WithTx(ctx, tx), pool, "SELECT id, name, age, active FROM users", spec, pgx.RowToStructByName[user])
+		require.NoError(t, err)
+		assert.Len(t, users, 3)
+
+		users, err = specquery.Find(ctx, pool, "SELECT id, name, age, active FROM users", spec, pgx.RowToStructByName[user])
+		require.NoError(t, err)
+		assert.Len(t, users, 2)
+	})
+}
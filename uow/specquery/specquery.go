@@ -0,0 +1,65 @@
+// Package specquery runs specifications against PostgreSQL, so repositories can accept a
+// specification.Specification and filter in the database instead of loading every row
+// for specification.Filter.
+package specquery
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/shortlink-org/go-sdk/specification"
+	"github.com/shortlink-org/go-sdk/uow"
+)
+
+// Querier is implemented by *pgxpool.Pool, *pgx.Conn and pgx.Tx.
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// Find runs selectSQL filtered by spec and scans every row with scan, e.g.
+// pgx.RowToStructByName[User].
+//
+// The WHERE clause comes from specification.ToSQL, so spec must be translatable;
+// otherwise the error wraps specification.ErrNotTranslatable and no query is sent.
+// selectSQL must not have a WHERE clause or placeholders of its own. The query runs in
+// the transaction carried by ctx (see uow.WithTx) when there is one, otherwise on db.
+func Find[T any](
+	ctx context.Context,
+	db Querier,
+	selectSQL string,
+	spec specification.Specification[T],
+	scan pgx.RowToFunc[T],
+) ([]T, error) {
+	query, args, err := Query(selectSQL, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx := uow.FromContext(ctx); tx != nil {
+		db = tx
+	}
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("specquery: query: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, scan)
+	if err != nil {
+		return nil, fmt.Errorf("specquery: scan: %w", err)
+	}
+
+	return items, nil
+}
+
+// Query appends the WHERE clause of spec to selectSQL and returns the query with its arguments.
+func Query[T any](selectSQL string, spec specification.Specification[T]) (string, []any, error) {
+	clause, args, err := specification.ToSQL(spec)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return selectSQL + " WHERE " + clause, args, nil
+}
@@ -0,0 +1,107 @@
+package specquery_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/specification"
+	"github.com/shortlink-org/go-sdk/uow/specquery"
+)
+
+type user struct {
+	ID     int64
+	Name   string
+	Age    int
+	Active bool
+}
+
+type ageAtLeast struct{ min int }
+
+func (s ageAtLeast) IsSatisfiedBy(u *user) error {
+	if u.Age < s.min {
+		return specification.ErrNotSatisfied
+	}
+
+	return nil
+}
+
+func (s ageAtLeast) ToSQL() (string, []any, error) {
+	return "age >= $1", []any{s.min}, nil
+}
+
+type active struct{}
+
+func (active) IsSatisfiedBy(u *user) error {
+	if !u.Active {
+		return specification.ErrNotSatisfied
+	}
+
+	return nil
+}
+
+func (active) ToSQL() (string, []any, error) {
+	return "active", nil, nil
+}
+
+type nameIs struct{ name string }
+
+func (s nameIs) IsSatisfiedBy(u *user) error {
+	if u.Name != s.name {
+		return specification.ErrNotSatisfied
+	}
+
+	return nil
+}
+
+func (s nameIs) ToSQL() (string, []any, error) {
+	return "name = $1", []any{s.name}, nil
+}
+
+// memoryOnly has no SQL translation.
+type memoryOnly struct{}
+
+func (memoryOnly) IsSatisfiedBy(*user) error { return nil }
+
+type recordingQuerier struct {
+	calls int
+}
+
+func (q *recordingQuerier) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	q.calls++
+
+	return nil, nil
+}
+
+func TestQuery(t *testing.T) {
+	// Arrange
+	spec := specification.AllOf[user](
+		ageAtLeast{min: 18},
+		specification.AnyOf[user](active{}, nameIs{name: "root"}),
+	)
+
+	// Act
+	query, args, err := specquery.Query("SELECT id, name, age, active FROM users", spec)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT id, name, age, active FROM users WHERE (age >= $1) AND ((active) OR (name = $2))", query)
+	assert.Equal(t, []any{18, "root"}, args)
+}
+
+func TestFind_NotTranslatable(t *testing.T) {
+	// Arrange
+	db := &recordingQuerier{}
+	spec := specification.AllOf[user](ageAtLeast{min: 18}, memoryOnly{})
+
+	// Act
+	items, err := specquery.Find(context.Background(), db, "SELECT * FROM users", spec, pgx.RowToStructByName[user])
+
+	// Assert
+	require.ErrorIs(t, err, specification.ErrNotTranslatable)
+	assert.Nil(t, items)
+	assert.Zero(t, db.calls)
+}
@@ -23,6 +23,10 @@ type MarshalerUnmarshaler interface {
 	Unmarshaler
 }
 
+// DefaultMarshaler maps Watermill metadata to Kafka record headers and back.
+// It also writes the W3C traceparent/tracestate headers for traced messages and
+// restores the trace metadata from them, so traces cross the broker boundary
+// even when the other side is not built on this SDK.
 type DefaultMarshaler struct{}
 
 func (DefaultMarshaler) Marshal(topic string, msg *message.Message) (*sarama.ProducerMessage, error) {
@@ -41,6 +45,8 @@ func (DefaultMarshaler) Marshal(topic string, msg *message.Message) (*sarama.Pro
 		})
 	}
 
+	headers = append(headers, traceHeaders(msg)...)
+
 	return &sarama.ProducerMessage{
 		Topic:   topic,
 		Value:   sarama.ByteEncoder(msg.Payload),
@@ -61,6 +67,8 @@ func (DefaultMarshaler) Unmarshal(kafkaMsg *sarama.ConsumerMessage) (*message.Me
 		}
 	}
 
+	extractTraceMetadata(metadata)
+
 	msg := message.NewMessage(messageID, kafkaMsg.Value)
 	msg.Metadata = metadata
 
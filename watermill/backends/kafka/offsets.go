@@ -0,0 +1,176 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/pkg/errors"
+
+	"github.com/shortlink-org/go-sdk/config"
+)
+
+var (
+	// ErrConsumerGroupActive is returned by ResetOffsets while the group still has members.
+	ErrConsumerGroupActive = errors.New("consumer group is active, stop all consumers before resetting offsets")
+	// ErrNoCommittedOffsets is returned by ResetOffsets when the group has never committed an offset.
+	ErrNoCommittedOffsets = errors.New("consumer group has no committed offsets")
+)
+
+// OffsetTarget is the position ResetOffsets moves a consumer group to.
+type OffsetTarget struct {
+	// at is sarama.OffsetOldest, sarama.OffsetNewest or a Unix timestamp in milliseconds.
+	at int64
+}
+
+var (
+	// OffsetEarliest moves the group to the oldest retained message.
+	OffsetEarliest = OffsetTarget{at: sarama.OffsetOldest}
+	// OffsetLatest moves the group past the newest message.
+	OffsetLatest = OffsetTarget{at: sarama.OffsetNewest}
+)
+
+// OffsetAt moves the group to the first message produced at or after t.
+// Partitions without such a message are moved to the latest offset.
+func OffsetAt(t time.Time) OffsetTarget {
+	return OffsetTarget{at: t.UnixMilli()}
+}
+
+// ResetOffsets rewrites the committed offsets of group for every partition it has consumed.
+//
+// It is meant for incident recovery and is never called automatically. The group
+// must be inactive (no running consumers), otherwise ErrConsumerGroupActive is returned.
+func ResetOffsets(ctx context.Context, cfg *config.Config, group string, to OffsetTarget) error {
+	if cfg == nil {
+		return errors.New("config is nil")
+	}
+
+	if group == "" {
+		return errors.New("consumer group is required")
+	}
+
+	settings, err := loadBackendSettings(cfg)
+	if err != nil {
+		return err
+	}
+
+	client, err := sarama.NewClient(settings.brokers, settings.subscriberSarama)
+	if err != nil {
+		return errors.Wrap(err, "cannot create Kafka client")
+	}
+	defer client.Close()
+
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		return errors.Wrap(err, "cannot create Kafka cluster admin")
+	}
+
+	err = ensureGroupInactive(admin, group)
+	if err != nil {
+		return err
+	}
+
+	committed, err := admin.ListConsumerGroupOffsets(group, nil)
+	if err != nil {
+		return errors.Wrapf(err, "cannot list offsets of consumer group %s", group)
+	}
+
+	if len(committed.Blocks) == 0 {
+		return errors.Wrap(ErrNoCommittedOffsets, group)
+	}
+
+	offsetManager, err := sarama.NewOffsetManagerFromClient(group, client)
+	if err != nil {
+		return errors.Wrap(err, "cannot create offset manager")
+	}
+	defer offsetManager.Close()
+
+	targets := make(map[string]map[int32]int64, len(committed.Blocks))
+
+	for topic, partitions := range committed.Blocks {
+		targets[topic] = make(map[int32]int64, len(partitions))
+
+		for partition := range partitions {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			offset, err := resetPartition(client, offsetManager, topic, partition, to)
+			if err != nil {
+				return err
+			}
+
+			targets[topic][partition] = offset
+		}
+	}
+
+	// Commit reports failures asynchronously, so read the offsets back to confirm.
+	offsetManager.Commit()
+
+	return verifyOffsets(admin, group, targets)
+}
+
+func verifyOffsets(admin sarama.ClusterAdmin, group string, targets map[string]map[int32]int64) error {
+	committed, err := admin.ListConsumerGroupOffsets(group, nil)
+	if err != nil {
+		return errors.Wrapf(err, "cannot list offsets of consumer group %s", group)
+	}
+
+	for topic, partitions := range targets {
+		for partition, want := range partitions {
+			block := committed.GetBlock(topic, partition)
+			if block == nil || block.Offset != want {
+				return errors.Errorf("offset of %s/%d was not reset to %d", topic, partition, want)
+			}
+		}
+	}
+
+	return nil
+}
+
+func ensureGroupInactive(admin sarama.ClusterAdmin, group string) error {
+	groups, err := admin.DescribeConsumerGroups([]string{group})
+	if err != nil {
+		return errors.Wrapf(err, "cannot describe consumer group %s", group)
+	}
+
+	for _, description := range groups {
+		if description.State != "Empty" && description.State != "Dead" {
+			return errors.Wrapf(ErrConsumerGroupActive, "%s (state %s)", group, description.State)
+		}
+	}
+
+	return nil
+}
+
+func resetPartition(
+	client sarama.Client,
+	offsetManager sarama.OffsetManager,
+	topic string,
+	partition int32,
+	to OffsetTarget,
+) (int64, error) {
+	offset, err := client.GetOffset(topic, partition, to.at)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot resolve offset for %s/%d", topic, partition)
+	}
+
+	if offset < 0 {
+		offset, err = client.GetOffset(topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return 0, errors.Wrapf(err, "cannot resolve latest offset for %s/%d", topic, partition)
+		}
+	}
+
+	pom, err := offsetManager.ManagePartition(topic, partition)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot manage offsets for %s/%d", topic, partition)
+	}
+	defer pom.AsyncClose()
+
+	// ResetOffset only moves backwards and MarkOffset only forwards; one of them applies.
+	pom.ResetOffset(offset, "")
+	pom.MarkOffset(offset, "")
+
+	return offset, nil
+}
@@ -0,0 +1,119 @@
+//go:build integration
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/config"
+	"github.com/shortlink-org/go-sdk/logger"
+)
+
+func TestResetOffsetsToEarliestReconsumes(t *testing.T) {
+	brokers := os.Getenv("WATERMILL_TEST_KAFKA_BROKERS")
+	if brokers == "" {
+		t.Skip("WATERMILL_TEST_KAFKA_BROKERS is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	suffix := time.Now().UnixNano()
+	topic := fmt.Sprintf("watermill-reset-%d", suffix)
+	group := fmt.Sprintf("watermill-reset-group-%d", suffix)
+
+	cfg := newTestConfig(t)
+	cfg.Set("SERVICE_NAME", "watermill-kafka-reset")
+	cfg.Set("WATERMILL_KAFKA_BROKERS", brokers)
+	cfg.Set("WATERMILL_KAFKA_CONSUMER_GROUP", group)
+	cfg.Set("WATERMILL_KAFKA_CONSUMER_INITIAL_OFFSET", "earliest")
+
+	log, cleanup, err := logger.NewDefault(ctx, cfg)
+	require.NoError(t, err)
+	t.Cleanup(cleanup)
+
+	published := make([]string, 0, 3)
+
+	first := consumeAll(ctx, t, log, cfg, topic, func(backend *Backend) {
+		for range 3 {
+			msg := message.NewMessage(watermill.NewUUID(), []byte("payload"))
+			require.NoError(t, backend.Publisher().Publish(topic, msg))
+
+			published = append(published, msg.UUID)
+		}
+	}, func() {
+		err := ResetOffsets(ctx, cfg, group, OffsetEarliest)
+		require.ErrorIs(t, err, ErrConsumerGroupActive)
+	})
+	require.Equal(t, published, first)
+
+	// Members leave asynchronously after Close; retry until the group is empty.
+	require.Eventually(t, func() bool {
+		err := ResetOffsets(ctx, cfg, group, OffsetEarliest)
+		if errors.Is(err, ErrConsumerGroupActive) {
+			return false
+		}
+
+		require.NoError(t, err)
+
+		return true
+	}, time.Minute, time.Second)
+
+	second := consumeAll(ctx, t, log, cfg, topic, nil, nil)
+	require.Equal(t, published, second)
+}
+
+// consumeAll subscribes with a fresh backend, runs publish, reads three messages,
+// runs whileActive while the group still has a member and closes the backend.
+func consumeAll(
+	ctx context.Context,
+	t *testing.T,
+	log logger.Logger,
+	cfg *config.Config,
+	topic string,
+	publish func(*Backend),
+	whileActive func(),
+) []string {
+	t.Helper()
+
+	backend, err := New(ctx, log, cfg)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, backend.Close())
+	}()
+
+	messages, err := backend.Subscriber().Subscribe(ctx, topic)
+	require.NoError(t, err)
+
+	if publish != nil {
+		publish(backend)
+	}
+
+	received := make([]string, 0, 3)
+
+	for len(received) < 3 {
+		select {
+		case msg := <-messages:
+			received = append(received, msg.UUID)
+			msg.Ack()
+		case <-time.After(30 * time.Second):
+			t.Fatalf("timeout waiting for kafka message, got %d", len(received))
+		}
+	}
+
+	if whileActive != nil {
+		whileActive()
+	}
+
+	return received
+}
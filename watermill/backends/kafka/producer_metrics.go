@@ -0,0 +1,150 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Error classes reported by watermill_kafka_producer_delivery_failures_total.
+const (
+	errorClassMarshal       = "marshal"
+	errorClassTimeout       = "timeout"
+	errorClassUnavailable   = "broker_unavailable"
+	errorClassLeader        = "leader_not_available"
+	errorClassReplicas      = "not_enough_replicas"
+	errorClassTooLarge      = "message_too_large"
+	errorClassUnknownTopic  = "unknown_topic_or_partition"
+	errorClassAuthorization = "authorization"
+	errorClassClosed        = "closed"
+	errorClassOther         = "other"
+)
+
+// producerMetrics counts what the Sarama producer does behind a Publish call;
+// a nil *producerMetrics records nothing.
+type producerMetrics struct {
+	retries  metric.Int64Counter
+	failures metric.Int64Counter
+	inFlight metric.Int64UpDownCounter
+}
+
+func newProducerMetrics(provider metric.MeterProvider) (*producerMetrics, error) {
+	m := provider.Meter("watermill/kafka")
+
+	retries, err := m.Int64Counter(
+		"watermill_kafka_producer_retries_total",
+		metric.WithDescription("Total number of produce retry rounds scheduled by the Kafka producer (one per partition batch retried, not per message)"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	failures, err := m.Int64Counter(
+		"watermill_kafka_producer_delivery_failures_total",
+		metric.WithDescription("Total number of messages the Kafka producer failed to deliver, by error class"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	inFlight, err := m.Int64UpDownCounter(
+		"watermill_kafka_producer_in_flight_requests",
+		metric.WithDescription("Number of produce requests waiting for a broker acknowledgement"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &producerMetrics{
+		retries:  retries,
+		failures: failures,
+		inFlight: inFlight,
+	}, nil
+}
+
+// instrumentRetries wraps the producer backoff so every retry round Sarama schedules
+// is counted. Sarama calls the backoff once per partition retry round, before
+// waiting, however many messages that round resends; the counter therefore
+// tracks retry rounds, not retried messages.
+func (m *producerMetrics) instrumentRetries(cfg *sarama.Config) {
+	if m == nil {
+		return
+	}
+
+	next := cfg.Producer.Retry.BackoffFunc
+	backoff := cfg.Producer.Retry.Backoff
+
+	cfg.Producer.Retry.BackoffFunc = func(retries, maxRetries int) time.Duration {
+		m.retries.Add(context.Background(), 1)
+
+		if next != nil {
+			return next(retries, maxRetries)
+		}
+
+		return backoff
+	}
+}
+
+// sendMessage produces msg and tracks it as in flight until the broker answers.
+func (m *producerMetrics) sendMessage(producer sarama.SyncProducer, msg *sarama.ProducerMessage) (int32, int64, error) {
+	if m == nil {
+		return producer.SendMessage(msg)
+	}
+
+	ctx := context.Background()
+	attrs := metric.WithAttributes(attribute.String("topic", msg.Topic))
+
+	m.inFlight.Add(ctx, 1, attrs)
+	defer m.inFlight.Add(ctx, -1, attrs)
+
+	partition, offset, err := producer.SendMessage(msg)
+	if err != nil {
+		m.recordFailure(msg.Topic, classifyProducerError(err))
+	}
+
+	return partition, offset, err
+}
+
+func (m *producerMetrics) recordFailure(topic, class string) {
+	if m == nil {
+		return
+	}
+
+	m.failures.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("topic", topic),
+		attribute.String("error_class", class),
+	))
+}
+
+// classifyProducerError maps a produce error to a low-cardinality error class.
+func classifyProducerError(err error) string {
+	switch {
+	case errors.Is(err, sarama.ErrRequestTimedOut), errors.Is(err, context.DeadlineExceeded):
+		return errorClassTimeout
+	case errors.Is(err, sarama.ErrOutOfBrokers), errors.Is(err, sarama.ErrNotConnected),
+		errors.Is(err, sarama.ErrBrokerNotAvailable):
+		return errorClassUnavailable
+	case errors.Is(err, sarama.ErrLeaderNotAvailable), errors.Is(err, sarama.ErrNotLeaderForPartition):
+		return errorClassLeader
+	case errors.Is(err, sarama.ErrNotEnoughReplicas), errors.Is(err, sarama.ErrNotEnoughReplicasAfterAppend):
+		return errorClassReplicas
+	case errors.Is(err, sarama.ErrMessageSizeTooLarge):
+		return errorClassTooLarge
+	case errors.Is(err, sarama.ErrUnknownTopicOrPartition):
+		return errorClassUnknownTopic
+	case errors.Is(err, sarama.ErrTopicAuthorizationFailed), errors.Is(err, sarama.ErrClusterAuthorizationFailed),
+		errors.Is(err, sarama.ErrTransactionalIDAuthorizationFailed):
+		return errorClassAuthorization
+	case errors.Is(err, sarama.ErrClosedClient), errors.Is(err, sarama.ErrShuttingDown):
+		return errorClassClosed
+	default:
+		return errorClassOther
+	}
+}
@@ -0,0 +1,101 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestPublisherMetricsCountTransientRetry(t *testing.T) {
+	const topic = "metrics-topic"
+
+	broker := sarama.NewMockBroker(t, 1)
+	t.Cleanup(broker.Close)
+
+	// The first produce request hits a leader change, the retry succeeds.
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(topic, 0, broker.BrokerID()),
+		"ProduceRequest": sarama.NewMockSequence(
+			sarama.NewMockProduceResponse(t).SetError(topic, 0, sarama.ErrNotLeaderForPartition),
+			sarama.NewMockProduceResponse(t),
+		),
+	})
+
+	saramaConfig := DefaultSaramaSyncPublisherConfig()
+	saramaConfig.ApiVersionsRequest = false // not answered by the mock broker
+	saramaConfig.Producer.Retry.Max = 3
+	saramaConfig.Producer.Retry.Backoff = time.Millisecond
+	saramaConfig.Metadata.Retry.Backoff = time.Millisecond
+
+	reader := sdkmetric.NewManualReader()
+
+	pub, err := NewPublisher(PublisherConfig{
+		Brokers:               []string{broker.Addr()},
+		OverwriteSaramaConfig: saramaConfig,
+		MeterProvider:         sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	}, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = pub.Close() })
+
+	require.NoError(t, pub.Publish(topic, message.NewMessage("1", []byte("payload"))))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	assert.Equal(t, int64(1), sumOf(t, rm, "watermill_kafka_producer_retries_total"))
+	assert.Equal(t, int64(0), sumOf(t, rm, "watermill_kafka_producer_in_flight_requests"))
+	assert.Nil(t, saramaConfig.Producer.Retry.BackoffFunc, "caller's sarama config must not be modified")
+}
+
+func TestClassifyProducerError(t *testing.T) {
+	tests := map[error]string{
+		&sarama.ProducerError{Err: sarama.ErrRequestTimedOut}: errorClassTimeout,
+		sarama.ErrOutOfBrokers:                                errorClassUnavailable,
+		sarama.ErrNotLeaderForPartition:                       errorClassLeader,
+		sarama.ErrNotEnoughReplicas:                           errorClassReplicas,
+		sarama.ErrMessageSizeTooLarge:                         errorClassTooLarge,
+		sarama.ErrUnknownTopicOrPartition:                     errorClassUnknownTopic,
+		sarama.ErrTopicAuthorizationFailed:                    errorClassAuthorization,
+		sarama.ErrClosedClient:                                errorClassClosed,
+		sarama.ErrInvalidMessage:                              errorClassOther,
+	}
+
+	for err, class := range tests {
+		assert.Equal(t, class, classifyProducerError(err), "%#v", err)
+	}
+}
+
+func sumOf(t *testing.T, rm metricdata.ResourceMetrics, name string) int64 {
+	t.Helper()
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok, "metric %s is not an int64 sum", name)
+
+			var total int64
+			for _, dp := range sum.DataPoints {
+				total += dp.Value
+			}
+
+			return total
+		}
+	}
+
+	t.Fatalf("metric %s not recorded", name)
+
+	return 0
+}
@@ -1,19 +1,27 @@
 package kafka
 
 import (
+	"sync"
 	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 )
 
 type Publisher struct {
 	config   PublisherConfig
 	producer sarama.SyncProducer
 	logger   watermill.LoggerAdapter
+	metrics  *producerMetrics
 
+	transactional bool
+	// txnMu serializes transactions: the producer has a single open transaction,
+	// so concurrent Publish calls would otherwise commit each other's messages.
+	txnMu  sync.Mutex
 	closed bool
 }
 
@@ -32,6 +40,26 @@ func NewPublisher(
 		logger = watermill.NopLogger{}
 	}
 
+	if config.OTELEnabled && config.MeterProvider == nil {
+		config.MeterProvider = otel.GetMeterProvider()
+	}
+
+	var metrics *producerMetrics
+
+	if config.MeterProvider != nil {
+		var err error
+
+		metrics, err = newProducerMetrics(config.MeterProvider)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot create Kafka producer metrics")
+		}
+
+		// Instrument a copy so a sarama config shared between publishers is not wrapped twice.
+		saramaConfig := *config.OverwriteSaramaConfig
+		metrics.instrumentRetries(&saramaConfig)
+		config.OverwriteSaramaConfig = &saramaConfig
+	}
+
 	producer, err := sarama.NewSyncProducer(config.Brokers, config.OverwriteSaramaConfig)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot create Kafka producer")
@@ -46,12 +74,30 @@ func NewPublisher(
 	}
 
 	return &Publisher{
-		config:   config,
-		producer: producer,
-		logger:   logger,
+		config:        config,
+		producer:      producer,
+		logger:        logger,
+		metrics:       metrics,
+		transactional: config.OverwriteSaramaConfig.Producer.Transaction.ID != "",
 	}, nil
 }
 
+// NewTransactionalPublisher creates a Kafka Publisher that wraps every Publish call in a
+// Kafka transaction, so a batch of messages is either committed together or not at all.
+//
+// OverwriteSaramaConfig must set Producer.Transaction.ID, enable the idempotent producer
+// and use acks=all.
+func NewTransactionalPublisher(
+	config PublisherConfig,
+	logger watermill.LoggerAdapter,
+) (*Publisher, error) {
+	if config.OverwriteSaramaConfig == nil || config.OverwriteSaramaConfig.Producer.Transaction.ID == "" {
+		return nil, errors.New("transactional publisher requires Producer.Transaction.ID")
+	}
+
+	return NewPublisher(config, logger)
+}
+
 type PublisherConfig struct {
 	// Kafka brokers list.
 	Brokers []string
@@ -68,6 +114,11 @@ type PublisherConfig struct {
 	// Tracer is used to trace Kafka messages.
 	// If nil, then no tracing will be used.
 	Tracer SaramaTracer
+
+	// MeterProvider records producer retries, delivery failures and in-flight requests.
+	// If nil and OTELEnabled is true, the global OpenTelemetry meter provider is used;
+	// otherwise no metrics are recorded.
+	MeterProvider metric.MeterProvider
 }
 
 func (c *PublisherConfig) setDefaults() {
@@ -89,6 +140,10 @@ func (c PublisherConfig) Validate() error {
 		return errors.New("missing marshaler")
 	}
 
+	if c.OverwriteSaramaConfig != nil && c.OverwriteSaramaConfig.Producer.Transaction.ID != "" {
+		return validateTransactionalSarama(c.OverwriteSaramaConfig)
+	}
+
 	return nil
 }
 
@@ -107,11 +162,40 @@ func DefaultSaramaSyncPublisherConfig() *sarama.Config {
 //
 // Publish is blocking and wait for ack from Kafka.
 // When one of messages delivery fails - function is interrupted.
+// For transactional publishers all messages are sent in a single transaction
+// which is aborted on the first failure.
 func (p *Publisher) Publish(topic string, msgs ...*message.Message) error {
 	if p.closed {
 		return errors.New("publisher closed")
 	}
 
+	if !p.transactional {
+		return p.send(topic, msgs)
+	}
+
+	p.txnMu.Lock()
+	defer p.txnMu.Unlock()
+
+	if err := p.producer.BeginTxn(); err != nil {
+		return errors.Wrap(err, "cannot begin Kafka transaction")
+	}
+
+	if err := p.send(topic, msgs); err != nil {
+		if abortErr := p.producer.AbortTxn(); abortErr != nil {
+			p.logger.Error("Cannot abort Kafka transaction", abortErr, watermill.LogFields{"topic": topic})
+		}
+
+		return err
+	}
+
+	if err := p.producer.CommitTxn(); err != nil {
+		return errors.Wrap(err, "cannot commit Kafka transaction")
+	}
+
+	return nil
+}
+
+func (p *Publisher) send(topic string, msgs []*message.Message) error {
 	logFields := make(watermill.LogFields, 4)
 	logFields["topic"] = topic
 
@@ -121,10 +205,12 @@ func (p *Publisher) Publish(topic string, msgs ...*message.Message) error {
 
 		kafkaMsg, err := p.config.Marshaler.Marshal(topic, msg)
 		if err != nil {
+			p.metrics.recordFailure(topic, errorClassMarshal)
+
 			return errors.Wrapf(err, "cannot marshal message %s", msg.UUID)
 		}
 
-		partition, offset, err := p.producer.SendMessage(kafkaMsg)
+		partition, offset, err := p.metrics.sendMessage(p.producer, kafkaMsg)
 		if err != nil {
 			return errors.Wrapf(err, "cannot produce message %s", msg.UUID)
 		}
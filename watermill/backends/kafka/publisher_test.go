@@ -0,0 +1,101 @@
+package kafka
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// txnProducer is a SyncProducer with a single open transaction, like sarama's.
+// It records every transaction that was begun while another one was open.
+type txnProducer struct {
+	sarama.SyncProducer
+
+	mu        sync.Mutex
+	open      bool
+	overlaps  atomic.Int64
+	committed []string
+	pending   []string
+}
+
+func (p *txnProducer) BeginTxn() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.open {
+		p.overlaps.Add(1)
+	}
+
+	p.open = true
+
+	return nil
+}
+
+func (p *txnProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	// Give a concurrent Publish the chance to interleave.
+	time.Sleep(5 * time.Millisecond)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.pending = append(p.pending, msg.Topic)
+
+	return 0, 0, nil
+}
+
+func (p *txnProducer) CommitTxn() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.committed = append(p.committed, p.pending...)
+	p.pending = nil
+	p.open = false
+
+	return nil
+}
+
+func (p *txnProducer) AbortTxn() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.pending = nil
+	p.open = false
+
+	return nil
+}
+
+func TestTransactionalPublishSerializesConcurrentCalls(t *testing.T) {
+	producer := &txnProducer{}
+
+	pub := &Publisher{
+		config:        PublisherConfig{Marshaler: DefaultMarshaler{}},
+		producer:      producer,
+		logger:        watermill.NopLogger{},
+		transactional: true,
+	}
+
+	const publishers = 8
+
+	var wg sync.WaitGroup
+
+	for range publishers {
+		wg.Go(func() {
+			assert.NoError(t, pub.Publish("orders",
+				message.NewMessage(watermill.NewUUID(), []byte("a")),
+				message.NewMessage(watermill.NewUUID(), []byte("b")),
+			))
+		})
+	}
+
+	wg.Wait()
+
+	require.Zero(t, producer.overlaps.Load(), "transactions of concurrent Publish calls overlapped")
+	require.Len(t, producer.committed, 2*publishers)
+}
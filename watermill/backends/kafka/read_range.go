@@ -0,0 +1,159 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/pkg/errors"
+
+	"github.com/shortlink-org/go-sdk/config"
+)
+
+// readRangeIdleTimeout ends a partition read when no record arrives for this long. The last
+// offsets of a range may hold no deliverable record (transaction markers, compacted records),
+// so the consumer cannot wait for offset end-1 to show up.
+var readRangeIdleTimeout = 5 * time.Second
+
+// ReadRange calls fn for every message of topic between from (inclusive) and to (exclusive),
+// partition by partition and in offset order within each partition.
+//
+// A partition is done once a record at or past to is seen, the partition tail is reached,
+// or no record arrives for 5 seconds (the range ends in transaction markers or compacted
+// records).
+//
+// It reads with plain partition consumers: no consumer group is joined and no offsets are
+// committed, so it is safe to run next to live consumers (e.g. to rebuild projections).
+// Returning an error from fn stops the read.
+func ReadRange(
+	ctx context.Context,
+	cfg *config.Config,
+	topic string,
+	from, to OffsetTarget,
+	fn func(*message.Message) error,
+) error {
+	if cfg == nil {
+		return errors.New("config is nil")
+	}
+
+	settings, err := loadBackendSettings(cfg)
+	if err != nil {
+		return err
+	}
+
+	client, err := sarama.NewClient(settings.brokers, settings.subscriberSarama)
+	if err != nil {
+		return errors.Wrap(err, "cannot create Kafka client")
+	}
+	defer client.Close()
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return errors.Wrap(err, "cannot create Kafka consumer")
+	}
+	defer consumer.Close()
+
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return errors.Wrapf(err, "cannot list partitions of %s", topic)
+	}
+
+	for _, partition := range partitions {
+		start, err := resolveOffset(client, topic, partition, from)
+		if err != nil {
+			return err
+		}
+
+		end, err := resolveOffset(client, topic, partition, to)
+		if err != nil {
+			return err
+		}
+
+		if start >= end {
+			continue
+		}
+
+		err = readPartition(ctx, consumer, topic, partition, start, end, fn)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveOffset turns target into an absolute offset; timestamps past the newest message resolve to the newest offset.
+func resolveOffset(client sarama.Client, topic string, partition int32, target OffsetTarget) (int64, error) {
+	offset, err := client.GetOffset(topic, partition, target.at)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot resolve offset for %s/%d", topic, partition)
+	}
+
+	if offset < 0 {
+		offset, err = client.GetOffset(topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return 0, errors.Wrapf(err, "cannot resolve latest offset for %s/%d", topic, partition)
+		}
+	}
+
+	return offset, nil
+}
+
+func readPartition(
+	ctx context.Context,
+	consumer sarama.Consumer,
+	topic string,
+	partition int32,
+	start, end int64,
+	fn func(*message.Message) error,
+) error {
+	partitionConsumer, err := consumer.ConsumePartition(topic, partition, start)
+	if err != nil {
+		return errors.Wrapf(err, "cannot consume %s/%d", topic, partition)
+	}
+	defer partitionConsumer.AsyncClose()
+
+	var marshaler DefaultMarshaler
+
+	idle := time.NewTimer(readRangeIdleTimeout)
+	defer idle.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-idle.C:
+			return nil
+		case consumerErr := <-partitionConsumer.Errors():
+			return errors.Wrapf(consumerErr, "cannot read %s/%d", topic, partition)
+		case kafkaMsg := <-partitionConsumer.Messages():
+			// end-1 may be missing (compaction, transaction markers), so the next record can be past the range.
+			if kafkaMsg.Offset >= end {
+				return nil
+			}
+
+			msg, err := marshaler.Unmarshal(kafkaMsg)
+			if err != nil {
+				return errors.Wrapf(err, "cannot unmarshal %s/%d@%d", topic, partition, kafkaMsg.Offset)
+			}
+
+			msgCtx := setPartitionToCtx(ctx, kafkaMsg.Partition)
+			msgCtx = setPartitionOffsetToCtx(msgCtx, kafkaMsg.Offset)
+			msgCtx = setMessageTimestampToCtx(msgCtx, kafkaMsg.Timestamp)
+			msgCtx = setMessageKeyToCtx(msgCtx, kafkaMsg.Key)
+			msg.SetContext(msgCtx)
+
+			if err := fn(msg); err != nil {
+				return err
+			}
+
+			// Compacted topics may have no message at end-1, so also stop at the partition tail.
+			if kafkaMsg.Offset >= end-1 || kafkaMsg.Offset+1 >= partitionConsumer.HighWaterMarkOffset() {
+				return nil
+			}
+
+			idle.Reset(readRangeIdleTimeout)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRange(t *testing.T) {
+	const topic = "range-topic"
+
+	upTo := time.UnixMilli(1_700_000_000_000)
+
+	tests := map[string]struct {
+		offsets       []int64
+		highWaterMark int64
+		to            OffsetTarget
+		want          []int64
+	}{
+		// Offset 2 (end-1) was compacted away, the next record is already past the range.
+		"gap before range end": {
+			offsets:       []int64{0, 1, 3, 4},
+			highWaterMark: 5,
+			to:            OffsetAt(upTo),
+			want:          []int64{0, 1},
+		},
+		// Offsets 2 and 3 are transaction markers, no record ever reaches end-1.
+		"range ends in control records": {
+			offsets:       []int64{0, 1},
+			highWaterMark: 4,
+			to:            OffsetLatest,
+			want:          []int64{0, 1},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			setReadRangeIdleTimeout(t, 200*time.Millisecond)
+
+			broker := sarama.NewMockBroker(t, 1)
+			t.Cleanup(broker.Close)
+
+			fetch := sarama.NewMockFetchResponse(t, 1).SetHighWaterMark(topic, 0, tt.highWaterMark)
+			for _, offset := range tt.offsets {
+				fetch.SetMessage(topic, 0, offset, sarama.StringEncoder("payload"))
+			}
+
+			broker.SetHandlerByMap(map[string]sarama.MockResponse{
+				"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+				"MetadataRequest": sarama.NewMockMetadataResponse(t).
+					SetBroker(broker.Addr(), broker.BrokerID()).
+					SetLeader(topic, 0, broker.BrokerID()),
+				"OffsetRequest": sarama.NewMockOffsetResponse(t).
+					SetOffset(topic, 0, sarama.OffsetOldest, 0).
+					SetOffset(topic, 0, sarama.OffsetNewest, tt.highWaterMark).
+					SetOffset(topic, 0, upTo.UnixMilli(), 3),
+				"FetchRequest": fetch,
+			})
+
+			cfg := newTestConfig(t)
+			cfg.Set("SERVICE_NAME", "range-reader")
+			cfg.Set("WATERMILL_KAFKA_BROKERS", []string{broker.Addr()})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			var got []int64
+
+			err := ReadRange(ctx, cfg, topic, OffsetEarliest, tt.to, func(msg *message.Message) error {
+				offset, ok := MessagePartitionOffsetFromCtx(msg.Context())
+				require.True(t, ok)
+
+				got = append(got, offset)
+
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func setReadRangeIdleTimeout(t *testing.T, timeout time.Duration) {
+	t.Helper()
+
+	previous := readRangeIdleTimeout
+	readRangeIdleTimeout = timeout
+
+	t.Cleanup(func() { readRangeIdleTimeout = previous })
+}
@@ -19,6 +19,7 @@ type backendSettings struct {
 	reconnectSleep          time.Duration
 	waitForTopicTimeout     time.Duration
 	skipTopicInitialization bool
+	offsetCommit            OffsetCommitStrategy
 
 	publisherSarama  *sarama.Config
 	subscriberSarama *sarama.Config
@@ -39,6 +40,8 @@ type kafkaConfig struct {
 	producerRetryMax        int
 	compression             sarama.CompressionCodec
 	idempotentProducer      bool
+	transactionalID         string
+	offsetCommit            OffsetCommitStrategy
 }
 
 func (s *backendSettings) publisherConfig() PublisherConfig {
@@ -59,6 +62,7 @@ func (s *backendSettings) subscriberConfig() SubscriberConfig {
 		WaitForTopicCreationTimeout: s.waitForTopicTimeout,
 		DoNotWaitForTopicCreation:   s.skipTopicInitialization,
 		OTELEnabled:                 s.enableOTEL,
+		OffsetCommit:                s.offsetCommit,
 	}
 }
 
@@ -80,6 +84,15 @@ func loadBackendSettings(cfg *config.Config) (*backendSettings, error) {
 		pubSarama.Net.MaxOpenRequests = 1
 	}
 
+	if kcfg.transactionalID != "" {
+		pubSarama.Producer.Transaction.ID = kcfg.transactionalID
+
+		err = validateTransactionalSarama(pubSarama)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	subSarama := DefaultSaramaSubscriberConfig()
 	subSarama.ClientID = kcfg.clientID
 	subSarama.Version = kcfg.version
@@ -94,6 +107,7 @@ func loadBackendSettings(cfg *config.Config) (*backendSettings, error) {
 		reconnectSleep:          kcfg.reconnectSleep,
 		waitForTopicTimeout:     kcfg.waitForTopicTimeout,
 		skipTopicInitialization: kcfg.skipTopicInitialization,
+		offsetCommit:            kcfg.offsetCommit,
 		publisherSarama:         pubSarama,
 		subscriberSarama:        subSarama,
 	}, nil
@@ -139,6 +153,11 @@ func newKafkaConfig(cfg *config.Config) (*kafkaConfig, error) {
 		return nil, err
 	}
 
+	offsetCommit, err := parseOffsetCommit(firstNonEmpty(cfg.GetString("WATERMILL_KAFKA_OFFSET_COMMIT"), "auto"))
+	if err != nil {
+		return nil, err
+	}
+
 	producerRetryMax := cfg.GetInt("WATERMILL_KAFKA_PRODUCER_RETRY_MAX")
 	if producerRetryMax == 0 {
 		producerRetryMax = 10
@@ -151,6 +170,11 @@ func newKafkaConfig(cfg *config.Config) (*kafkaConfig, error) {
 	waitTimeout := durationWithDefault(cfg, "WATERMILL_KAFKA_WAIT_FOR_TOPIC_TIMEOUT", 10*time.Second)
 	skipTopicInit := boolWithDefault(cfg, "WATERMILL_KAFKA_SKIP_TOPIC_INIT", false)
 
+	transactionalID := strings.TrimSpace(cfg.GetString("WATERMILL_KAFKA_PRODUCER_TRANSACTIONAL_ID"))
+	if transactionalID != "" && !idempotent {
+		return nil, errors.New("WATERMILL_KAFKA_PRODUCER_TRANSACTIONAL_ID requires WATERMILL_KAFKA_PRODUCER_IDEMPOTENT=true")
+	}
+
 	return &kafkaConfig{
 		brokers:                 brokers,
 		consumerGroup:           consumerGroup,
@@ -166,9 +190,28 @@ func newKafkaConfig(cfg *config.Config) (*kafkaConfig, error) {
 		producerRetryMax:        producerRetryMax,
 		compression:             compression,
 		idempotentProducer:      idempotent,
+		transactionalID:         transactionalID,
+		offsetCommit:            offsetCommit,
 	}, nil
 }
 
+// validateTransactionalSarama checks producer settings required by Kafka transactions.
+func validateTransactionalSarama(cfg *sarama.Config) error {
+	if !cfg.Producer.Idempotent {
+		return errors.New("transactional producer requires idempotence")
+	}
+
+	if cfg.Producer.RequiredAcks != sarama.WaitForAll {
+		return errors.New("transactional producer requires acks=all")
+	}
+
+	if cfg.Net.MaxOpenRequests != 1 {
+		return errors.New("transactional producer requires Net.MaxOpenRequests=1")
+	}
+
+	return nil
+}
+
 func parseBrokerList(cfg *config.Config) []string {
 	brokers := filterBrokers(cfg.GetStringSlice("WATERMILL_KAFKA_BROKERS"))
 	if len(brokers) > 0 {
@@ -213,6 +256,17 @@ func parseInitialOffset(raw string) (int64, error) {
 	}
 }
 
+func parseOffsetCommit(raw string) (OffsetCommitStrategy, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", "auto":
+		return OffsetCommitAuto, nil
+	case "after_ack", "after-ack":
+		return OffsetCommitAfterAck, nil
+	default:
+		return OffsetCommitAuto, fmt.Errorf("unsupported WATERMILL_KAFKA_OFFSET_COMMIT: %s", raw)
+	}
+}
+
 func parseRebalanceStrategy(raw string) (sarama.BalanceStrategy, error) {
 	switch strings.ToLower(strings.TrimSpace(raw)) {
 	case "", "range":
@@ -32,6 +32,7 @@ func TestNewKafkaConfigDefaults(t *testing.T) {
 	assert.Equal(t, 10, kcfg.producerRetryMax)
 	assert.Equal(t, sarama.CompressionSnappy, kcfg.compression)
 	assert.True(t, kcfg.idempotentProducer)
+	assert.Equal(t, OffsetCommitAuto, kcfg.offsetCommit)
 }
 
 func TestNewKafkaConfigOverrides(t *testing.T) {
@@ -52,6 +53,7 @@ func TestNewKafkaConfigOverrides(t *testing.T) {
 	cfg.Set("WATERMILL_KAFKA_SUBSCRIBER_RECONNECT_SLEEP", 2*time.Second)
 	cfg.Set("WATERMILL_KAFKA_WAIT_FOR_TOPIC_TIMEOUT", 30*time.Second)
 	cfg.Set("WATERMILL_KAFKA_SKIP_TOPIC_INIT", true)
+	cfg.Set("WATERMILL_KAFKA_OFFSET_COMMIT", "after_ack")
 
 	kcfg, err := newKafkaConfig(cfg)
 	require.NoError(t, err)
@@ -72,6 +74,72 @@ func TestNewKafkaConfigOverrides(t *testing.T) {
 	assert.Equal(t, 2*time.Second, kcfg.reconnectSleep)
 	assert.Equal(t, 30*time.Second, kcfg.waitForTopicTimeout)
 	assert.True(t, kcfg.skipTopicInitialization)
+	assert.Equal(t, OffsetCommitAfterAck, kcfg.offsetCommit)
+}
+
+func TestLoadBackendSettingsTransactional(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Set("WATERMILL_KAFKA_PRODUCER_TRANSACTIONAL_ID", "orders-outbox-forwarder")
+
+	settings, err := loadBackendSettings(cfg)
+	require.NoError(t, err)
+
+	pub := settings.publisherSarama
+	assert.Equal(t, "orders-outbox-forwarder", pub.Producer.Transaction.ID)
+	assert.True(t, pub.Producer.Idempotent)
+	assert.Equal(t, sarama.WaitForAll, pub.Producer.RequiredAcks)
+	assert.Equal(t, 1, pub.Net.MaxOpenRequests)
+	require.NoError(t, pub.Validate())
+
+	pubCfg := settings.publisherConfig()
+	pubCfg.setDefaults()
+	require.NoError(t, pubCfg.Validate())
+}
+
+func TestLoadBackendSettingsNotTransactionalByDefault(t *testing.T) {
+	cfg := newTestConfig(t)
+
+	settings, err := loadBackendSettings(cfg)
+	require.NoError(t, err)
+
+	assert.Empty(t, settings.publisherSarama.Producer.Transaction.ID)
+}
+
+func TestNewKafkaConfigTransactionalRequiresIdempotence(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Set("WATERMILL_KAFKA_PRODUCER_TRANSACTIONAL_ID", "orders-outbox-forwarder")
+	cfg.Set("WATERMILL_KAFKA_PRODUCER_IDEMPOTENT", false)
+
+	_, err := newKafkaConfig(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "WATERMILL_KAFKA_PRODUCER_IDEMPOTENT")
+}
+
+func TestPublisherConfigValidateTransactional(t *testing.T) {
+	saramaCfg := DefaultSaramaSyncPublisherConfig()
+	saramaCfg.Producer.Transaction.ID = "tx"
+
+	pubCfg := PublisherConfig{
+		Brokers:               []string{"localhost:9092"},
+		Marshaler:             DefaultMarshaler{},
+		OverwriteSaramaConfig: saramaCfg,
+	}
+	require.Error(t, pubCfg.Validate(), "idempotence is required")
+
+	saramaCfg.Producer.Idempotent = true
+	require.Error(t, pubCfg.Validate(), "acks=all is required")
+
+	saramaCfg.Producer.RequiredAcks = sarama.WaitForAll
+	saramaCfg.Net.MaxOpenRequests = 1
+	require.NoError(t, pubCfg.Validate())
+}
+
+func TestNewTransactionalPublisherRequiresTransactionID(t *testing.T) {
+	_, err := NewTransactionalPublisher(PublisherConfig{
+		Brokers:               []string{"localhost:9092"},
+		OverwriteSaramaConfig: DefaultSaramaSyncPublisherConfig(),
+	}, nil)
+	require.Error(t, err)
 }
 
 func newTestConfig(t *testing.T) *config.Config {
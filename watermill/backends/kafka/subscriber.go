@@ -93,8 +93,32 @@ type SubscriberConfig struct {
 	// Tracer is used to trace Kafka messages.
 	// If nil, then no tracing will be used.
 	Tracer SaramaTracer
+
+	// OffsetCommit selects when consumer group offsets are committed.
+	// Defaults to OffsetCommitAuto.
+	OffsetCommit OffsetCommitStrategy
 }
 
+// OffsetCommitStrategy selects when the offsets of acked messages are committed.
+//
+// With either strategy an offset is only marked once its message is acked: a nacked
+// message is redelivered from the same offset and never advances the group, so
+// delivery is at-least-once. The strategies differ in how much may be redelivered
+// after a crash or rebalance.
+type OffsetCommitStrategy int
+
+const (
+	// OffsetCommitAuto marks offsets on ack and lets sarama commit them in the
+	// background every Consumer.Offsets.AutoCommit.Interval (1s by default).
+	// Messages acked within the last interval may be redelivered after a crash.
+	OffsetCommitAuto OffsetCommitStrategy = iota
+	// OffsetCommitAfterAck disables sarama auto-commit and commits synchronously after
+	// every ack, so only the in-flight message is redelivered after a crash, at the
+	// cost of one commit request per message. Ack only after side effects (e.g. the
+	// DB write) succeed.
+	OffsetCommitAfterAck
+)
+
 // NoSleep can be set to SubscriberConfig.NackResendSleep and SubscriberConfig.ReconnectRetrySleep.
 const NoSleep time.Duration = -1
 
@@ -118,6 +142,13 @@ func (c *SubscriberConfig) setDefaults() {
 	if c.WaitForTopicCreationTimeout == 0 {
 		c.WaitForTopicCreationTimeout = 10 * time.Second
 	}
+
+	if c.OffsetCommit == OffsetCommitAfterAck {
+		// copy, so the caller's sarama config is left untouched
+		saramaConfig := *c.OverwriteSaramaConfig
+		saramaConfig.Consumer.Offsets.AutoCommit.Enable = false
+		c.OverwriteSaramaConfig = &saramaConfig
+	}
 }
 
 func (c SubscriberConfig) Validate() error {
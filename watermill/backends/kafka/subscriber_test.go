@@ -0,0 +1,135 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSession records marked offsets and commits of a consumer group session.
+type recordingSession struct {
+	ctx context.Context
+
+	mu      sync.Mutex
+	marked  []int64
+	commits int
+}
+
+func (s *recordingSession) Claims() map[string][]int32 { return nil }
+func (s *recordingSession) MemberID() string           { return "member" }
+func (s *recordingSession) GenerationID() int32        { return 1 }
+func (s *recordingSession) Context() context.Context   { return s.ctx }
+
+func (s *recordingSession) MarkOffset(string, int32, int64, string)  {}
+func (s *recordingSession) ResetOffset(string, int32, int64, string) {}
+
+func (s *recordingSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.marked = append(s.marked, msg.Offset)
+}
+
+func (s *recordingSession) Commit() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.commits++
+}
+
+func (s *recordingSession) state() ([]int64, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]int64(nil), s.marked...), s.commits
+}
+
+func TestProcessMessage_NackedMessageIsRedeliveredAndNotCommitted(t *testing.T) {
+	tests := []struct {
+		name        string
+		strategy    OffsetCommitStrategy
+		wantCommits int
+	}{
+		{name: "auto", strategy: OffsetCommitAuto, wantCommits: 0},
+		{name: "after_ack", strategy: OffsetCommitAfterAck, wantCommits: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := SubscriberConfig{Brokers: []string{"localhost:9092"}, OffsetCommit: tt.strategy, NackResendSleep: NoSleep}
+			cfg.setDefaults()
+
+			output := make(chan *message.Message)
+			handler := messageHandler{
+				outputChannel:   output,
+				unmarshaler:     cfg.Unmarshaler,
+				saramaConfig:    cfg.OverwriteSaramaConfig,
+				nackResendSleep: cfg.NackResendSleep,
+				logger:          watermill.NopLogger{},
+				closing:         make(chan struct{}),
+			}
+
+			kafkaMsg := &sarama.ConsumerMessage{
+				Topic:     "orders",
+				Partition: 0,
+				Offset:    42,
+				Value:     []byte("payload"),
+				Headers:   []*sarama.RecordHeader{{Key: []byte(UUIDHeaderKey), Value: []byte("msg-1")}},
+			}
+			sess := &recordingSession{ctx: context.Background()}
+
+			done := make(chan error, 1)
+
+			go func() {
+				done <- handler.processMessage(context.Background(), kafkaMsg, sess, watermill.LogFields{})
+			}()
+
+			first := receive(t, output)
+			first.Nack()
+
+			redelivered := receive(t, output)
+			assert.Equal(t, first.UUID, redelivered.UUID)
+
+			marked, commits := sess.state()
+			assert.Empty(t, marked, "nacked message must not advance the offset")
+			assert.Zero(t, commits)
+
+			redelivered.Ack()
+			require.NoError(t, <-done)
+
+			marked, commits = sess.state()
+			assert.Equal(t, []int64{42}, marked)
+			assert.Equal(t, tt.wantCommits, commits)
+		})
+	}
+}
+
+func TestSubscriberConfig_AfterAckDisablesAutoCommit(t *testing.T) {
+	saramaConfig := DefaultSaramaSubscriberConfig()
+
+	cfg := SubscriberConfig{OverwriteSaramaConfig: saramaConfig, OffsetCommit: OffsetCommitAfterAck}
+	cfg.setDefaults()
+
+	assert.False(t, cfg.OverwriteSaramaConfig.Consumer.Offsets.AutoCommit.Enable)
+	assert.True(t, saramaConfig.Consumer.Offsets.AutoCommit.Enable, "caller's sarama config must not change")
+}
+
+func receive(t *testing.T, output <-chan *message.Message) *message.Message {
+	t.Helper()
+
+	select {
+	case msg := <-output:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not delivered")
+
+		return nil
+	}
+}
@@ -0,0 +1,61 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/IBM/sarama"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	sdkwatermill "github.com/shortlink-org/go-sdk/watermill"
+)
+
+// traceContext propagates W3C trace context (traceparent/tracestate) independently
+// of the global propagator, so broker-level continuity does not depend on app setup.
+var traceContext = propagation.TraceContext{}
+
+// traceHeaders returns W3C trace context record headers for msg, taken from its
+// context or, failing that, from the trace IDs in its metadata (see InjectTrace).
+// Headers already present in the metadata are left to the caller's values.
+func traceHeaders(msg *message.Message) []sarama.RecordHeader {
+	ctx := msg.Context()
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = sdkwatermill.ExtractTrace(context.Background(), msg)
+	}
+
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+
+	carrier := propagation.MapCarrier{}
+	traceContext.Inject(ctx, carrier)
+
+	headers := make([]sarama.RecordHeader, 0, len(carrier))
+
+	for key, value := range carrier {
+		if msg.Metadata.Get(key) != "" {
+			continue
+		}
+
+		headers = append(headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+	}
+
+	return headers
+}
+
+// extractTraceMetadata fills the trace IDs read by ExtractTrace from a traceparent
+// header, so records produced outside the SDK continue the producer's trace.
+func extractTraceMetadata(metadata message.Metadata) {
+	if metadata.Get(sdkwatermill.MetaTraceID) != "" {
+		return
+	}
+
+	spanCtx := trace.SpanContextFromContext(traceContext.Extract(context.Background(), propagation.MapCarrier(metadata)))
+	if !spanCtx.IsValid() {
+		return
+	}
+
+	metadata.Set(sdkwatermill.MetaTraceID, spanCtx.TraceID().String())
+	metadata.Set(sdkwatermill.MetaSpanID, spanCtx.SpanID().String())
+}
@@ -0,0 +1,65 @@
+package kafka_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/message/router/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	sdkwatermill "github.com/shortlink-org/go-sdk/watermill"
+	"github.com/shortlink-org/go-sdk/watermill/backends/kafka"
+)
+
+func TestDefaultMarshaler_TraceHeadersRoundTrip(t *testing.T) {
+	m := kafka.DefaultMarshaler{}
+
+	traceID, err := trace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("b7ad6b7169203331")
+	require.NoError(t, err)
+
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	msg := message.NewMessage(watermill.NewUUID(), []byte("payload"))
+	msg.Metadata.Set(middleware.CorrelationIDMetadataKey, "corr-1")
+	sdkwatermill.InjectTrace(ctx, msg)
+
+	marshaled, err := m.Marshal("topic", msg)
+	require.NoError(t, err)
+
+	headers := map[string]string{}
+	for _, header := range marshaled.Headers {
+		headers[string(header.Key)] = string(header.Value)
+	}
+
+	assert.Equal(t, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", headers["traceparent"])
+	assert.Equal(t, "corr-1", headers[middleware.CorrelationIDMetadataKey])
+
+	// Drop the SDK metadata headers: a consumer must recover the trace from traceparent alone.
+	consumed := producerToConsumerMessage(marshaled)
+	consumed.Headers = slices.DeleteFunc(consumed.Headers, func(header *sarama.RecordHeader) bool {
+		key := string(header.Key)
+
+		return key == sdkwatermill.MetaTraceID || key == sdkwatermill.MetaSpanID
+	})
+
+	unmarshaled, err := m.Unmarshal(consumed)
+	require.NoError(t, err)
+
+	assert.Equal(t, "corr-1", middleware.MessageCorrelationID(unmarshaled))
+
+	spanCtx := trace.SpanContextFromContext(sdkwatermill.ExtractTrace(context.Background(), unmarshaled))
+	assert.Equal(t, traceID, spanCtx.TraceID())
+	assert.Equal(t, spanID, spanCtx.SpanID())
+}
@@ -0,0 +1,96 @@
+package dlq
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+)
+
+// Metadata keys of the failure envelope set on every DLQ message.
+const (
+	MetadataOriginalTopic = "dlq_original_topic"
+	MetadataHandler       = "dlq_handler"
+	MetadataError         = "dlq_error"
+	MetadataAttempts      = "dlq_attempts"
+	MetadataFirstSeenAt   = "dlq_first_seen_at"
+	MetadataLastSeenAt    = "dlq_last_seen_at"
+)
+
+// ErrNotDLQMessage is returned by FailureFromMessage for messages without a failure envelope.
+var ErrNotDLQMessage = errors.New("dlq: message has no failure envelope")
+
+// Failure is the structured failure envelope carried in DLQ message metadata,
+// so a DLQ message can be triaged without decoding its payload.
+type Failure struct {
+	// OriginalTopic is the topic the failed message was consumed from.
+	OriginalTopic string
+	// Handler is the name of the router handler that failed.
+	Handler string
+	// Error is the error returned by the last attempt.
+	Error string
+	// Attempts counts the runs of the message through the poison middleware: 1, plus one for
+	// each re-run by a retry middleware placed outside it. Redeliveries by the broker start over.
+	Attempts int
+	// FirstSeenAt and LastSeenAt are the start times of the first and last attempts.
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+}
+
+// FailureFromMessage reads the failure envelope back from a DLQ message.
+func FailureFromMessage(msg *message.Message) (Failure, error) {
+	if msg == nil || msg.Metadata.Get(MetadataError) == "" {
+		return Failure{}, ErrNotDLQMessage
+	}
+
+	failure := Failure{
+		OriginalTopic: msg.Metadata.Get(MetadataOriginalTopic),
+		Handler:       msg.Metadata.Get(MetadataHandler),
+		Error:         msg.Metadata.Get(MetadataError),
+	}
+
+	var err error
+
+	if failure.Attempts, err = strconv.Atoi(msg.Metadata.Get(MetadataAttempts)); err != nil {
+		return Failure{}, fmt.Errorf("dlq: %s: %w", MetadataAttempts, err)
+	}
+
+	if failure.FirstSeenAt, err = time.Parse(time.RFC3339Nano, msg.Metadata.Get(MetadataFirstSeenAt)); err != nil {
+		return Failure{}, fmt.Errorf("dlq: %s: %w", MetadataFirstSeenAt, err)
+	}
+
+	if failure.LastSeenAt, err = time.Parse(time.RFC3339Nano, msg.Metadata.Get(MetadataLastSeenAt)); err != nil {
+		return Failure{}, fmt.Errorf("dlq: %s: %w", MetadataLastSeenAt, err)
+	}
+
+	return failure, nil
+}
+
+// setFailureMetadata writes the envelope for event to msg, defaulting what the event leaves unset.
+func setFailureMetadata(msg *message.Message, event DLQEvent) {
+	topic := event.OriginalTopic
+	if topic == "" {
+		topic = event.OriginalMsg.Metadata.Get("received_topic")
+	}
+
+	attempts := max(event.Attempts, 1)
+
+	lastSeen := event.LastSeenAt
+	if lastSeen.IsZero() {
+		lastSeen = event.FailedAt
+	}
+
+	firstSeen := event.FirstSeenAt
+	if firstSeen.IsZero() {
+		firstSeen = lastSeen
+	}
+
+	msg.Metadata.Set(MetadataOriginalTopic, topic)
+	msg.Metadata.Set(MetadataHandler, event.Handler)
+	msg.Metadata.Set(MetadataError, event.Reason)
+	msg.Metadata.Set(MetadataAttempts, strconv.Itoa(attempts))
+	msg.Metadata.Set(MetadataFirstSeenAt, firstSeen.UTC().Format(time.RFC3339Nano))
+	msg.Metadata.Set(MetadataLastSeenAt, lastSeen.UTC().Format(time.RFC3339Nano))
+}
@@ -19,6 +19,13 @@ type DLQEvent struct {
 	OriginalMsg *message.Message `json:"-"`
 	Stacktrace  string           `json:"stacktrace,omitempty"`
 	ServiceName string           `json:"service_name,omitempty"`
+
+	// Failure envelope, see Failure. Zero values are derived when building the message.
+	OriginalTopic string    `json:"original_topic,omitempty"`
+	Handler       string    `json:"handler,omitempty"`
+	Attempts      int       `json:"attempts,omitempty"`
+	FirstSeenAt   time.Time `json:"first_seen_at,omitzero"`
+	LastSeenAt    time.Time `json:"last_seen_at,omitzero"`
 }
 
 // BuildDLQMessage serializes the DLQEvent and enriches metadata to keep context.
@@ -44,6 +51,7 @@ func BuildDLQMessage(event DLQEvent) (*message.Message, error) {
 	msg.Metadata.Set("poison_stacktrace", event.Stacktrace)
 	msg.Metadata.Set("service_name", event.ServiceName)
 	msg.Metadata.Set("dlq_version", "1")
+	setFailureMetadata(msg, event)
 
 	return msg, nil
 }
@@ -73,6 +81,11 @@ func (event DLQEvent) MarshalJSON() ([]byte, error) {
 		Reason      string              `json:"reason"`
 		Stacktrace  string              `json:"stacktrace,omitempty"`
 		ServiceName string              `json:"service_name,omitempty"`
+		Topic       string              `json:"original_topic,omitempty"`
+		Handler     string              `json:"handler,omitempty"`
+		Attempts    int                 `json:"attempts,omitempty"`
+		FirstSeenAt time.Time           `json:"first_seen_at,omitzero"`
+		LastSeenAt  time.Time           `json:"last_seen_at,omitzero"`
 		Original    originalMessageJSON `json:"original_message"`
 	}
 
@@ -81,6 +94,11 @@ func (event DLQEvent) MarshalJSON() ([]byte, error) {
 		Reason:      event.Reason,
 		Stacktrace:  event.Stacktrace,
 		ServiceName: event.ServiceName,
+		Topic:       event.OriginalTopic,
+		Handler:     event.Handler,
+		Attempts:    event.Attempts,
+		FirstSeenAt: event.FirstSeenAt,
+		LastSeenAt:  event.LastSeenAt,
 		Original:    original,
 	})
 }
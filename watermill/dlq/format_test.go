@@ -58,3 +58,35 @@ func TestDLQEventJSONIncludesOriginalPayload(t *testing.T) {
 	require.JSONEq(t, `{"hello":"world"}`, string(payload.Original.Payload))
 	require.Equal(t, map[string]string(original.Metadata), payload.Original.Metadata)
 }
+
+func TestFailureFromMessage(t *testing.T) {
+	original := message.NewMessage("original-789", []byte(`{}`))
+	original.Metadata.Set("received_topic", "billing")
+
+	event := DLQEvent{
+		FailedAt:    time.Unix(30, 0).UTC(),
+		Reason:      "boom",
+		OriginalMsg: original,
+		Handler:     "billing_handler",
+		Attempts:    3,
+		FirstSeenAt: time.Unix(10, 0).UTC(),
+		LastSeenAt:  time.Unix(29, 0).UTC(),
+	}
+
+	msg, err := BuildDLQMessage(event)
+	require.NoError(t, err)
+
+	failure, err := FailureFromMessage(msg)
+	require.NoError(t, err)
+	require.Equal(t, Failure{
+		OriginalTopic: "billing",
+		Handler:       "billing_handler",
+		Error:         "boom",
+		Attempts:      3,
+		FirstSeenAt:   time.Unix(10, 0).UTC(),
+		LastSeenAt:    time.Unix(29, 0).UTC(),
+	}, failure)
+
+	_, err = FailureFromMessage(original)
+	require.ErrorIs(t, err, ErrNotDLQMessage)
+}
@@ -1,10 +1,12 @@
 package watermill
 
 import (
+	"context"
 	"log/slog"
 	"maps"
 
 	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
 
 	"github.com/shortlink-org/go-sdk/logger"
 )
@@ -12,6 +14,8 @@ import (
 type watermillLoggerAdapter struct {
 	log    logger.Logger
 	fields watermill.LogFields
+	// ctx, when set, routes logs through the *WithContext methods for trace correlation.
+	ctx context.Context //nolint:containedctx // LoggerAdapter methods take no context.
 }
 
 func NewWatermillLogger(log logger.Logger) watermill.LoggerAdapter {
@@ -22,6 +26,10 @@ func NewWatermillLogger(log logger.Logger) watermill.LoggerAdapter {
 }
 
 func (l *watermillLoggerAdapter) With(fields watermill.LogFields) watermill.LoggerAdapter {
+	return l.with(fields)
+}
+
+func (l *watermillLoggerAdapter) with(fields watermill.LogFields) *watermillLoggerAdapter {
 	// Merge new fields with existing ones
 	merged := make(watermill.LogFields, len(l.fields)+len(fields))
 	maps.Copy(merged, l.fields)
@@ -31,6 +39,7 @@ func (l *watermillLoggerAdapter) With(fields watermill.LogFields) watermill.Logg
 	return &watermillLoggerAdapter{
 		log:    l.log,
 		fields: merged,
+		ctx:    l.ctx,
 	}
 }
 
@@ -61,19 +70,86 @@ func (l *watermillLoggerAdapter) Error(msg string, err error, fields watermill.L
 		attrs = append(attrs, slog.Any("error", err))
 	}
 
+	if l.ctx != nil {
+		l.log.ErrorWithContext(l.ctx, msg, attrs...)
+
+		return
+	}
+
 	l.log.Error(msg, attrs...)
 }
 
 func (l *watermillLoggerAdapter) Info(msg string, fields watermill.LogFields) {
 	attrs := l.mergeFields(fields)
+
+	if l.ctx != nil {
+		l.log.InfoWithContext(l.ctx, msg, attrs...)
+
+		return
+	}
+
 	l.log.Info(msg, attrs...)
 }
 
 func (l *watermillLoggerAdapter) Debug(msg string, fields watermill.LogFields) {
 	attrs := l.mergeFields(fields)
+
+	if l.ctx != nil {
+		l.log.DebugWithContext(l.ctx, msg, attrs...)
+
+		return
+	}
+
 	l.log.Debug(msg, attrs...)
 }
 
 func (l *watermillLoggerAdapter) Trace(msg string, fields watermill.LogFields) {
 	l.Debug(msg, fields)
 }
+
+// ContextualLogger is a watermill.LoggerAdapter bound to a context.
+// Log lines carry the traceID/spanID of the span in that context, so Watermill
+// logs correlate with traces.
+type ContextualLogger struct {
+	*watermillLoggerAdapter
+}
+
+// NewContextualLogger creates a ContextualLogger bound to context.Background().
+// Use WithContext or ForMessage to bind it to a traced context.
+func NewContextualLogger(log logger.Logger) *ContextualLogger {
+	return &ContextualLogger{
+		watermillLoggerAdapter: &watermillLoggerAdapter{
+			log:    log,
+			fields: make(watermill.LogFields),
+			ctx:    context.Background(),
+		},
+	}
+}
+
+// With returns a ContextualLogger with extra fields and the same context.
+func (l *ContextualLogger) With(fields watermill.LogFields) watermill.LoggerAdapter {
+	return &ContextualLogger{watermillLoggerAdapter: l.with(fields)}
+}
+
+// WithContext returns a copy of the logger bound to ctx.
+func (l *ContextualLogger) WithContext(ctx context.Context) *ContextualLogger {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	adapter := l.with(nil)
+	adapter.ctx = ctx
+
+	return &ContextualLogger{watermillLoggerAdapter: adapter}
+}
+
+// ForMessage returns a copy of the logger bound to the message context,
+// falling back to the trace carried in the message metadata (see InjectTrace).
+func (l *ContextualLogger) ForMessage(msg *message.Message) *ContextualLogger {
+	ctx := msg.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return l.WithContext(ExtractTrace(ctx, msg))
+}
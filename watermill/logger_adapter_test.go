@@ -0,0 +1,66 @@
+package watermill
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/shortlink-org/go-sdk/logger"
+)
+
+const testTraceID = "0102030405060708090a0b0c0d0e0f10"
+
+func newTestContextualLogger(t *testing.T) (*ContextualLogger, *bytes.Buffer) {
+	t.Helper()
+
+	var buffer bytes.Buffer
+
+	log, err := logger.New(logger.Configuration{Writer: &buffer, Level: logger.INFO_LEVEL})
+	require.NoError(t, err)
+
+	return NewContextualLogger(log), &buffer
+}
+
+func tracedContext(t *testing.T) context.Context {
+	t.Helper()
+
+	traceID, err := trace.TraceIDFromHex(testTraceID)
+	require.NoError(t, err)
+
+	spanID, err := trace.SpanIDFromHex("0102030405060708")
+	require.NoError(t, err)
+
+	return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+}
+
+func TestContextualLoggerIncludesTraceID(t *testing.T) {
+	log, buffer := newTestContextualLogger(t)
+
+	var adapter watermill.LoggerAdapter = log.WithContext(tracedContext(t))
+
+	adapter.With(watermill.LogFields{"topic": "orders"}).Info("message handled", nil)
+
+	require.Contains(t, buffer.String(), testTraceID)
+	require.Contains(t, buffer.String(), `"topic":"orders"`)
+}
+
+func TestContextualLoggerForMessage(t *testing.T) {
+	log, buffer := newTestContextualLogger(t)
+
+	msg := message.NewMessage("1", nil)
+	InjectTrace(tracedContext(t), msg)
+	msg.SetContext(context.Background())
+
+	log.ForMessage(msg).Info("message received", nil)
+
+	require.Contains(t, buffer.String(), testTraceID)
+}
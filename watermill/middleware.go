@@ -8,6 +8,7 @@ import (
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
 	wmmid "github.com/ThreeDotsLabs/watermill/message/router/middleware"
+	"github.com/sony/gobreaker"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
@@ -17,7 +18,115 @@ import (
 
 // ----------- BASE MIDDLEWARE (panic, correlation, retry) ------------
 
-func configureBaseMiddlewares(router *message.Router, log logger.Logger, wmLogger watermill.LoggerAdapter, opts Options) {
+// baseMetrics counts what the base middleware does to messages; a nil *baseMetrics records nothing.
+type baseMetrics struct {
+	retries       metric.Int64Counter
+	poisoned      metric.Int64Counter
+	breakerStates metric.Int64Counter
+}
+
+func newBaseMetrics(log logger.Logger, provider metric.MeterProvider) (*baseMetrics, error) {
+	m := provider.Meter("watermill")
+
+	retries, err := m.Int64Counter(
+		"watermill_handler_retries_total",
+		metric.WithDescription("Total number of handler retries attempted by the retry middleware"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		log.Error("Failed to create retries counter metric", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	poisoned, err := m.Int64Counter(
+		"watermill_messages_poisoned_total",
+		metric.WithDescription("Total number of messages sent to the poison queue (DLQ)"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		log.Error("Failed to create poisoned counter metric", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	breakerStates, err := m.Int64Counter(
+		"watermill_circuit_breaker_state_changes_total",
+		metric.WithDescription("Total number of circuit breaker state transitions"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		log.Error("Failed to create circuit breaker counter metric", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &baseMetrics{
+		retries:       retries,
+		poisoned:      poisoned,
+		breakerStates: breakerStates,
+	}, nil
+}
+
+// countRetries wraps the retry middleware and counts every attempt after the first.
+func (m *baseMetrics) countRetries(retry message.HandlerMiddleware) message.HandlerMiddleware {
+	if m == nil {
+		return retry
+	}
+
+	return func(h message.HandlerFunc) message.HandlerFunc {
+		return func(msg *message.Message) ([]*message.Message, error) {
+			attempts := 0
+
+			return retry(func(msg *message.Message) ([]*message.Message, error) {
+				attempts++
+				if attempts > 1 {
+					m.retries.Add(ensureContext(msg.Context()), 1, metric.WithAttributes(
+						attribute.String("topic", msg.Metadata.Get("received_topic")),
+					))
+				}
+
+				return h(msg)
+			})(msg)
+		}
+	}
+}
+
+// observeBreaker chains a state change counter onto the circuit breaker settings.
+func (m *baseMetrics) observeBreaker(settings gobreaker.Settings) gobreaker.Settings {
+	if m == nil {
+		return settings
+	}
+
+	next := settings.OnStateChange
+	settings.OnStateChange = func(name string, from, to gobreaker.State) {
+		m.breakerStates.Add(context.Background(), 1, metric.WithAttributes(
+			attribute.String("name", name),
+			attribute.String("from", from.String()),
+			attribute.String("to", to.String()),
+		))
+
+		if next != nil {
+			next(name, from, to)
+		}
+	}
+
+	return settings
+}
+
+func (m *baseMetrics) poisonedCounter() metric.Int64Counter {
+	if m == nil {
+		return nil
+	}
+
+	return m.poisoned
+}
+
+func configureBaseMiddlewares(
+	router *message.Router,
+	log logger.Logger,
+	wmLogger watermill.LoggerAdapter,
+	opts Options,
+	metrics *baseMetrics,
+	poison message.HandlerMiddleware,
+) {
 	router.AddMiddleware(wmmid.Recoverer)
 	router.AddMiddleware(wmmid.CorrelationID)
 
@@ -29,7 +138,7 @@ func configureBaseMiddlewares(router *message.Router, log logger.Logger, wmLogge
 	}
 
 	if opts.CircuitBreaker.Enabled {
-		cb := wmmid.NewCircuitBreaker(opts.CircuitBreaker.Settings)
+		cb := wmmid.NewCircuitBreaker(metrics.observeBreaker(opts.CircuitBreaker.Settings))
 		router.AddMiddleware(cb.Middleware)
 		log.Info("Configured circuit breaker middleware",
 			slog.String("name", opts.CircuitBreaker.Settings.Name),
@@ -38,6 +147,10 @@ func configureBaseMiddlewares(router *message.Router, log logger.Logger, wmLogge
 		)
 	}
 
+	if poison != nil {
+		router.AddMiddleware(poison)
+	}
+
 	if opts.Retry.Enabled {
 		retryMiddleware := wmmid.Retry{
 			MaxRetries:          opts.Retry.MaxRetries,
@@ -49,7 +162,7 @@ func configureBaseMiddlewares(router *message.Router, log logger.Logger, wmLogge
 			ResetContextOnRetry: opts.Retry.ResetContextOnRetry,
 			Logger:              wmLogger,
 		}
-		router.AddMiddleware(retryMiddleware.Middleware)
+		router.AddMiddleware(metrics.countRetries(retryMiddleware.Middleware))
 
 		log.Info("Configured retry middleware",
 			slog.Int("max_retries", opts.Retry.MaxRetries),
@@ -59,6 +172,11 @@ func configureBaseMiddlewares(router *message.Router, log logger.Logger, wmLogge
 			slog.Float64("jitter", opts.Retry.Jitter),
 		)
 	}
+
+	if poison != nil {
+		// Inside retry, so every attempt reaches the DLQ failure envelope.
+		router.AddMiddleware(countAttempts)
+	}
 }
 
 // -------------------- METRICS MIDDLEWARE ---------------------------
@@ -0,0 +1,91 @@
+package watermill
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	wmmid "github.com/ThreeDotsLabs/watermill/message/router/middleware"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/shortlink-org/go-sdk/logger"
+)
+
+func newTestBaseMetrics(t *testing.T) (*baseMetrics, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	log, err := logger.New(logger.Configuration{Writer: io.Discard})
+	require.NoError(t, err)
+
+	reader := sdkmetric.NewManualReader()
+
+	metrics, err := newBaseMetrics(log, sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+
+	return metrics, reader
+}
+
+func collectSums(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	sums := map[string]int64{}
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if data, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, dp := range data.DataPoints {
+					sums[m.Name] += dp.Value
+				}
+			}
+		}
+	}
+
+	return sums
+}
+
+func TestBaseMetricsCountsRetries(t *testing.T) {
+	metrics, reader := newTestBaseMetrics(t)
+
+	retry := wmmid.Retry{MaxRetries: 3, InitialInterval: time.Millisecond}
+
+	calls := 0
+	handler := metrics.countRetries(retry.Middleware)(func(msg *message.Message) ([]*message.Message, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("transient")
+		}
+
+		return nil, nil
+	})
+
+	msg := message.NewMessage("msg-id", []byte("{}"))
+	msg.Metadata.Set("received_topic", "orders")
+
+	_, err := handler(msg)
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+
+	require.Equal(t, int64(1), collectSums(t, reader)["watermill_handler_retries_total"])
+}
+
+func TestBaseMetricsCountsPoisonedMessages(t *testing.T) {
+	metrics, reader := newTestBaseMetrics(t)
+
+	mw := newShortlinkPoisonMiddleware(&poisonTestPublisher{}, "dlq.topic", metrics.poisonedCounter())
+	handler := mw(func(msg *message.Message) ([]*message.Message, error) {
+		return nil, errors.New("boom")
+	})
+
+	_, err := handler(message.NewMessage("msg-id", []byte("{}")))
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1), collectSums(t, reader)["watermill_messages_poisoned_total"])
+}
@@ -11,12 +11,34 @@ import (
 
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/ThreeDotsLabs/watermill/message/router/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 
 	"github.com/shortlink-org/go-sdk/watermill/dlq"
 )
 
 type originalMessageCtxKey struct{}
 
+type attemptsCtxKey struct{}
+
+// attemptTracker counts handler runs for one message. The poison middleware stores
+// it in the message context and countAttempts, placed inside retry, records each run.
+type attemptTracker struct {
+	count     int
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+func (t *attemptTracker) record() {
+	now := time.Now().UTC()
+	if t.count == 0 {
+		t.firstSeen = now
+	}
+
+	t.count++
+	t.lastSeen = now
+}
+
 var (
 	serviceNameOnce sync.Once
 	cachedService   string
@@ -24,6 +46,11 @@ var (
 
 // NewShortlinkPoisonMiddleware adapts Watermill's poison queue to Shortlink DLQ builder.
 func NewShortlinkPoisonMiddleware(publisher message.Publisher, dlqTopic string) message.HandlerMiddleware {
+	return newShortlinkPoisonMiddleware(publisher, dlqTopic, nil)
+}
+
+// newShortlinkPoisonMiddleware counts poisoned messages when poisoned is non-nil.
+func newShortlinkPoisonMiddleware(publisher message.Publisher, dlqTopic string, poisoned metric.Int64Counter) message.HandlerMiddleware {
 	if publisher == nil {
 		panic("watermill: poison middleware requires a publisher")
 	}
@@ -32,6 +59,7 @@ func NewShortlinkPoisonMiddleware(publisher message.Publisher, dlqTopic string)
 		topic:       dlqTopic,
 		publisher:   publisher,
 		serviceName: detectServiceName(),
+		poisoned:    poisoned,
 	}
 
 	poisonTopic := dlqTopic
@@ -47,14 +75,39 @@ func NewShortlinkPoisonMiddleware(publisher message.Publisher, dlqTopic string)
 	return func(h message.HandlerFunc) message.HandlerFunc {
 		return poisonMW(func(msg *message.Message) ([]*message.Message, error) {
 			ctx := ensureContext(msg.Context())
+
+			tracker := &attemptTracker{}
+			ctx = context.WithValue(ctx, attemptsCtxKey{}, tracker)
 			ctx = context.WithValue(ctx, originalMessageCtxKey{}, snapshotMessage(msg))
 			msg.SetContext(ctx)
 
-			return h(msg)
+			// Without countAttempts further in, the single run counts as the only attempt.
+			start := time.Now().UTC()
+			produced, err := h(msg)
+
+			if tracker.count == 0 {
+				tracker.count = 1
+				tracker.firstSeen = start
+				tracker.lastSeen = start
+			}
+
+			return produced, err
 		})
 	}
 }
 
+// countAttempts records each handler run on the attemptTracker of the poison
+// middleware. Place it inside the retry middleware so that retries are counted.
+func countAttempts(h message.HandlerFunc) message.HandlerFunc {
+	return func(msg *message.Message) ([]*message.Message, error) {
+		if tracker, ok := ensureContext(msg.Context()).Value(attemptsCtxKey{}).(*attemptTracker); ok {
+			tracker.record()
+		}
+
+		return h(msg)
+	}
+}
+
 func detectServiceName() string {
 	serviceNameOnce.Do(func() {
 		cachedService = os.Getenv("SERVICE_NAME")
@@ -89,6 +142,7 @@ type poisonPublisher struct {
 	topic       string
 	publisher   message.Publisher
 	serviceName string
+	poisoned    metric.Int64Counter
 }
 
 func (p *poisonPublisher) Publish(_ string, msgs ...*message.Message) error {
@@ -106,11 +160,19 @@ func (p *poisonPublisher) Publish(_ string, msgs ...*message.Message) error {
 		}
 
 		event := dlq.DLQEvent{
-			FailedAt:    time.Now().UTC(),
-			Reason:      poisoned.Metadata.Get(middleware.ReasonForPoisonedKey),
-			OriginalMsg: original,
-			Stacktrace:  string(debug.Stack()),
-			ServiceName: p.serviceName,
+			FailedAt:      time.Now().UTC(),
+			Reason:        poisoned.Metadata.Get(middleware.ReasonForPoisonedKey),
+			OriginalMsg:   original,
+			Stacktrace:    string(debug.Stack()),
+			ServiceName:   p.serviceName,
+			OriginalTopic: original.Metadata.Get("received_topic"),
+			Handler:       message.HandlerNameFromCtx(ctx),
+		}
+
+		if tracker, ok := ctx.Value(attemptsCtxKey{}).(*attemptTracker); ok {
+			event.Attempts = tracker.count
+			event.FirstSeenAt = tracker.firstSeen
+			event.LastSeenAt = tracker.lastSeen
 		}
 
 		if event.Reason == "" {
@@ -120,6 +182,12 @@ func (p *poisonPublisher) Publish(_ string, msgs ...*message.Message) error {
 		if err := dlq.PublishDLQ(ctx, p.publisher, targetTopic, event); err != nil {
 			return err
 		}
+
+		if p.poisoned != nil {
+			p.poisoned.Add(ctx, 1, metric.WithAttributes(
+				attribute.String("topic", original.Metadata.Get("received_topic")),
+			))
+		}
 	}
 
 	return nil
@@ -1,11 +1,19 @@
 package watermill
 
 import (
+	"context"
 	"errors"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
 	"github.com/stretchr/testify/require"
+
+	"github.com/shortlink-org/go-sdk/logger"
+
+	"github.com/shortlink-org/go-sdk/watermill/dlq"
 )
 
 type poisonTestPublisher struct {
@@ -66,3 +74,99 @@ func TestShortlinkPoisonMiddlewareDerivesTopicWhenEmpty(t *testing.T) {
 	require.Len(t, pub.published, 1)
 	require.Equal(t, "payments.DLQ", pub.published[0].topic)
 }
+
+func TestShortlinkPoisonMiddlewareSetsFailureEnvelope(t *testing.T) {
+	pub := &poisonTestPublisher{}
+	mw := NewShortlinkPoisonMiddleware(pub, "dlq.topic")
+
+	handler := mw(func(msg *message.Message) ([]*message.Message, error) {
+		return nil, errors.New("boom")
+	})
+
+	msg := message.NewMessage("msg-id", []byte(`{"foo":"bar"}`))
+	msg.Metadata.Set("received_topic", "orders")
+
+	before := time.Now().UTC()
+
+	_, err := handler(msg)
+	require.NoError(t, err)
+	require.Len(t, pub.published, 1)
+
+	failure, err := dlq.FailureFromMessage(pub.published[0].msg)
+	require.NoError(t, err)
+
+	require.Equal(t, "orders", failure.OriginalTopic)
+	require.Equal(t, "boom", failure.Error)
+	require.Equal(t, 1, failure.Attempts)
+	require.False(t, failure.FirstSeenAt.Before(before.Truncate(time.Microsecond)))
+	require.False(t, failure.LastSeenAt.Before(failure.FirstSeenAt))
+}
+
+type chanPublisher chan *message.Message
+
+func (p chanPublisher) Publish(_ string, msgs ...*message.Message) error {
+	for _, msg := range msgs {
+		p <- msg
+	}
+
+	return nil
+}
+
+func (chanPublisher) Close() error { return nil }
+
+func TestPoisonMiddlewareCountsRetriedAttempts(t *testing.T) {
+	const maxRetries = 2
+
+	log, err := logger.New(logger.Configuration{Writer: io.Discard})
+	require.NoError(t, err)
+
+	wmLogger := NewWatermillLogger(log)
+
+	router, err := message.NewRouter(message.RouterConfig{}, wmLogger)
+	require.NoError(t, err)
+
+	metrics, _ := newTestBaseMetrics(t)
+	dlqMessages := make(chanPublisher, 1)
+
+	opts := Options{Retry: RetryOptions{Enabled: true, MaxRetries: maxRetries, InitialInterval: time.Millisecond}}
+	configureBaseMiddlewares(router, log, wmLogger, opts, metrics,
+		newShortlinkPoisonMiddleware(dlqMessages, "dlq.topic", metrics.poisonedCounter()))
+
+	pubSub := gochannel.NewGoChannel(gochannel.Config{}, wmLogger)
+	t.Cleanup(func() { _ = pubSub.Close() })
+
+	calls := 0
+
+	router.AddConsumerHandler("orders-handler", "orders", pubSub, func(*message.Message) error {
+		calls++
+
+		return errors.New("boom")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go func() { _ = router.Run(ctx) }()
+
+	<-router.Running()
+
+	require.NoError(t, pubSub.Publish("orders", message.NewMessage("msg-id", []byte("{}"))))
+
+	var dlqMsg *message.Message
+
+	select {
+	case dlqMsg = <-dlqMessages:
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not dead-lettered")
+	}
+
+	require.NoError(t, router.Close())
+
+	failure, err := dlq.FailureFromMessage(dlqMsg)
+	require.NoError(t, err)
+
+	require.Equal(t, maxRetries+1, calls)
+	require.Equal(t, maxRetries+1, failure.Attempts)
+	require.Equal(t, "orders-handler", failure.Handler)
+	require.True(t, failure.LastSeenAt.After(failure.FirstSeenAt))
+}
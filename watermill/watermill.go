@@ -64,16 +64,13 @@ func New(
 		opt(&optsCfg)
 	}
 
-	// Global middleware (panic, retry, correlation, timeout, circuit breaker)
-	configureBaseMiddlewares(router, log, wmLogger, optsCfg)
-	cfg.SetDefault("WATERMILL_DLQ_ENABLED", false)
-	cfg.SetDefault("WATERMILL_DLQ_TOPIC", "")
+	baseMetrics, err := newBaseMetrics(log, meterProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create base middleware metrics: %w", err)
+	}
 
-	// OTEL tracing middleware
 	otelMW := NewOTELMiddleware(tracerProvider)
-	router.AddMiddleware(otelMW.HandlerMiddleware())
 
-	// OTEL metrics / exemplars middleware
 	metricsMW, err := NewMetricsMiddleware(log, meterProvider)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create metrics middleware: %w", err)
@@ -81,11 +78,23 @@ func New(
 
 	publisher := metricsMW.PublisherWrapper(backend.Publisher(), otelMW)
 
+	cfg.SetDefault("WATERMILL_DLQ_ENABLED", false)
+	cfg.SetDefault("WATERMILL_DLQ_TOPIC", "")
+
+	// The poison middleware wraps retry, so a message is dead-lettered only once retries are exhausted.
+	var poison message.HandlerMiddleware
 	if cfg.GetBool("WATERMILL_DLQ_ENABLED") {
 		dlqTopic := cfg.GetString("WATERMILL_DLQ_TOPIC")
-		router.AddMiddleware(NewShortlinkPoisonMiddleware(publisher, dlqTopic))
+		poison = newShortlinkPoisonMiddleware(publisher, dlqTopic, baseMetrics.poisonedCounter())
 	}
 
+	// Global middleware (panic, retry, correlation, timeout, circuit breaker, DLQ)
+	configureBaseMiddlewares(router, log, wmLogger, optsCfg, baseMetrics, poison)
+
+	// OTEL tracing middleware
+	router.AddMiddleware(otelMW.HandlerMiddleware())
+
+	// OTEL metrics / exemplars middleware
 	router.AddMiddleware(metricsMW.HandlerMiddleware())
 
 	client := &Client{